@@ -5,9 +5,13 @@ import (
 	"HomeApplianceStore/internal/services"
 	"HomeApplianceStore/pkg"
 	"HomeApplianceStore/pkg/gen"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
 	"github.com/go-chi/chi/v5"
@@ -36,13 +40,84 @@ func main() {
 	queries := gen.New(db)
 
 	accountService := services.AccountService{Queries: *queries}
-	employeeService := services.EmployeeService{Queries: *queries}
+	employeeService := services.EmployeeService{Queries: *queries, DB: db}
 	roleService := &services.RoleService{Queries: queries}
-	customerService := services.CustomerService{Queries: *queries}
+	customerService := services.CustomerService{Queries: *queries, DB: db}
 	goodsService := services.GoodsService{Queries: *queries}
+	goodsImportService := services.GoodsImportService{Queries: *queries, DB: db}
+	exportService := services.ExportService{Queries: *queries, DB: db}
+	taxRateService := services.TaxRateService{Queries: *queries}
+	categoryService := services.CategoryService{Queries: *queries}
 	storeService := services.StoreService{Queries: *queries}
 	supplierService := services.SupplierService{Queries: *queries}
 	goodsSupplierService := services.GoodsSupplierService{Queries: *queries}
+	reservationService := services.ReservationService{Queries: *queries, DB: db}
+	orderService := services.OrderService{Queries: *queries, DB: db}
+	deliveryService := services.DeliveryService{Queries: *queries, DB: db}
+	serviceCatalogService := services.ServiceCatalogService{Queries: *queries}
+	technicianBookingService := services.TechnicianBookingService{Queries: *queries, DB: db}
+	shiftService := services.ShiftService{Queries: *queries, DB: db}
+	commissionRuleService := services.CommissionRuleService{Queries: *queries}
+	registerService := services.RegisterService{Queries: *queries, DB: db}
+	stocktakeService := services.StocktakeService{Queries: *queries, DB: db}
+	writeOffService := services.WriteOffService{Queries: *queries, DB: db}
+	purchaseOrderService := services.PurchaseOrderService{Queries: *queries, DB: db}
+	replenishmentService := services.ReplenishmentService{Queries: *queries, DB: db}
+	forecastService := services.ForecastService{Queries: *queries}
+	goodsReceiptService := services.GoodsReceiptService{Queries: *queries, DB: db}
+	supplierClaimService := services.SupplierClaimService{Queries: *queries, DB: db}
+	reportService := services.ReportService{Queries: *queries}
+
+	// Без PAYMENT_GATEWAY_URL оплаты проходят через локальный фейковый эквайер.
+	// С настоящим шлюзом секрет обязателен: иначе webhook подписывался бы общеизвестным ключом
+	paymentSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	gatewayURL := os.Getenv("PAYMENT_GATEWAY_URL")
+	var paymentProvider services.PaymentProvider
+	var fakePaymentProvider *services.FakePaymentProvider
+	if gatewayURL != "" {
+		if paymentSecret == "" {
+			log.Fatal("PAYMENT_WEBHOOK_SECRET is required when PAYMENT_GATEWAY_URL is set")
+		}
+		paymentProvider = services.HTTPPaymentProvider{BaseURL: gatewayURL, Secret: paymentSecret, Client: http.DefaultClient}
+	} else {
+		if paymentSecret == "" {
+			paymentSecret = "local-payment-secret"
+		}
+		fakePaymentProvider = services.NewFakePaymentProvider(paymentSecret)
+		paymentProvider = fakePaymentProvider
+	}
+	paymentService := services.PaymentService{Queries: *queries, DB: db, Provider: paymentProvider}
+	refundService := services.RefundService{Queries: *queries, DB: db, Provider: paymentProvider}
+	giftCardService := services.GiftCardService{Queries: *queries}
+
+	// Реквизиты продавца для чеков. Без настоящей ККТ чеки регистрирует эмулятор
+	fiscalOrganization := services.FiscalOrganization{
+		Name:      os.Getenv("FISCAL_COMPANY_NAME"),
+		Inn:       os.Getenv("FISCAL_INN"),
+		TaxSystem: services.FiscalTaxSystemGeneral,
+	}
+	if fiscalOrganization.Inn == "" {
+		fiscalOrganization.Name, fiscalOrganization.Inn = "Home Appliance Store", "0000000000"
+	}
+	fiscalRegistrar := services.NewFiscalRegistrarEmulator("9999078900000001", "fiscal-emulator-key")
+	receiptService := services.ReceiptService{
+		Queries:      *queries,
+		DB:           db,
+		Registrar:    fiscalRegistrar,
+		Organization: fiscalOrganization,
+	}
+
+	reservationService.StartSweeper(context.Background(), time.Minute)
+	receiptService.StartWorker(context.Background(), 10*time.Second)
+	refundService.StartWorker(context.Background(), time.Minute)
+	replenishmentService.StartScanner(context.Background(), time.Hour)
+
+	// Файлы обмена с 1С хранятся до конца сессии обмена
+	exchangeDir := os.Getenv("ONEC_EXCHANGE_DIR")
+	if exchangeDir == "" {
+		exchangeDir = filepath.Join(os.TempDir(), "1c-exchange")
+	}
+	exchangeService := services.NewExchangeService(*queries, db, exchangeDir)
 
 	r := chi.NewRouter()
 
@@ -70,11 +145,36 @@ func main() {
 	r.Mount("/accounts", routes.NewAccountRouter(accountService))
 	r.Mount("/employees", routes.NewEmployeeRouter(employeeService))
 	r.Mount("/roles", routes.NewRoleRouter(roleService))
-	r.Mount("/customers", routes.NewCustomerRouter(customerService))
-	r.Mount("/goods", routes.NewGoodsRouter(goodsService))
-	r.Mount("/stores", routes.NewStoreRouter(storeService))
-	r.Mount("/suppliers", routes.NewSupplierRouter(supplierService))
+	r.Mount("/customers", routes.NewCustomerRouter(customerService, exportService))
+	r.Mount("/goods", routes.NewGoodsRouter(goodsService, forecastService, goodsImportService, exportService))
+	r.Mount("/tax-rates", routes.NewTaxRateRouter(taxRateService))
+	r.Mount("/categories", routes.NewCategoryRouter(categoryService))
+	r.Mount("/stores", routes.NewStoreRouter(storeService, exportService))
+	r.Mount("/suppliers", routes.NewSupplierRouter(supplierService, exportService))
 	r.Mount("/goods-suppliers", routes.NewGoodsSupplierRouter(goodsSupplierService))
+	r.Mount("/reservations", routes.NewReservationRouter(reservationService))
+	r.Mount("/orders", routes.NewOrderRouter(orderService))
+	r.Mount("/payments", routes.NewPaymentRouter(paymentService))
+	r.Mount("/refunds", routes.NewRefundRouter(refundService))
+	r.Mount("/gift-cards", routes.NewGiftCardRouter(giftCardService))
+	r.Mount("/receipts", routes.NewReceiptRouter(receiptService))
+	r.Mount("/deliveries", routes.NewDeliveryRouter(deliveryService))
+	r.Mount("/services", routes.NewServiceRouter(serviceCatalogService))
+	r.Mount("/technician-bookings", routes.NewTechnicianBookingRouter(technicianBookingService))
+	r.Mount("/shifts", routes.NewShiftRouter(shiftService))
+	r.Mount("/commission-rules", routes.NewCommissionRuleRouter(commissionRuleService))
+	r.Mount("/registers", routes.NewRegisterRouter(registerService))
+	r.Mount("/stocktakes", routes.NewStocktakeRouter(stocktakeService))
+	r.Mount("/write-offs", routes.NewWriteOffRouter(writeOffService))
+	r.Mount("/purchase-orders", routes.NewPurchaseOrderRouter(purchaseOrderService))
+	r.Mount("/replenishment", routes.NewReplenishmentRouter(replenishmentService))
+	r.Mount("/goods-receipts", routes.NewGoodsReceiptRouter(goodsReceiptService))
+	r.Mount("/supplier-claims", routes.NewSupplierClaimRouter(supplierClaimService))
+	r.Mount("/reports", routes.NewReportRouter(reportService))
+	r.Mount("/1c-exchange", routes.NewExchangeRouter(exchangeService))
+	if fakePaymentProvider != nil {
+		r.Mount("/fake-acquirer", routes.NewFakeAcquirerRouter(fakePaymentProvider, paymentService))
+	}
 
 	log.Println("Server started at :8080")
 	http.ListenAndServe(":8080", r)
@@ -24,6 +24,130 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/1c-exchange": {
+            "get": {
+                "description": "Точка обмена для 1С: Управление торговлей и похожих конфигураций. Режим выбирается параметрами type и mode:\ncheckauth — вход по Basic-авторизации сотрудника с правом exchange.1c, возвращает cookie сессии;\ninit — начало обмена, возвращает zip=no и file_limit; file — приём файла частями в теле запроса, часть больше file_limit отклоняется целиком;\nimport (type=catalog) — загрузка import.xml (категории и товары) или offers.xml (цены);\nquery (type=sale) — оплаченные заказы, ещё не принятые 1С; success (type=sale) — подтверждение приёма заказов.\nТовары и категории сопоставляются по Ид 1С, а до первого обмена — по артикулу и названию. Остатки из 1С не загружаются.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "exchange"
+                ],
+                "summary": "Обмен с 1С по CommerceML 2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "catalog или sale",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "checkauth, init, file, import, query или success",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла для mode=file и mode=import",
+                        "name": "filename",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Точка обмена для 1С: Управление торговлей и похожих конфигураций. Режим выбирается параметрами type и mode:\ncheckauth — вход по Basic-авторизации сотрудника с правом exchange.1c, возвращает cookie сессии;\ninit — начало обмена, возвращает zip=no и file_limit; file — приём файла частями в теле запроса, часть больше file_limit отклоняется целиком;\nimport (type=catalog) — загрузка import.xml (категории и товары) или offers.xml (цены);\nquery (type=sale) — оплаченные заказы, ещё не принятые 1С; success (type=sale) — подтверждение приёма заказов.\nТовары и категории сопоставляются по Ид 1С, а до первого обмена — по артикулу и названию. Остатки из 1С не загружаются.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "exchange"
+                ],
+                "summary": "Обмен с 1С по CommerceML 2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "catalog или sale",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "checkauth, init, file, import, query или success",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла для mode=file и mode=import",
+                        "name": "filename",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/accounts": {
             "get": {
                 "description": "Возвращает все аккаунты",
@@ -207,39 +331,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/customers": {
+        "/categories": {
             "get": {
-                "description": "Возвращает всех клиентов",
+                "description": "Возвращает все действующие категории",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "customers"
+                    "categories"
                 ],
-                "summary": "Получить список клиентов",
+                "summary": "Получить список категорий",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.CustomerDto"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                                "$ref": "#/definitions/services.CategoryDto"
                             }
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Создаёт нового клиента",
+            "put": {
+                "description": "Обновляет название и ставку НДС категории",
                 "consumes": [
                     "application/json"
                 ],
@@ -247,137 +362,118 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "customers"
+                    "categories"
                 ],
-                "summary": "Создать клиента",
+                "summary": "Обновить категорию",
                 "parameters": [
                     {
-                        "description": "Данные для создания клиента",
-                        "name": "customer",
+                        "description": "Данные для обновления категории",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateCustomerDto"
+                            "$ref": "#/definitions/services.UpdateCategoryDto"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.CustomerDto"
+                            "$ref": "#/definitions/services.CategoryDto"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/customers/{id}": {
-            "get": {
-                "description": "Возвращает клиента по идентификатору",
+            },
+            "post": {
+                "description": "Создаёт категорию товаров. Ставка НДС категории действует для товаров без своей ставки",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "customers"
+                    "categories"
                 ],
-                "summary": "Получить клиента по id",
+                "summary": "Создать категорию",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID клиента",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные категории",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateCategoryDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/services.CustomerDto"
+                            "$ref": "#/definitions/services.CategoryDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные клиента по id",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Возвращает категорию по идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "customers"
+                    "categories"
                 ],
-                "summary": "Обновить клиента",
+                "summary": "Получить категорию по id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID клиента",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для обновления клиента",
-                        "name": "customer",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.UpdateCustomerDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.CustomerDto"
+                            "$ref": "#/definitions/services.CategoryDto"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Удаляет клиента по id",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Помечает категорию удалённой",
                 "tags": [
-                    "customers"
+                    "categories"
                 ],
-                "summary": "Удалить клиента",
+                "summary": "Удалить категорию",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID клиента",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -386,43 +482,78 @@ const docTemplate = `{
                 "responses": {
                     "204": {
                         "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/employees": {
+        "/commission-rules": {
             "get": {
-                "description": "Возвращает всех сотрудников",
+                "description": "Возвращает все действующие правила комиссии",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "employees"
+                    "commissions"
                 ],
-                "summary": "Получить список сотрудников",
+                "summary": "Получить правила комиссии",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.EmployeeDto"
+                                "$ref": "#/definitions/services.CommissionRuleDto"
                             }
                         }
                     }
                 }
             },
+            "put": {
+                "description": "Обновляет роль, категорию и ставку правила",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "commissions"
+                ],
+                "summary": "Обновить правило комиссии",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления правила",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateCommissionRuleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CommissionRuleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Создаёт нового сотрудника",
+                "description": "Задаёт ставку комиссии продавцов для роли, категории или их сочетания. Более точное правило имеет приоритет",
                 "consumes": [
                     "application/json"
                 ],
@@ -430,17 +561,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employees"
+                    "commissions"
                 ],
-                "summary": "Создать сотрудника",
+                "summary": "Создать правило комиссии",
                 "parameters": [
                     {
-                        "description": "Данные для создания сотрудника",
-                        "name": "employee",
+                        "description": "Данные правила",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateEmployeeRequest"
+                            "$ref": "#/definitions/services.CreateCommissionRuleDto"
                         }
                     }
                 ],
@@ -448,35 +579,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/services.EmployeeDto"
+                            "$ref": "#/definitions/services.CommissionRuleDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/employees/{id}": {
+        "/commission-rules/{id}": {
             "get": {
-                "description": "Возвращает сотрудника по идентификатору",
+                "description": "Возвращает правило комиссии по идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "employees"
+                    "commissions"
                 ],
-                "summary": "Получить сотрудника по id",
+                "summary": "Получить правило комиссии",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID сотрудника",
+                        "description": "ID правила",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -486,55 +614,57 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.EmployeeDto"
+                            "$ref": "#/definitions/services.CommissionRuleDto"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Обновляет данные сотрудника по id",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Помечает правило удалённым",
                 "tags": [
-                    "employees"
+                    "commissions"
                 ],
-                "summary": "Обновить сотрудника",
+                "summary": "Удалить правило комиссии",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID сотрудника",
+                        "description": "ID правила",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для обновления сотрудника",
-                        "name": "employee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.UpdateEmployeeDto"
-                        }
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/customers": {
+            "get": {
+                "description": "Возвращает всех клиентов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Получить список клиентов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.EmployeeDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.CustomerDto"
+                            }
                         }
                     },
                     "400": {
@@ -548,27 +678,35 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Удаляет сотрудника по id",
+            "post": {
+                "description": "Создаёт нового клиента",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "employees"
+                    "customers"
                 ],
-                "summary": "Удалить сотрудника",
+                "summary": "Создать клиента",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID сотрудника",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для создания клиента",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateCustomerDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -582,24 +720,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods": {
+        "/customers/export": {
             "get": {
-                "description": "Возвращает все товары",
+                "description": "Выгружает те же записи, что возвращает список сущности, читая их из базы страницами по 1000 строк. CSV и JSON Lines отдаются по мере чтения, XLSX — целиком после формирования книги",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "goods"
+                    "export"
+                ],
+                "summary": "Выгрузка в CSV, XLSX или JSON Lines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (по умолчанию), xlsx или jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить список товаров",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/services.GoodDto"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -609,47 +754,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные товара",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/customers/{id}": {
+            "get": {
+                "description": "Возвращает клиента по идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "customers"
                 ],
-                "summary": "Обновить товар",
+                "summary": "Получить клиента по id",
                 "parameters": [
                     {
-                        "description": "Данные для обновления товара",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.UpdateGoodDto"
-                        }
+                        "type": "integer",
+                        "description": "ID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.GoodDto"
+                            "$ref": "#/definitions/services.CustomerDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Создаёт новый товар",
+            "put": {
+                "description": "Обновляет данные клиента по id",
                 "consumes": [
                     "application/json"
                 ],
@@ -657,91 +802,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "customers"
                 ],
-                "summary": "Создать новый товар",
+                "summary": "Обновить клиента",
                 "parameters": [
                     {
-                        "description": "Данные товара",
-                        "name": "input",
+                        "type": "integer",
+                        "description": "ID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления клиента",
+                        "name": "customer",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateGoodDto"
+                            "$ref": "#/definitions/services.UpdateCustomerDto"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.GoodDto"
+                            "$ref": "#/definitions/services.CustomerDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/goods-supplier": {
-            "post": {
-                "description": "Создаёт новую связь между товаром и поставщиком",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Удаляет клиента по id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods-supplier"
+                    "customers"
                 ],
-                "summary": "Создать связь между товаром и поставщиком",
+                "summary": "Удалить клиента",
                 "parameters": [
                     {
-                        "description": "Данные для создания связи",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.CreateGoodsSupplierDto"
-                        }
+                        "type": "integer",
+                        "description": "ID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/services.CreateGoodsSupplierDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/goods-supplier/by_good_id/{id}": {
+        "/customers/{id}/addresses": {
             "get": {
-                "description": "Возвращает поставщиков, связанных с товаром",
+                "description": "Возвращает книгу адресов клиента, адрес по умолчанию первым",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods-supplier"
+                    "customers"
                 ],
-                "summary": "Получить поставщиков по товару",
+                "summary": "Получить адреса клиента",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID товара",
-                        "name": "good_id",
+                        "description": "ID клиента",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -752,7 +900,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.SupplierDto"
+                                "$ref": "#/definitions/services.CustomerAddressDto"
                             }
                         }
                     },
@@ -762,6 +910,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -769,35 +923,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/goods-supplier/by_supplier_id/{id}": {
-            "get": {
-                "description": "Возвращает товары, связанные с поставщиком",
+            },
+            "post": {
+                "description": "Добавляет адрес в книгу адресов клиента. Первый адрес автоматически становится адресом по умолчанию",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods-supplier"
+                    "customers"
                 ],
-                "summary": "Получить товары по поставщику",
+                "summary": "Добавить адрес клиента",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID поставщика",
-                        "name": "supplier_id",
+                        "description": "ID клиента",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Адрес",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateCustomerAddressDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/services.GoodDto"
-                            }
+                            "$ref": "#/definitions/services.CustomerAddressDto"
                         }
                     },
                     "400": {
@@ -806,6 +967,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -815,28 +982,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods-supplier/{id}": {
-            "delete": {
-                "description": "Удаляет связь между товаром и поставщиком по идентификатору",
+        "/customers/{id}/addresses/{addressId}": {
+            "get": {
+                "description": "Возвращает адрес из книги адресов клиента",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods-supplier"
+                    "customers"
                 ],
-                "summary": "Удалить связь между товаром и поставщиком",
+                "summary": "Получить адрес клиента",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID связи",
+                        "description": "ID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID адреса",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerAddressDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -844,6 +1021,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -851,32 +1034,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/goods/{id}": {
-            "get": {
-                "description": "Возвращает товар по идентификатору",
+            },
+            "put": {
+                "description": "Обновляет адрес из книги адресов клиента",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "customers"
                 ],
-                "summary": "Получить товар по id",
+                "summary": "Обновить адрес клиента",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID товара",
+                        "description": "ID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID адреса",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Адрес",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateCustomerAddressDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.GoodDto"
+                            "$ref": "#/definitions/services.CustomerAddressDto"
                         }
                     },
                     "400": {
@@ -884,64 +1084,160 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Удаляет товар по идентификатору",
+                "description": "Удаляет адрес из книги адресов клиента. Если он был адресом по умолчанию, им становится следующий адрес",
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Удалить адрес клиента",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID адреса",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}/addresses/{addressId}/default": {
+            "post": {
+                "description": "Помечает адрес как адрес по умолчанию, снимая отметку с остальных адресов клиента",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "customers"
                 ],
-                "summary": "Удалить товар",
+                "summary": "Сделать адрес адресом по умолчанию",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID товара",
+                        "description": "ID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID адреса",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CustomerAddressDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/roles": {
+        "/deliveries": {
             "get": {
-                "description": "Возвращает все роли",
+                "description": "Возвращает все доставки, новые первыми, или доставки одного заказа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "deliveries"
+                ],
+                "summary": "Получить список доставок",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "order_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить список ролей",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.RoleDto"
+                                "$ref": "#/definitions/services.DeliveryDto"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "Создаёт новую роль",
+                "description": "Бронирует место в слоте доставки магазина заказа",
                 "consumes": [
                     "application/json"
                 ],
@@ -949,17 +1245,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "deliveries"
                 ],
-                "summary": "Создать роль",
+                "summary": "Оформить доставку",
                 "parameters": [
                     {
-                        "description": "Данные для создания роли",
-                        "name": "role",
+                        "description": "Данные доставки",
+                        "name": "delivery",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateRoleDto"
+                            "$ref": "#/definitions/services.CreateDeliveryDto"
                         }
                     }
                 ],
@@ -967,124 +1263,103 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/services.RoleDto"
+                            "$ref": "#/definitions/services.DeliveryDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/roles/{id}": {
+        "/deliveries/couriers/{id}/route": {
             "get": {
-                "description": "Возвращает роль по идентификатору",
+                "description": "Доставки курьера на день в порядке слотов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "deliveries"
                 ],
-                "summary": "Получить роль по id",
+                "summary": "Маршрутный лист курьера",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID роли",
+                        "description": "ID сотрудника-курьера",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.RoleDto"
+                            "$ref": "#/definitions/services.CourierRouteDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные роли по id",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/deliveries/slots": {
+            "get": {
+                "description": "Возвращает слоты магазина за период с числом занятых и свободных мест",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "deliveries"
                 ],
-                "summary": "Обновить роль",
+                "summary": "Получить слоты доставки магазина",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID роли",
-                        "name": "id",
-                        "in": "path",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления роли",
-                        "name": "role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.CreateRoleDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/services.RoleDto"
-                        }
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/stores": {
-            "get": {
-                "description": "Возвращает все магазины",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "stores"
                 ],
-                "summary": "Получить список магазинов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.StoreDto"
+                                "$ref": "#/definitions/services.DeliverySlotDto"
                             }
                         }
                     },
@@ -1097,7 +1372,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создаёт новый магазин",
+                "description": "Создаёт интервал доставки магазина с ограничением по числу доставок",
                 "consumes": [
                     "application/json"
                 ],
@@ -1105,17 +1380,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stores"
+                    "deliveries"
                 ],
-                "summary": "Создать магазин",
+                "summary": "Создать слот доставки",
                 "parameters": [
                     {
-                        "description": "Данные для создания магазина",
-                        "name": "store",
+                        "description": "Данные слота",
+                        "name": "slot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateStoreDto"
+                            "$ref": "#/definitions/services.CreateDeliverySlotDto"
                         }
                     }
                 ],
@@ -1123,7 +1398,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/services.StoreDto"
+                            "$ref": "#/definitions/services.DeliverySlotDto"
                         }
                     },
                     "400": {
@@ -1135,20 +1410,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/stores/{id}": {
+        "/deliveries/{id}": {
             "get": {
-                "description": "Возвращает магазин по идентификатору",
+                "description": "Возвращает доставку по идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stores"
+                    "deliveries"
                 ],
-                "summary": "Получить магазин по id",
+                "summary": "Получить доставку по id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID магазина",
+                        "description": "ID доставки",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1158,19 +1433,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.StoreDto"
+                            "$ref": "#/definitions/services.DeliveryDto"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные магазина по id",
+            }
+        },
+        "/deliveries/{id}/courier": {
+            "post": {
+                "description": "Назначает сотрудника курьером доставки, пока она не выехала",
                 "consumes": [
                     "application/json"
                 ],
@@ -1178,24 +1455,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stores"
+                    "deliveries"
                 ],
-                "summary": "Обновить магазин",
+                "summary": "Назначить курьера",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID магазина",
+                        "description": "ID доставки",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления магазина",
-                        "name": "store",
+                        "description": "Курьер",
+                        "name": "courier",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.UpdateStoreDto"
+                            "$ref": "#/definitions/services.AssignCourierDto"
                         }
                     }
                 ],
@@ -1203,7 +1480,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.StoreDto"
+                            "$ref": "#/definitions/services.DeliveryDto"
                         }
                     },
                     "400": {
@@ -1211,33 +1488,56 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет магазин по id",
-                "produces": [
-                    "application/json"
+            }
+        },
+        "/deliveries/{id}/status": {
+            "post": {
+                "description": "scheduled → out_for_delivery → delivered; из scheduled и out_for_delivery можно перейти в failed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "stores"
+                    "deliveries"
                 ],
-                "summary": "Удалить магазин",
+                "summary": "Изменить статус доставки",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID магазина",
+                        "description": "ID доставки",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateDeliveryStatusDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.DeliveryDto"
+                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "string"
                         }
@@ -1245,36 +1545,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/suppliers": {
+        "/employees": {
             "get": {
-                "description": "Возвращает всех поставщиков",
+                "description": "Возвращает всех сотрудников",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "employees"
                 ],
-                "summary": "Получить список поставщиков",
+                "summary": "Получить список сотрудников",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/services.SupplierDto"
+                                "$ref": "#/definitions/services.EmployeeDto"
                             }
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
             },
             "post": {
-                "description": "Создаёт нового поставщика",
+                "description": "Создаёт нового сотрудника",
                 "consumes": [
                     "application/json"
                 ],
@@ -1282,17 +1576,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "employees"
                 ],
-                "summary": "Создать поставщика",
+                "summary": "Создать сотрудника",
                 "parameters": [
                     {
-                        "description": "Данные для создания поставщика",
-                        "name": "supplier",
+                        "description": "Данные для создания сотрудника",
+                        "name": "employee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateSupplierDto"
+                            "$ref": "#/definitions/services.CreateEmployeeRequest"
                         }
                     }
                 ],
@@ -1300,32 +1594,35 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/services.SupplierDto"
+                            "$ref": "#/definitions/services.EmployeeDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/suppliers/{id}": {
+        "/employees/{id}": {
             "get": {
-                "description": "Возвращает поставщика по идентификатору",
+                "description": "Возвращает сотрудника по идентификатору",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "employees"
                 ],
-                "summary": "Получить поставщика по id",
+                "summary": "Получить сотрудника по id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID поставщика",
+                        "description": "ID сотрудника",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1335,19 +1632,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.SupplierDto"
+                            "$ref": "#/definitions/services.EmployeeDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "put": {
-                "description": "Обновляет данные поставщика по id",
+                "description": "Обновляет данные сотрудника по id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1355,24 +1655,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "employees"
                 ],
-                "summary": "Обновить поставщика",
+                "summary": "Обновить сотрудника",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID поставщика",
+                        "description": "ID сотрудника",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления поставщика",
-                        "name": "supplier",
+                        "description": "Данные для обновления сотрудника",
+                        "name": "employee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.UpdateSupplierDto"
+                            "$ref": "#/definitions/services.UpdateEmployeeDto"
                         }
                     }
                 ],
@@ -1380,30 +1680,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.SupplierDto"
+                            "$ref": "#/definitions/services.EmployeeDto"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Удаляет поставщика по id",
+                "description": "Удаляет сотрудника по id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "employees"
                 ],
-                "summary": "Удалить поставщика",
+                "summary": "Удалить сотрудника",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID поставщика",
+                        "description": "ID сотрудника",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1413,37 +1716,7790 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/commissions": {
+            "get": {
+                "description": "Комиссия с оплаченных заказов продавца за период за вычетом возвратов, оформленных в периоде. Комиссия в копейках",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Комиссия продавца",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD (по умолчанию 30 дней назад)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.CommissionReportDto"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "services.AccountDto": {
+        },
+        "/employees/{id}/store": {
+            "post": {
+                "description": "Назначает или переводит сотрудника в магазин. Текущее назначение закрывается датой начала нового, история сохраняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Назначить сотрудника в магазин",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Магазин и дата начала",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AssignEmployeeStoreDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.EmployeeStoreAssignmentDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Закрывает текущее назначение сотрудника указанной датой (не включительно)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Снять сотрудника с магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.EmployeeStoreAssignmentDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/stores": {
+            "get": {
+                "description": "Возвращает все магазины, в которых работал сотрудник, начиная с последнего",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "История назначений сотрудника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.EmployeeStoreAssignmentDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards": {
+            "get": {
+                "description": "Возвращает все действующие подарочные карты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Получить список подарочных карт",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.GiftCardDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт подарочную карту с начальным балансом",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Выпустить подарочную карту",
+                "parameters": [
+                    {
+                        "description": "Код и номинал карты",
+                        "name": "card",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateGiftCardDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.GiftCardDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/{code}": {
+            "get": {
+                "description": "Возвращает подарочную карту и её остаток",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gift-cards"
+                ],
+                "summary": "Получить подарочную карту по коду",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Код карты",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.GiftCardDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods": {
+            "get": {
+                "description": "Возвращает все товары",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Получить список товаров",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.GoodDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные товара. Остаток и себестоимость так не меняются: их ведут приёмки, продажи, списания и инвентаризации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Обновить товар",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления товара",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateGoodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт новый товар",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Создать новый товар",
+                "parameters": [
+                    {
+                        "description": "Данные товара",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateGoodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-receipts": {
+            "get": {
+                "description": "Возвращает приёмки товара, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-receipts"
+                ],
+                "summary": "Получить приёмки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа поставщику",
+                        "name": "purchase_order_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.GoodsReceiptDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Фиксирует привезённое, бракованное и недопоставленное количество по строкам заказа. Остаток увеличивается на принятое количество, по браку и недопоставке создаётся претензия поставщику",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-receipts"
+                ],
+                "summary": "Принять товар по заказу поставщику",
+                "parameters": [
+                    {
+                        "description": "Данные приёмки",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateGoodsReceiptDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodsReceiptDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-receipts/{id}": {
+            "get": {
+                "description": "Возвращает приёмку товара со строками",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-receipts"
+                ],
+                "summary": "Получить приёмку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID приёмки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodsReceiptDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-supplier": {
+            "post": {
+                "description": "Создаёт новую связь между товаром и поставщиком",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-supplier"
+                ],
+                "summary": "Создать связь между товаром и поставщиком",
+                "parameters": [
+                    {
+                        "description": "Данные для создания связи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateGoodsSupplierDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateGoodsSupplierDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-supplier/by_good_id/{id}": {
+            "get": {
+                "description": "Возвращает поставщиков, связанных с товаром",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-supplier"
+                ],
+                "summary": "Получить поставщиков по товару",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "good_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.SupplierDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-supplier/by_supplier_id/{id}": {
+            "get": {
+                "description": "Возвращает товары, связанные с поставщиком",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-supplier"
+                ],
+                "summary": "Получить товары по поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "supplier_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.GoodDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods-supplier/{id}": {
+            "delete": {
+                "description": "Удаляет связь между товаром и поставщиком по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods-supplier"
+                ],
+                "summary": "Удалить связь между товаром и поставщиком",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID связи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/export": {
+            "get": {
+                "description": "Выгружает те же записи, что возвращает список сущности, читая их из базы страницами по 1000 строк. CSV и JSON Lines отдаются по мере чтения, XLSX — целиком после формирования книги",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка в CSV, XLSX или JSON Lines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (по умолчанию), xlsx или jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/import": {
+            "post": {
+                "description": "Загружает каталог из тела запроса. Колонки сопоставляются с полями по заголовку: article, name, price, category_id, tax_rate_id, brand, другие заголовки задаются через mapping. Товар с существующим артикулом обновляется, меняются только колонки из файла. Новые товары создаются с нулевым остатком и без себестоимости: их задают приёмка и инвентаризация. Строки с ошибками, в том числе битые записи CSV, пропускаются и перечисляются в ответе",
+                "consumes": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Импорт товаров из CSV или XLSX",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv или xlsx, по умолчанию по Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сопоставление поле:заголовок через запятую, например article:Артикул,name:Наименование",
+                        "name": "mapping",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Разделитель колонок CSV, по умолчанию запятая",
+                        "name": "delimiter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл, ничего не записывая",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodsImportResultDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}": {
+            "get": {
+                "description": "Возвращает товар по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Получить товар по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.GoodDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет товар по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Удалить товар",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}/forecast": {
+            "get": {
+                "description": "Прогноз продаж по дням по истории оплаченных заказов за год: модель Хольта-Винтерса с недельной сезонностью, при короткой истории — скользящее среднее. Возвращает рекомендуемую точку заказа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Прогноз спроса на товар",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина, без него — по всей сети",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Горизонт прогноза в днях, по умолчанию 30",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ForecastDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/goods/{id}/forecast/apply": {
+            "post": {
+                "description": "Записывает рекомендуемые прогнозом минимальный остаток и партию дозаказа в точку заказа товара",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goods"
+                ],
+                "summary": "Применить прогноз к точке заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина, без него — порог по всей сети",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReorderPointDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Возвращает все заказы, новые первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить список заказов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.OrderDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт заказ и резервирует товары на время оплаты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Создать заказ",
+                "parameters": [
+                    {
+                        "description": "Данные заказа",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateOrderDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.OrderDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Возвращает заказ с позициями и статусом оплаты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Получить заказ по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.OrderDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "description": "Отменяет неоплаченный заказ и снимает резервы",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Отменить заказ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.OrderDto"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments": {
+            "get": {
+                "description": "Возвращает все платежи по заказу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Получить платежи заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.PaymentDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Оплачивает заказ одним или несколькими способами: наличные, карта, баланс, подарочная карта.\nСумма способов оплаты должна совпадать с суммой заказа. Карта авторизуется у платёжного провайдера,\nостальные способы проводятся сразу. После авторизации карты резервы заказа продлеваются\nна срок авторизации, чтобы списание прошло и после обычного срока резерва.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Оплатить заказ",
+                "parameters": [
+                    {
+                        "description": "Заказ и способы оплаты",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreatePaymentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.PaymentDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/report": {
+            "get": {
+                "description": "Количество платежей, суммы оплат и возвратов по каждому способу оплаты за период",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Отчёт по способам оплаты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD (по умолчанию 30 дней назад)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.TenderReportDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Принимает подписанный webhook эквайера и обновляет статус платежа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Уведомление от платёжного провайдера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 подпись тела",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Событие",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PaymentWebhookEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PaymentDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}": {
+            "get": {
+                "description": "Возвращает платёж по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Получить платёж по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PaymentDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/capture": {
+            "post": {
+                "description": "Захватывает деньги по карте. Когда проведены все способы оплаты, резервы списываются со склада.\nНа время запроса к эквайеру платёж находится в статусе capturing; если ответ не получен,\nвызов можно повторить — списание уйдёт с тем же ключом идемпотентности и не пройдёт дважды.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Списать авторизованный платёж",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PaymentDto"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders": {
+            "get": {
+                "description": "Возвращает заказы поставщикам, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Получить заказы поставщикам",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус: draft, sent, partially_received, received или cancelled",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.PurchaseOrderDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт черновик заказа поставщику",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Создать заказ поставщику",
+                "parameters": [
+                    {
+                        "description": "Данные заказа",
+                        "name": "purchase_order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreatePurchaseOrderDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}": {
+            "get": {
+                "description": "Возвращает заказ поставщику с товарами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Получить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/cancel": {
+            "post": {
+                "description": "Отменяет черновик или отправленный заказ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Отменить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/send": {
+            "post": {
+                "description": "Переводит черновик в статус sent, после чего товар из заказа считается заказанным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Отправить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ожидаемая дата поставки",
+                        "name": "send",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SendPurchaseOrderDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts": {
+            "get": {
+                "description": "Возвращает чеки продажи и возврата по заказу с фискальными реквизитами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Получить чеки заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ReceiptDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{id}": {
+            "get": {
+                "description": "Возвращает чек с документом в структуре тегов ФФД 1.2",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Получить чек по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID чека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReceiptDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{id}/register": {
+            "post": {
+                "description": "Отправляет чек в фискальный регистратор, не дожидаясь фоновой очереди. Подходит для повтора ошибочных чеков\nи чеков в статусе registering: повтор отправляет тот же документ и не пробивает чек дважды",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Зарегистрировать чек",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID чека",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReceiptDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds": {
+            "get": {
+                "description": "Возвращает все возвраты по заказу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Получить возвраты заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.RefundDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Возврат товаров или суммы по оплаченному заказу. Деньги возвращаются на исходные способы оплаты:\nсначала на карту, затем на подарочные карты и баланс, наличными — в последнюю очередь.\nВозврат на карту проводится у эквайера после записи возврата; пока он не подтверждён,\nчасть возврата остаётся в статусе pending и повторяется в фоне.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Оформить возврат",
+                "parameters": [
+                    {
+                        "description": "Данные возврата",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateRefundDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.RefundDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/refunds/{id}": {
+            "get": {
+                "description": "Возвращает возврат с товарами и разбивкой по способам оплаты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "refunds"
+                ],
+                "summary": "Получить возврат по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID возврата",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.RefundDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers": {
+            "get": {
+                "description": "Возвращает все кассы или кассы магазина",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Получить кассы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.RegisterDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Регистрирует кассу в магазине. Касса создаётся закрытой",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Создать кассу",
+                "parameters": [
+                    {
+                        "description": "Данные кассы",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateRegisterDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.RegisterDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/sessions/{id}": {
+            "get": {
+                "description": "Возвращает смену кассы с движениями наличных",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Получить смену кассы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.RegisterSessionDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/sessions/{id}/z-report": {
+            "get": {
+                "description": "Возвращает Z-отчёт закрытой смены кассы",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Получить Z-отчёт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ZReportDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/{id}": {
+            "get": {
+                "description": "Возвращает кассу и её открытую смену",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Получить кассу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кассы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.RegisterDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/{id}/cash": {
+            "post": {
+                "description": "Записывает внесение (cash_in) или изъятие (cash_out) наличных в открытую смену кассы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Внести или изъять наличные",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кассы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Движение наличных",
+                        "name": "movement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateCashMovementDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.CashMovementDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/{id}/close": {
+            "post": {
+                "description": "Закрывает смену: сверяет пересчитанные наличные с ожидаемыми и формирует Z-отчёт.\nПосле закрытия продажи и возвраты наличными через кассу невозможны до открытия новой смены.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Закрыть смену кассы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кассы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Кассир и пересчитанные наличные",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CloseRegisterDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ZReportDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/{id}/open": {
+            "post": {
+                "description": "Открывает смену с разменом в кассе. Открыть кассу может только сотрудник, работающий в её магазине",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Открыть смену кассы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кассы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Кассир и размен",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.OpenRegisterDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.RegisterSessionDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/registers/{id}/sessions": {
+            "get": {
+                "description": "Возвращает смены кассы, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registers"
+                ],
+                "summary": "Получить смены кассы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID кассы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.RegisterSessionDto"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/replenishment/reorder-points": {
+            "get": {
+                "description": "Возвращает пороги остатков по сети и по магазинам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Получить точки заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "good_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ReorderPointDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Задаёт минимальный остаток и партию дозаказа товара по сети или в магазине, заменяя прежние значения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Задать точку заказа",
+                "parameters": [
+                    {
+                        "description": "Порог и партия",
+                        "name": "reorder_point",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SetReorderPointDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReorderPointDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/replenishment/reorder-points/{id}": {
+            "delete": {
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Удалить точку заказа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID точки заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/replenishment/suggestions": {
+            "get": {
+                "description": "Товары ниже точки заказа, сгруппированные по лучшему поставщику. Предложения пересчитываются фоновой задачей раз в час",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Предложения к заказу",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Пересчитать предложения перед выдачей",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.SupplierSuggestionsDto"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/replenishment/suggestions/convert": {
+            "post": {
+                "description": "Создаёт черновик заказа поставщику из всех открытых предложений по нему",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "replenishment"
+                ],
+                "summary": "Оформить заказ по предложениям",
+                "parameters": [
+                    {
+                        "description": "Поставщик и магазин приёмки",
+                        "name": "convert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ConvertSuggestionsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.PurchaseOrderDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/abc-xyz": {
+            "get": {
+                "description": "ABC — вклад товара в выручку за период: A до 80% накопленной доли, B до 95%, остальные C. XYZ — стабильность продаж по коэффициенту вариации по неделям или месяцам: X до 10%, Y до 25%, остальные Z. Матрица — число товаров и выручка в каждом из девяти классов.\nПериод расширяется до целых недель или месяцев, расширенный период возвращается в from и to. Не больше 520 недель или месяцев",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "ABC/XYZ-анализ ассортимента",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Интервал для XYZ: week (по умолчанию) или month",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (по умолчанию) или csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.AbcXyzDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/dead-stock": {
+            "get": {
+                "description": "Товары на складе без продаж за последние days дней и замороженная в них сумма по себестоимости",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Неликвидные товары",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Сколько дней без продаж, по умолчанию 90",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.DeadStockDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/inventory-valuation": {
+            "get": {
+                "description": "Стоимость остатков по средней взвешенной себестоимости на конец дня по магазинам и категориям. Остатки ведутся по сети, разбивка по магазинам — сальдо движений магазина по журналу остатков.\nОценка точна с того дня, когда появились журнал остатков и учёт себестоимости: остаток, заведённый при создании товара, импортом или до появления журнала, а также проданный до записи продаж в журнал, попадает в нераспределённую по магазинам часть",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Оценка товарных запасов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата оценки, YYYY-MM-DD, по умолчанию сегодня",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.InventoryValuationDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/low-stock": {
+            "get": {
+                "description": "Товары, доступный остаток которых ниже порога, с запасом в днях при текущих продажах. Без threshold порогом служит минимальный остаток точки заказа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Товары с низким остатком",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Порог доступного остатка",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "За сколько дней считать средние продажи, по умолчанию 30",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.LowStockItemDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "description": "Выручка, количество, себестоимость, маржа и средний чек оплаченных заказов за период с группировкой по времени, магазину, категории, бренду и продавцу.\nВыручка валовая: возвраты не вычитаются. Маржа считается только по строкам с известной себестоимостью, выручка остальных строк — в uncosted_revenue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Отчёт о продажах",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Измерения через запятую: day, week или month, store, category, brand, employee",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Бренд",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID продавца",
+                        "name": "employee_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SalesReportDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "description": "Возвращает активные резервы, при указании owner — только резервы владельца",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Получить активные резервы",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Владелец резерва (корзина, заказ)",
+                        "name": "owner",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ReservationDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Резервирует количество товара в магазине на время жизни резерва",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Зарезервировать товар",
+                "parameters": [
+                    {
+                        "description": "Данные резерва",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateReservationDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReservationDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "Возвращает резерв по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Получить резерв по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID резерва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReservationDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Освобождает активный резерв",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Снять резерв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID резерва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReservationDto"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/commit": {
+            "post": {
+                "description": "Превращает активный резерв в продажу и списывает товар со склада",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Провести резерв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID резерва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ReservationDto"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Возвращает все роли",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Получить список ролей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.RoleDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт новую роль",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Создать роль",
+                "parameters": [
+                    {
+                        "description": "Данные для создания роли",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateRoleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.RoleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "description": "Возвращает роль по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Получить роль по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID роли",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.RoleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные роли по id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Обновить роль",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID роли",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления роли",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateRoleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.RoleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions": {
+            "get": {
+                "description": "Возвращает права, выданные роли",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Получить права роли",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID роли",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions/{permission}": {
+            "put": {
+                "description": "Выдаёт роли право, например write_offs.approve — утверждение списаний",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Выдать право роли",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID роли",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Право",
+                        "name": "permission",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Отзывает у роли право",
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Отозвать право роли",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID роли",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Право",
+                        "name": "permission",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "description": "Возвращает все действующие услуги",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Получить каталог услуг",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ServiceDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет название, длительность, цену и ставку НДС услуги",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Обновить услугу",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления услуги",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateServiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ServiceDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет услугу в каталог: установка, подключение, ремонт. Услуги не имеют остатков",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Создать услугу",
+                "parameters": [
+                    {
+                        "description": "Данные услуги",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateServiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.ServiceDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "description": "Возвращает услугу по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Получить услугу по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID услуги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ServiceDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Помечает услугу удалённой",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Удалить услугу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID услуги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/shifts": {
+            "get": {
+                "description": "Смены магазина или сотрудника, начинающиеся в периоде. Нужно указать store_id или employee_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Получить график смен",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.ShiftDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Планирует смену сотрудника в магазине. Смена не длиннее 12 часов, не пересекается с другими сменами сотрудника и укладывается в недельную норму 40 часов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Создать смену",
+                "parameters": [
+                    {
+                        "description": "Данные смены",
+                        "name": "shift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateShiftDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/timesheet": {
+            "get": {
+                "description": "Запланированное и фактически отработанное время сотрудника за месяц в JSON или CSV",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Табель сотрудника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employee_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Месяц, YYYY-MM (по умолчанию текущий)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (по умолчанию) или csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TimesheetDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}": {
+            "get": {
+                "description": "Возвращает смену по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Получить смену",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Меняет магазин и время смены, пока сотрудник не отметил приход",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Перенести смену",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые магазин и время",
+                        "name": "shift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateShiftDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Отменяет смену, на которую сотрудник ещё не пришёл",
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Удалить смену",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}/clock-in": {
+            "post": {
+                "description": "Фиксирует время прихода на смену: не раньше чем за час до начала и не позже её окончания",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Отметить приход",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/shifts/{id}/clock-out": {
+            "post": {
+                "description": "Фиксирует время ухода со смены после отметки прихода",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Отметить уход",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID смены",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes": {
+            "get": {
+                "description": "Возвращает документы инвентаризации без строк, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Получить инвентаризации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.StocktakeDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт документ инвентаризации магазина и фиксирует ожидаемые остатки всех товаров или товаров категории.\nОжидаемый остаток — остаток этого магазина по журналу движений (продажи, возвраты, приёмки, списания,\nинвентаризации), а не остаток всей сети. Товар, попавший на склад до появления журнала, не числится\nни за одним магазином: излишек при утверждении сначала закрепляет его за магазином и не увеличивает остаток сети.\nВ магазине одновременно может идти только одна инвентаризация",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Начать инвентаризацию",
+                "parameters": [
+                    {
+                        "description": "Данные инвентаризации",
+                        "name": "stocktake",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateStocktakeDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}": {
+            "get": {
+                "description": "Возвращает документ инвентаризации со строками: ожидаемое и посчитанное количество, расхождения",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Получить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/adjustments": {
+            "get": {
+                "description": "Возвращает корректировки остатков, проведённые при утверждении инвентаризации",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Получить корректировки инвентаризации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.StockAdjustmentDto"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/approve": {
+            "post": {
+                "description": "Проводит расхождения по остаткам товаров и записывает корректировки. Непосчитанные товары не меняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Утвердить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Утверждающий сотрудник",
+                        "name": "approve",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ApproveStocktakeDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/cancel": {
+            "post": {
+                "description": "Отменяет инвентаризацию без изменения остатков",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Отменить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/counts": {
+            "post": {
+                "description": "Принимает подсчёт сотрудника магазина. Подсчёты разных сотрудников по одному товару складываются,\nповторный подсчёт того же сотрудника заменяет предыдущий",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Внести подсчёт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Посчитанные товары",
+                        "name": "count",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeCountDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StocktakeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/variances": {
+            "get": {
+                "description": "Возвращает посчитанные товары, количество которых разошлось с ожидаемым",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stocktakes"
+                ],
+                "summary": "Получить расхождения",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.StocktakeLineDto"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores": {
+            "get": {
+                "description": "Возвращает все магазины",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Получить список магазинов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.StoreDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт новый магазин",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Создать магазин",
+                "parameters": [
+                    {
+                        "description": "Данные для создания магазина",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateStoreDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.StoreDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/export": {
+            "get": {
+                "description": "Выгружает те же записи, что возвращает список сущности, читая их из базы страницами по 1000 строк. CSV и JSON Lines отдаются по мере чтения, XLSX — целиком после формирования книги",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка в CSV, XLSX или JSON Lines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (по умолчанию), xlsx или jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}": {
+            "get": {
+                "description": "Возвращает магазин по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Получить магазин по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StoreDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные магазина по id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Обновить магазин",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления магазина",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateStoreDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.StoreDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет магазин по id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Удалить магазин",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/employees": {
+            "get": {
+                "description": "Возвращает сотрудников, работавших в магазине в указанный день",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Сотрудники магазина",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.StoreEmployeeDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier-claims": {
+            "get": {
+                "description": "Возвращает претензии поставщикам, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier-claims"
+                ],
+                "summary": "Получить претензии поставщикам",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус: open или resolved",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.SupplierClaimDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier-claims/{id}": {
+            "get": {
+                "description": "Возвращает претензию по браку и недопоставке со строками",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier-claims"
+                ],
+                "summary": "Получить претензию поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID претензии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierClaimDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier-claims/{id}/resolve": {
+            "post": {
+                "description": "Отмечает претензию урегулированной",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier-claims"
+                ],
+                "summary": "Закрыть претензию поставщику",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID претензии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Итог претензии",
+                        "name": "resolve",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ResolveSupplierClaimDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierClaimDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "description": "Возвращает всех поставщиков",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить список поставщиков",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.SupplierDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт нового поставщика",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Создать поставщика",
+                "parameters": [
+                    {
+                        "description": "Данные для создания поставщика",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateSupplierDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/export": {
+            "get": {
+                "description": "Выгружает те же записи, что возвращает список сущности, читая их из базы страницами по 1000 строк. CSV и JSON Lines отдаются по мере чтения, XLSX — целиком после формирования книги",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка в CSV, XLSX или JSON Lines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (по умолчанию), xlsx или jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "description": "Возвращает поставщика по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Получить поставщика по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные поставщика по id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Обновить поставщика",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления поставщика",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateSupplierDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет поставщика по id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Удалить поставщика",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/scorecard": {
+            "get": {
+                "description": "Доля поставок в срок, доля выполнения заказов, доля брака, доля возвратов покупателей, средний срок поставки и претензии по заказам, отправленным поставщику за период",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Оценка поставщика",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.SupplierScorecardDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates": {
+            "get": {
+                "description": "Возвращает все действующие ставки НДС",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Получить список ставок НДС",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.TaxRateDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт ставку НДС: 20%, 10%, 0% или без НДС (rate = null)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Создать ставку НДС",
+                "parameters": [
+                    {
+                        "description": "Данные ставки",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateTaxRateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.TaxRateDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates/{id}": {
+            "get": {
+                "description": "Возвращает ставку НДС по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Получить ставку НДС по id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID ставки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TaxRateDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Помечает ставку НДС удалённой",
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Удалить ставку НДС",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID ставки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/technician-bookings": {
+            "get": {
+                "description": "Возвращает записи техников, созданные под заказ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technician-bookings"
+                ],
+                "summary": "Записи техников по заказу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заказа",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.TechnicianBookingDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Бронирует сотрудника на окно времени для установки или ремонта. Окно не может пересекаться с другими активными записями техника",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technician-bookings"
+                ],
+                "summary": "Записать техника",
+                "parameters": [
+                    {
+                        "description": "Данные записи",
+                        "name": "booking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateTechnicianBookingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.TechnicianBookingDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/technician-bookings/technicians/{id}/schedule": {
+            "get": {
+                "description": "Записи техника на день в порядке начала",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technician-bookings"
+                ],
+                "summary": "Расписание техника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника-техника",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата, YYYY-MM-DD (по умолчанию сегодня)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TechnicianScheduleDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/technician-bookings/{id}": {
+            "get": {
+                "description": "Возвращает запись техника по идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technician-bookings"
+                ],
+                "summary": "Получить запись техника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TechnicianBookingDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/technician-bookings/{id}/status": {
+            "post": {
+                "description": "Запись из scheduled переводится в completed или cancelled, отмена освобождает окно",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "technician-bookings"
+                ],
+                "summary": "Изменить статус записи техника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID записи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateTechnicianBookingStatusDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.TechnicianBookingDto"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/write-offs": {
+            "get": {
+                "description": "Возвращает списания, последние первыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Получить списания",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус: draft, approved или rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.WriteOffDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт черновик списания повреждённых или утерянных товаров магазина. Остатки меняются только после утверждения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Создать списание",
+                "parameters": [
+                    {
+                        "description": "Данные списания",
+                        "name": "write_off",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateWriteOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/services.WriteOffDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/write-offs/report": {
+            "get": {
+                "description": "Себестоимость утверждённых списаний по магазинам и причинам за период.\nТовар без известной себестоимости в cost не входит, его количество — в uncosted_quantity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Отчёт по списаниям",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID магазина",
+                        "name": "store_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WriteOffReportDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/write-offs/{id}": {
+            "get": {
+                "description": "Возвращает списание с товарами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Получить списание",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID списания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WriteOffDto"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/write-offs/{id}/approve": {
+            "post": {
+                "description": "Фиксирует себестоимость товаров и уменьшает остатки. Нужна роль с правом write_offs.approve,\nавтор списания утвердить его не может. Товар без себестоимости списывается с пустой unit_cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Утвердить списание",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID списания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Утверждающий сотрудник",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ReviewWriteOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WriteOffDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/write-offs/{id}/reject": {
+            "post": {
+                "description": "Отклоняет черновик списания без изменения остатков. Нужна роль с правом write_offs.approve",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "write-offs"
+                ],
+                "summary": "Отклонить списание",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID списания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Проверяющий сотрудник",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ReviewWriteOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.WriteOffDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "services.AbcXyzCellDto": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string"
+                },
+                "goods": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.AbcXyzDto": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AbcXyzItemDto"
+                    }
+                },
+                "matrix": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.AbcXyzCellDto"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.AbcXyzItemDto": {
+            "type": "object",
+            "properties": {
+                "abc": {
+                    "type": "string"
+                },
+                "article": {
+                    "type": "string"
+                },
+                "class": {
+                    "description": "Класс матрицы, например AX",
+                    "type": "string"
+                },
+                "cumulative_share": {
+                    "type": "number"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Доля в выручке и накопленная доля с учётом более прибыльных товаров, в процентах",
+                    "type": "number"
+                },
+                "units": {
+                    "type": "integer"
+                },
+                "variation": {
+                    "description": "Коэффициент вариации продаж по интервалам в процентах, null — продаж не было",
+                    "type": "number"
+                },
+                "xyz": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.AccountDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "login": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ApproveStocktakeDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.AssignCourierDto": {
+            "type": "object",
+            "properties": {
+                "courier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.AssignEmployeeStoreDto": {
+            "type": "object",
+            "properties": {
+                "starts_on": {
+                    "description": "Дата начала работы в магазине, YYYY-MM-DD, по умолчанию сегодня",
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CashMovementDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CategoryDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CloseRegisterDto": {
+            "type": "object",
+            "properties": {
+                "counted_cash": {
+                    "description": "Пересчитанные наличные в кассе на конец смены",
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CommissionReportDto": {
+            "type": "object",
+            "properties": {
+                "earned": {
+                    "description": "Начислено: комиссия с продаж за вычетом комиссии с возвратов",
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "returns_amount": {
+                    "type": "integer"
+                },
+                "returns_commission": {
+                    "type": "integer"
+                },
+                "sales_amount": {
+                    "type": "integer"
+                },
+                "sales_commission": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CommissionRuleDto": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "rate_bps": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ConvertSuggestionsDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CourierRouteDto": {
+            "type": "object",
+            "properties": {
+                "courier_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CourierRouteStopDto"
+                    }
+                }
+            }
+        },
+        "services.CourierRouteStopDto": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "$ref": "#/definitions/services.DeliveryDto"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "slot_ends_at": {
+                    "type": "string"
+                },
+                "slot_starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateAccountDto": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateCashMovementDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "cash_in — внесение, cash_out — изъятие",
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateCategoryDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateCommissionRuleDto": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "Категория товара, null — правило для любых товаров и услуг",
+                    "type": "integer"
+                },
+                "rate_bps": {
+                    "description": "Ставка в сотых долях процента, 250 = 2,5%",
+                    "type": "integer"
+                },
+                "role_id": {
+                    "description": "Роль продавца, null — правило для любой роли",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateCustomerAddressDto": {
+            "type": "object",
+            "properties": {
+                "apartment": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "floor": {
+                    "type": "integer"
+                },
+                "has_elevator": {
+                    "type": "boolean"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "Метка адреса: «дом», «работа», «дача»",
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateCustomerDto": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateDeliveryDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Адрес можно не указывать: тогда берётся AddressId или адрес по умолчанию из книги адресов клиента",
+                    "type": "string"
+                },
+                "address_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateDeliverySlotDto": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Сколько доставок магазин может выполнить в этот интервал",
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateEmployeeRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateGiftCardDto": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateGoodDto": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "brand": {
+                    "description": "Производитель",
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "description": "Закупочная себестоимость единицы, null — неизвестна",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "description": "Своя ставка НДС товара, иначе действует ставка категории",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateGoodsReceiptDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "is_final": {
+                    "description": "Завершающая приёмка: всё, что не привезено, считается недопоставкой, и заказ закрывается",
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateGoodsReceiptItemDto"
+                    }
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateGoodsReceiptItemDto": {
+            "type": "object",
+            "properties": {
+                "damaged_quantity": {
+                    "type": "integer"
+                },
+                "purchase_order_item_id": {
+                    "type": "integer"
+                },
+                "received_quantity": {
+                    "description": "Всего привезено, включая брак",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateGoodsSupplierDto": {
+            "type": "object",
+            "properties": {
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_price": {
+                    "description": "Закупочная цена и срок поставки, по ним выбирается лучший поставщик для пополнения",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateOrderDto": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateOrderItemDto"
+                    }
+                },
+                "register_id": {
+                    "type": "integer"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateOrderServiceDto"
+                    }
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateOrderItemDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateOrderServiceDto": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreatePaymentDto": {
+            "type": "object",
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                },
+                "tenders": {
+                    "description": "Способы оплаты, сумма должна совпадать с суммой заказа. Если не указаны, весь заказ оплачивается картой",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TenderDto"
+                    }
+                }
+            }
+        },
+        "services.CreatePurchaseOrderDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreatePurchaseOrderItemDto"
+                    }
+                },
+                "store_id": {
+                    "description": "Магазин, в который поставщик привезёт товар",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreatePurchaseOrderItemDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateRefundDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Сумма возврата без товаров, например компенсация. Игнорируется, если указаны товары",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Возвращаемые товары: сумма считается по ценам заказа, товары возвращаются на склад",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateRefundItemDto"
+                    }
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateRefundItemDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateRegisterDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateReservationDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "ttl_seconds": {
+                    "description": "Время жизни резерва в секундах, по умолчанию 15 минут",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateRoleDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateServiceDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateShiftDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateStocktakeDto": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "Категория товаров для выборочной инвентаризации, null — все товары",
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateStoreDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateSupplierDto": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateTaxRateDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "20, 10 или 0; null — без НДС",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateTechnicianBookingDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Если не указан, берётся адрес по умолчанию покупателя заказа",
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "description": "Если не указано, окно рассчитывается по длительности услуги",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateWriteOffDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateWriteOffItemDto"
+                    }
+                },
+                "reason": {
+                    "description": "damaged, display_model, transport, lost, defective или other",
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateWriteOffItemDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CustomerAddressDto": {
+            "type": "object",
+            "properties": {
+                "apartment": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "floor": {
+                    "type": "integer"
+                },
+                "has_elevator": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CustomerDto": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/services.AccountDto"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DeadStockDto": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DeadStockItemDto"
+                    }
+                },
+                "total_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.DeadStockItemDto": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "last_sold_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Деньги, замороженные в остатке, по средней себестоимости",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.DeliveryDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "courier_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DeliverySlotDto": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "booked": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.EmployeeDto": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/services.AccountDto"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "$ref": "#/definitions/services.RoleDto"
+                },
+                "store": {
+                    "description": "Текущий магазин сотрудника, null — не назначен",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.EmployeeStoreDto"
+                        }
+                    ]
+                }
+            }
+        },
+        "services.EmployeeStoreAssignmentDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "ends_on": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "starts_on": {
+                    "type": "string"
+                },
+                "store_address": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.EmployeeStoreDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ForecastDayDto": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.ForecastDto": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ForecastDayDto"
+                    }
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "history_days": {
+                    "description": "Дней истории продаж, по которым построен прогноз",
+                    "type": "integer"
+                },
+                "lead_time_days": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "Метод прогноза: holt_winters при истории от двух недель, иначе moving_average, none — продаж не было",
+                    "type": "string"
+                },
+                "moving_average": {
+                    "description": "Средние продажи в день за последние 28 дней",
+                    "type": "number"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "suggested_min_quantity": {
+                    "description": "Рекомендуемая точка заказа: спрос за срок поставки со страховым запасом и партия на 30 дней продаж",
+                    "type": "integer"
+                },
+                "suggested_reorder_quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.GiftCardDto": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.GoodDto": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.GoodsImportErrorDto": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Номер строки в файле, заголовок — строка 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.GoodsImportResultDto": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Новые товары и товары, найденные по артикулу. При dry_run — сколько было бы создано и обновлено",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.GoodsImportErrorDto"
+                    }
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Строки с ошибками не загружаются",
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.GoodsReceiptDto": {
+            "type": "object",
+            "properties": {
+                "claim_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_final": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.GoodsReceiptItemDto"
+                    }
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.GoodsReceiptItemDto": {
+            "type": "object",
+            "properties": {
+                "accepted_quantity": {
+                    "description": "Принято на склад: привезено за вычетом брака",
+                    "type": "integer"
+                },
+                "damaged_quantity": {
+                    "type": "integer"
+                },
+                "expected_quantity": {
+                    "description": "Сколько оставалось привезти по строке заказа до этой приёмки",
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "missing_quantity": {
+                    "description": "Недопоставлено. Считается только при завершающей приёмке",
+                    "type": "integer"
+                },
+                "purchase_order_item_id": {
+                    "type": "integer"
+                },
+                "received_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.InventoryValuationDto": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.InventoryValuationRowDto"
+                    }
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.InventoryValuationRowDto": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "description": "Магазин. null — остаток, появившийся до ведения журнала движений и не распределённый по магазинам",
+                    "type": "integer"
+                },
+                "uncosted_quantity": {
+                    "description": "Количество товаров без известной себестоимости, в стоимость не вошло",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Стоимость остатка по средней себестоимости на дату",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.LowStockItemDto": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "average_daily_sales": {
+                    "type": "number"
+                },
+                "days_of_cover": {
+                    "description": "На сколько дней хватит доступного остатка при текущих продажах, null — продаж не было",
+                    "type": "number"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "description": "Порог: из запроса или минимальный остаток точки заказа",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.OpenRegisterDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "opening_cash": {
+                    "description": "Размен в кассе на начало смены",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.OrderDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "fiscal_sign": {
+                    "type": "string"
+                },
+                "fiscal_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderItemDto"
+                    }
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "register_id": {
+                    "type": "integer"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderServiceLineDto"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TaxBreakdownDto"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vat_total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.OrderItemDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "description": "Средняя себестоимость единицы на момент продажи, null — заказ не оплачен или себестоимость неизвестна",
+                    "type": "integer"
+                },
+                "vat_amount": {
+                    "description": "НДС, включённый в сумму позиции, в копейках",
+                    "type": "integer"
+                },
+                "vat_rate": {
+                    "description": "Ставка НДС на момент продажи, null — без НДС",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.OrderServiceLineDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "vat_amount": {
+                    "type": "integer"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.PaymentDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "gift_card_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tender": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PaymentWebhookEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PurchaseOrderDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expected_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PurchaseOrderItemDto"
+                    }
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.PurchaseOrderItemDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "description": "Закупочная цена единицы, null — цена не согласована",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ReceiptDto": {
+            "type": "object",
+            "properties": {
+                "calculation_type": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "object"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fiscal_document_number": {
+                    "type": "integer"
+                },
+                "fiscal_sign": {
+                    "type": "string"
+                },
+                "fn_number": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "refund_id": {
+                    "type": "integer"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.RefundDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.RefundItemDto"
+                    }
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "tenders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.RefundTenderDto"
+                    }
+                }
+            }
+        },
+        "services.RefundItemDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "vat_amount": {
+                    "type": "integer"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.RefundTenderDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending — возврат на карту ещё не подтверждён эквайером и будет повторён, completed — деньги возвращены",
+                    "type": "string"
+                },
+                "tender": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.RegisterDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "session": {
+                    "description": "Открытая смена кассы, null — касса закрыта и продажи через неё запрещены",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.RegisterSessionDto"
+                        }
+                    ]
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.RegisterSessionDto": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "counted_cash": {
+                    "type": "integer"
+                },
+                "expected_cash": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CashMovementDto"
+                    }
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "integer"
+                },
+                "opening_cash": {
+                    "type": "integer"
+                },
+                "register_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ReorderPointDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_quantity": {
+                    "description": "Минимальный остаток, ниже которого товар нужно дозаказать",
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "description": "Сколько заказывать за раз",
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ReservationDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ResolveSupplierClaimDto": {
+            "type": "object",
+            "properties": {
+                "resolution": {
+                    "description": "Чем закончилась претензия: возврат денег, допоставка и т.п.",
+                    "type": "string"
+                }
+            }
+        },
+        "services.ReviewWriteOffDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.RoleDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.SalesReportDto": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesReportRowDto"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/services.SalesReportRowDto"
+                }
+            }
+        },
+        "services.SalesReportRowDto": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "integer"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "description": "Себестоимость проданного, зафиксированная при продаже",
+                    "type": "integer"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "margin": {
+                    "description": "Выручка строк с себестоимостью за вычетом себестоимости",
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "Начало дня, недели или месяца, если выбрана группировка по времени",
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "uncosted_revenue": {
+                    "description": "Выручка строк без себестоимости: услуги, товары без себестоимости и продажи до её учёта",
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SendPurchaseOrderDto": {
+            "type": "object",
+            "properties": {
+                "expected_at": {
+                    "description": "Ожидаемая дата поставки, YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "services.ServiceDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SetReorderPointDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "description": "Магазин, null — порог по всей сети",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ShiftDto": {
+            "type": "object",
+            "properties": {
+                "clock_in_at": {
+                    "type": "string"
+                },
+                "clock_out_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StockAdjustmentDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "goods_receipt_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stocktake_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "write_off_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StocktakeCountDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StocktakeCountItemDto"
+                    }
+                }
+            }
+        },
+        "services.StocktakeCountItemDto": {
+            "type": "object",
+            "properties": {
+                "good_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StocktakeDto": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "counted": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "goods": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StocktakeLineDto"
+                    }
+                },
+                "shortage_amount": {
+                    "description": "Недостача и излишки в рублях по текущим ценам",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "surplus_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StocktakeLineDto": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "counted_quantity": {
+                    "description": "Сумма подсчётов всех сотрудников, null — товар ещё не считали",
+                    "type": "integer"
+                },
+                "counters": {
+                    "type": "integer"
+                },
+                "expected_quantity": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "variance": {
+                    "description": "Расхождение: посчитано минус ожидалось, отрицательное — недостача",
+                    "type": "integer"
+                },
+                "variance_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.StoreDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.StoreEmployeeDto": {
+            "type": "object",
+            "properties": {
+                "assigned_from": {
+                    "type": "string"
+                },
+                "assigned_to": {
+                    "type": "string"
+                },
+                "employee": {
+                    "$ref": "#/definitions/services.EmployeeDto"
+                }
+            }
+        },
+        "services.SuggestionDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "article": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_order": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SupplierClaimDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "goods_receipt_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SupplierClaimItemDto"
+                    }
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SupplierClaimItemDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "damaged_quantity": {
+                    "type": "integer"
+                },
+                "good_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "missing_quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SupplierDto": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/services.AccountDto"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.SupplierScorecardDto": {
+            "type": "object",
+            "properties": {
+                "average_lead_time_days": {
+                    "description": "Средний срок от отправки заказа до приёмки, закрывшей его, в днях",
+                    "type": "number"
+                },
+                "claim_amount": {
+                    "type": "integer"
+                },
+                "claims": {
+                    "type": "integer"
+                },
+                "completed_orders": {
+                    "description": "Заказы, полностью принятые или закрытые финальной приёмкой (статус received)",
+                    "type": "integer"
+                },
+                "damaged": {
+                    "type": "integer"
+                },
+                "defect_rate": {
+                    "description": "Доля брака от привезённого",
+                    "type": "number"
+                },
+                "fill_rate": {
+                    "description": "Доля принятого без брака от заказанного по закрытым заказам",
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "on_time_rate": {
+                    "description": "Доля заказов со сроком поставки, закрытых не позже ожидаемой даты. Просроченные незакрытые заказы считаются опоздавшими",
+                    "type": "number"
+                },
+                "ordered": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "return_rate": {
+                    "description": "Доля возвращённого покупателями от привезённого",
+                    "type": "number"
+                },
+                "returned": {
+                    "description": "Возвраты покупателей за период по товарам, которые поставщик привозил по заказам периода. Товар нескольких поставщиков учитывается у каждого из них",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.SupplierSuggestionsDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SuggestionDto"
+                    }
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TaxBreakdownDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Сумма строк по ставке в копейках, включая НДС",
+                    "type": "integer"
+                },
+                "vat_amount": {
+                    "description": "НДС, включённый в Amount, в копейках",
+                    "type": "integer"
+                },
+                "vat_rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TaxRateDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Ставка в процентах, null — товар не облагается НДС",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TechnicianBookingDto": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.TechnicianScheduleDto": {
+            "type": "object",
+            "properties": {
+                "bookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TechnicianBookingDto"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TenderDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "gift_card_code": {
+                    "type": "string"
+                },
+                "tender": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.TenderReportDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "refunded": {
+                    "type": "integer"
+                },
+                "tender": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.TimesheetDto": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "planned_minutes": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TimesheetRowDto"
+                    }
+                },
+                "worked_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TimesheetRowDto": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "clock_in_at": {
                     "type": "string"
                 },
-                "id": {
+                "clock_out_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "planned_minutes": {
                     "type": "integer"
                 },
-                "is_alive": {
-                    "type": "boolean"
+                "shift_id": {
+                    "type": "integer"
                 },
-                "login": {
+                "starts_at": {
                     "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "worked_minutes": {
+                    "description": "Фактически отработано между отметками прихода и ухода, 0 — если отметок нет",
+                    "type": "integer"
                 }
             }
         },
-        "services.CreateAccountDto": {
+        "services.UpdateAccountDto": {
             "type": "object",
             "properties": {
+                "is_alive": {
+                    "type": "boolean"
+                },
                 "login": {
                     "type": "string"
                 },
@@ -1452,21 +9508,36 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CreateCustomerDto": {
+        "services.UpdateCategoryDto": {
             "type": "object",
             "properties": {
-                "accountId": {
+                "id": {
                     "type": "integer"
                 },
-                "balance": {
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_rate_id": {
                     "type": "integer"
                 }
             }
         },
-        "services.CreateEmployeeRequest": {
+        "services.UpdateCommissionRuleDto": {
             "type": "object",
             "properties": {
-                "account_id": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_alive": {
+                    "type": "boolean"
+                },
+                "rate_bps": {
                     "type": "integer"
                 },
                 "role_id": {
@@ -1474,104 +9545,95 @@ const docTemplate = `{
                 }
             }
         },
-        "services.CreateGoodDto": {
+        "services.UpdateCustomerAddressDto": {
             "type": "object",
             "properties": {
-                "article": {
+                "apartment": {
                     "type": "string"
                 },
-                "name": {
+                "city": {
                     "type": "string"
                 },
-                "price": {
+                "floor": {
                     "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
-        },
-        "services.CreateGoodsSupplierDto": {
-            "type": "object",
-            "properties": {
-                "product_id": {
-                    "type": "integer"
+                "has_elevator": {
+                    "type": "boolean"
                 },
-                "supplier_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "services.CreateRoleDto": {
-            "type": "object",
-            "properties": {
-                "name": {
+                "label": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "street": {
                     "type": "string"
                 }
             }
         },
-        "services.CreateStoreDto": {
+        "services.UpdateCustomerDto": {
+            "type": "object"
+        },
+        "services.UpdateDeliveryStatusDto": {
             "type": "object",
             "properties": {
-                "address": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "services.CreateSupplierDto": {
+        "services.UpdateEmployeeDto": {
             "type": "object",
             "properties": {
-                "account_id": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "isAlive": {
+                    "type": "boolean"
+                },
+                "roleId": {
                     "type": "integer"
                 }
             }
         },
-        "services.CustomerDto": {
+        "services.UpdateGoodDto": {
             "type": "object",
             "properties": {
-                "account": {
-                    "$ref": "#/definitions/services.AccountDto"
-                },
-                "balance": {
-                    "type": "integer"
+                "article": {
+                    "type": "string"
                 },
-                "created_at": {
+                "brand": {
                     "type": "string"
                 },
+                "category_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "is_alive": {
                     "type": "boolean"
-                }
-            }
-        },
-        "services.EmployeeDto": {
-            "type": "object",
-            "properties": {
-                "account": {
-                    "$ref": "#/definitions/services.AccountDto"
                 },
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "price": {
                     "type": "integer"
                 },
-                "is_alive": {
-                    "type": "boolean"
-                },
-                "role": {
-                    "$ref": "#/definitions/services.RoleDto"
+                "tax_rate_id": {
+                    "type": "integer"
                 }
             }
         },
-        "services.GoodDto": {
+        "services.UpdateServiceDto": {
             "type": "object",
             "properties": {
-                "article": {
+                "description": {
                     "type": "string"
                 },
+                "duration_minutes": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -1584,54 +9646,42 @@ const docTemplate = `{
                 "price": {
                     "type": "integer"
                 },
-                "quantity": {
+                "tax_rate_id": {
                     "type": "integer"
                 }
             }
         },
-        "services.RoleDto": {
+        "services.UpdateShiftDto": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "ends_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
+                "starts_at": {
                     "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
                 }
             }
         },
-        "services.StoreDto": {
+        "services.UpdateStoreDto": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "is_alive": {
                     "type": "boolean"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "services.SupplierDto": {
+        "services.UpdateSupplierDto": {
             "type": "object",
             "properties": {
-                "account": {
-                    "$ref": "#/definitions/services.AccountDto"
-                },
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
@@ -1640,82 +9690,195 @@ const docTemplate = `{
                 }
             }
         },
-        "services.UpdateAccountDto": {
+        "services.UpdateTechnicianBookingStatusDto": {
             "type": "object",
             "properties": {
-                "is_alive": {
-                    "type": "boolean"
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.WriteOffDto": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
                 },
-                "login": {
+                "approved_by": {
+                    "type": "integer"
+                },
+                "comment": {
                     "type": "string"
                 },
-                "password": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.WriteOffItemDto"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "total_cost": {
+                    "type": "integer"
                 }
             }
         },
-        "services.UpdateCustomerDto": {
-            "type": "object"
-        },
-        "services.UpdateEmployeeDto": {
+        "services.WriteOffItemDto": {
             "type": "object",
             "properties": {
-                "accountId": {
+                "good_id": {
                     "type": "integer"
                 },
-                "isAlive": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
-                "roleId": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "description": "Себестоимость единицы на момент утверждения, null — списание ещё не утверждено\nили себестоимость товара не была известна",
                     "type": "integer"
                 }
             }
         },
-        "services.UpdateGoodDto": {
+        "services.WriteOffReportDto": {
             "type": "object",
             "properties": {
-                "article": {
+                "from": {
                     "type": "string"
                 },
-                "id": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.WriteOffReportRowDto"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
                     "type": "integer"
                 },
-                "is_alive": {
-                    "type": "boolean"
+                "uncosted_quantity": {
+                    "description": "Всего списано товара без известной себестоимости",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.WriteOffReportRowDto": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
                 },
-                "name": {
+                "documents": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "price": {
+                "store_id": {
                     "type": "integer"
                 },
-                "quantity": {
+                "uncosted_quantity": {
+                    "description": "Списано товара без известной себестоимости, в cost не входит",
                     "type": "integer"
                 }
             }
         },
-        "services.UpdateStoreDto": {
+        "services.ZReportDto": {
             "type": "object",
             "properties": {
-                "address": {
+                "cash_in": {
+                    "type": "integer"
+                },
+                "cash_out": {
+                    "type": "integer"
+                },
+                "cash_refunds": {
+                    "type": "integer"
+                },
+                "cash_sales": {
+                    "type": "integer"
+                },
+                "closed_at": {
                     "type": "string"
                 },
-                "id": {
+                "closed_by": {
                     "type": "integer"
                 },
-                "is_alive": {
-                    "type": "boolean"
+                "counted_cash": {
+                    "type": "integer"
+                },
+                "discrepancy": {
+                    "description": "Расхождение: пересчитано минус ожидалось, отрицательное — недостача",
+                    "type": "integer"
+                },
+                "expected_cash": {
+                    "type": "integer"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "integer"
+                },
+                "opening_cash": {
+                    "type": "integer"
+                },
+                "register_id": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "integer"
+                },
+                "tenders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ZReportTenderDto"
+                    }
                 }
             }
         },
-        "services.UpdateSupplierDto": {
+        "services.ZReportTenderDto": {
             "type": "object",
             "properties": {
-                "id": {
+                "net": {
                     "type": "integer"
                 },
-                "is_alive": {
-                    "type": "boolean"
+                "payments": {
+                    "type": "integer"
+                },
+                "refunds": {
+                    "type": "integer"
+                },
+                "sales": {
+                    "type": "integer"
+                },
+                "tender": {
+                    "type": "string"
                 }
             }
         }
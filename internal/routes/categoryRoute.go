@@ -0,0 +1,158 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.CategoryNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать категорию
+// @Description  Создаёт категорию товаров. Ставка НДС категории действует для товаров без своей ставки
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        category  body      services.CreateCategoryDto  true  "Данные категории"
+// @Success      201       {object}  services.CategoryDto
+// @Failure      400       {object}  string
+// @Router       /categories [post]
+func createCategoryHandler(service services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateCategoryDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateCategory(r.Context(), dto)
+		if err != nil {
+			writeCategoryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить категорию по id
+// @Description  Возвращает категорию по идентификатору
+// @Tags         categories
+// @Produce      json
+// @Param        id   path      int  true  "ID категории"
+// @Success      200  {object}  services.CategoryDto
+// @Failure      404  {object}  string
+// @Router       /categories/{id} [get]
+func getCategoryHandler(service services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetCategory(r.Context(), int32(id))
+		if err != nil {
+			writeCategoryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить список категорий
+// @Description  Возвращает все действующие категории
+// @Tags         categories
+// @Produce      json
+// @Success      200  {array}  services.CategoryDto
+// @Router       /categories [get]
+func getCategoriesHandler(service services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetCategories(r.Context())
+		if err != nil {
+			writeCategoryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Обновить категорию
+// @Description  Обновляет название и ставку НДС категории
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        category  body      services.UpdateCategoryDto  true  "Данные для обновления категории"
+// @Success      200       {object}  services.CategoryDto
+// @Failure      404       {object}  string
+// @Router       /categories [put]
+func updateCategoryHandler(service services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.UpdateCategoryDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateCategory(r.Context(), dto)
+		if err != nil {
+			writeCategoryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить категорию
+// @Description  Помечает категорию удалённой
+// @Tags         categories
+// @Param        id   path  int  true  "ID категории"
+// @Success      204
+// @Router       /categories/{id} [delete]
+func deleteCategoryHandler(service services.CategoryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteCategory(r.Context(), int32(id)); err != nil {
+			writeCategoryError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewCategoryRouter(service services.CategoryService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createCategoryHandler(service))
+	r.Get("/", getCategoriesHandler(service))
+	r.Get("/{id}", getCategoryHandler(service))
+	r.Put("/", updateCategoryHandler(service))
+	r.Delete("/{id}", deleteCategoryHandler(service))
+
+	return r
+}
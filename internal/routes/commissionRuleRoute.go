@@ -0,0 +1,163 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeCommissionRuleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.CommissionRuleNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidCommissionRuleError),
+		errors.Is(err, services.RoleNotFound),
+		errors.Is(err, services.CategoryNotFound):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать правило комиссии
+// @Description  Задаёт ставку комиссии продавцов для роли, категории или их сочетания. Более точное правило имеет приоритет
+// @Tags         commissions
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      services.CreateCommissionRuleDto  true  "Данные правила"
+// @Success      201   {object}  services.CommissionRuleDto
+// @Failure      400   {object}  string
+// @Router       /commission-rules [post]
+func createCommissionRuleHandler(service services.CommissionRuleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateCommissionRuleDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateRule(r.Context(), dto)
+		if err != nil {
+			writeCommissionRuleError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить правило комиссии
+// @Description  Возвращает правило комиссии по идентификатору
+// @Tags         commissions
+// @Produce      json
+// @Param        id   path      int  true  "ID правила"
+// @Success      200  {object}  services.CommissionRuleDto
+// @Failure      404  {object}  string
+// @Router       /commission-rules/{id} [get]
+func getCommissionRuleHandler(service services.CommissionRuleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetRule(r.Context(), int32(id))
+		if err != nil {
+			writeCommissionRuleError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить правила комиссии
+// @Description  Возвращает все действующие правила комиссии
+// @Tags         commissions
+// @Produce      json
+// @Success      200  {array}  services.CommissionRuleDto
+// @Router       /commission-rules [get]
+func getCommissionRulesHandler(service services.CommissionRuleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetRules(r.Context())
+		if err != nil {
+			writeCommissionRuleError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Обновить правило комиссии
+// @Description  Обновляет роль, категорию и ставку правила
+// @Tags         commissions
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      services.UpdateCommissionRuleDto  true  "Данные для обновления правила"
+// @Success      200   {object}  services.CommissionRuleDto
+// @Failure      400   {object}  string
+// @Failure      404   {object}  string
+// @Router       /commission-rules [put]
+func updateCommissionRuleHandler(service services.CommissionRuleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.UpdateCommissionRuleDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateRule(r.Context(), dto)
+		if err != nil {
+			writeCommissionRuleError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить правило комиссии
+// @Description  Помечает правило удалённым
+// @Tags         commissions
+// @Param        id   path  int  true  "ID правила"
+// @Success      204
+// @Router       /commission-rules/{id} [delete]
+func deleteCommissionRuleHandler(service services.CommissionRuleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteRule(r.Context(), int32(id)); err != nil {
+			writeCommissionRuleError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewCommissionRuleRouter(service services.CommissionRuleService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createCommissionRuleHandler(service))
+	r.Get("/", getCommissionRulesHandler(service))
+	r.Get("/{id}", getCommissionRuleHandler(service))
+	r.Put("/", updateCommissionRuleHandler(service))
+	r.Delete("/{id}", deleteCommissionRuleHandler(service))
+
+	return r
+}
@@ -0,0 +1,227 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeCustomerAddressError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.CustomerNotFound),
+		errors.Is(err, services.CustomerAddressNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidAddressError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+func customerAddressParams(r *http.Request) (int32, int32, error) {
+	customerId, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	addressId, err := strconv.Atoi(chi.URLParam(r, "addressId"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(customerId), int32(addressId), nil
+}
+
+// @Summary      Добавить адрес клиента
+// @Description  Добавляет адрес в книгу адресов клиента. Первый адрес автоматически становится адресом по умолчанию
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                true  "ID клиента"
+// @Param        address  body      services.CreateCustomerAddressDto  true  "Адрес"
+// @Success      201      {object}  services.CustomerAddressDto
+// @Failure      400      {string}  string
+// @Failure      404      {string}  string
+// @Failure      500      {string}  string
+// @Router       /customers/{id}/addresses [post]
+func createCustomerAddressHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.CreateCustomerAddressDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		address, err := service.CreateAddress(r.Context(), int32(customerId), dto)
+		if err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(address)
+	}
+}
+
+// @Summary      Получить адреса клиента
+// @Description  Возвращает книгу адресов клиента, адрес по умолчанию первым
+// @Tags         customers
+// @Produce      json
+// @Param        id   path      int  true  "ID клиента"
+// @Success      200  {array}   services.CustomerAddressDto
+// @Failure      400  {string}  string
+// @Failure      404  {string}  string
+// @Failure      500  {string}  string
+// @Router       /customers/{id}/addresses [get]
+func getCustomerAddressesHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		addresses, err := service.GetAddresses(r.Context(), int32(customerId))
+		if err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(addresses)
+	}
+}
+
+// @Summary      Получить адрес клиента
+// @Description  Возвращает адрес из книги адресов клиента
+// @Tags         customers
+// @Produce      json
+// @Param        id         path      int  true  "ID клиента"
+// @Param        addressId  path      int  true  "ID адреса"
+// @Success      200        {object}  services.CustomerAddressDto
+// @Failure      400        {string}  string
+// @Failure      404        {string}  string
+// @Failure      500        {string}  string
+// @Router       /customers/{id}/addresses/{addressId} [get]
+func getCustomerAddressHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, addressId, err := customerAddressParams(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		address, err := service.GetAddress(r.Context(), customerId, addressId)
+		if err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(address)
+	}
+}
+
+// @Summary      Обновить адрес клиента
+// @Description  Обновляет адрес из книги адресов клиента
+// @Tags         customers
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                                true  "ID клиента"
+// @Param        addressId  path      int                                true  "ID адреса"
+// @Param        address    body      services.UpdateCustomerAddressDto  true  "Адрес"
+// @Success      200        {object}  services.CustomerAddressDto
+// @Failure      400        {string}  string
+// @Failure      404        {string}  string
+// @Failure      500        {string}  string
+// @Router       /customers/{id}/addresses/{addressId} [put]
+func updateCustomerAddressHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, addressId, err := customerAddressParams(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.UpdateCustomerAddressDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		address, err := service.UpdateAddress(r.Context(), customerId, addressId, dto)
+		if err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(address)
+	}
+}
+
+// @Summary      Удалить адрес клиента
+// @Description  Удаляет адрес из книги адресов клиента. Если он был адресом по умолчанию, им становится следующий адрес
+// @Tags         customers
+// @Param        id         path  int  true  "ID клиента"
+// @Param        addressId  path  int  true  "ID адреса"
+// @Success      204
+// @Failure      400  {string}  string
+// @Failure      404  {string}  string
+// @Failure      500  {string}  string
+// @Router       /customers/{id}/addresses/{addressId} [delete]
+func deleteCustomerAddressHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, addressId, err := customerAddressParams(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteAddress(r.Context(), customerId, addressId); err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// @Summary      Сделать адрес адресом по умолчанию
+// @Description  Помечает адрес как адрес по умолчанию, снимая отметку с остальных адресов клиента
+// @Tags         customers
+// @Produce      json
+// @Param        id         path      int  true  "ID клиента"
+// @Param        addressId  path      int  true  "ID адреса"
+// @Success      200        {object}  services.CustomerAddressDto
+// @Failure      400        {string}  string
+// @Failure      404        {string}  string
+// @Failure      500        {string}  string
+// @Router       /customers/{id}/addresses/{addressId}/default [post]
+func setDefaultCustomerAddressHandler(service services.CustomerService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		customerId, addressId, err := customerAddressParams(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		address, err := service.SetDefaultAddress(r.Context(), customerId, addressId)
+		if err != nil {
+			writeCustomerAddressError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(address)
+	}
+}
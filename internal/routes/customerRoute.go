@@ -147,14 +147,21 @@ func deleteCustomerHandler(service services.CustomerService) http.HandlerFunc {
 	}
 }
 
-func NewCustomerRouter(service services.CustomerService) http.Handler {
+func NewCustomerRouter(service services.CustomerService, exportService services.ExportService) http.Handler {
 	r := chi.NewRouter()
 
 	r.Post("/", createCustomerHandler(service))
+	r.Get("/export", exportHandler(exportService, "customers"))
 	r.Get("/{id}", getCustomerHandler(service))
 	r.Get("/", getCustomersHandler(service))
 	r.Put("/{id}", updateCustomerHandler(service))
 	r.Delete("/{id}", deleteCustomerHandler(service))
+	r.Post("/{id}/addresses", createCustomerAddressHandler(service))
+	r.Get("/{id}/addresses", getCustomerAddressesHandler(service))
+	r.Get("/{id}/addresses/{addressId}", getCustomerAddressHandler(service))
+	r.Put("/{id}/addresses/{addressId}", updateCustomerAddressHandler(service))
+	r.Delete("/{id}/addresses/{addressId}", deleteCustomerAddressHandler(service))
+	r.Post("/{id}/addresses/{addressId}/default", setDefaultCustomerAddressHandler(service))
 
 	return r
 
@@ -0,0 +1,312 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func writeDeliveryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.DeliveryNotFound),
+		errors.Is(err, services.OrderNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.DeliverySlotNotFound),
+		errors.Is(err, services.CourierNotFound),
+		errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.InvalidDeliverySlotError),
+		errors.Is(err, services.CustomerAddressNotFound),
+		errors.Is(err, services.InvalidAddressError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.DeliverySlotFullError),
+		errors.Is(err, services.DeliveryExistsError),
+		errors.Is(err, services.DeliveryStatusError),
+		errors.Is(err, services.OrderStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать слот доставки
+// @Description  Создаёт интервал доставки магазина с ограничением по числу доставок
+// @Tags         deliveries
+// @Accept       json
+// @Produce      json
+// @Param        slot  body      services.CreateDeliverySlotDto  true  "Данные слота"
+// @Success      201   {object}  services.DeliverySlotDto
+// @Failure      400   {object}  string
+// @Router       /deliveries/slots [post]
+func createDeliverySlotHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateDeliverySlotDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateSlot(r.Context(), dto)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить слоты доставки магазина
+// @Description  Возвращает слоты магазина за период с числом занятых и свободных мест
+// @Tags         deliveries
+// @Produce      json
+// @Param        store_id  query     int     true   "ID магазина"
+// @Param        from      query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to        query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Success      200       {array}   services.DeliverySlotDto
+// @Failure      400       {object}  string
+// @Router       /deliveries/slots [get]
+func getDeliverySlotsHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storeId, err := strconv.Atoi(r.URL.Query().Get("store_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSlots(r.Context(), int32(storeId), from, to)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Оформить доставку
+// @Description  Бронирует место в слоте доставки магазина заказа
+// @Tags         deliveries
+// @Accept       json
+// @Produce      json
+// @Param        delivery  body      services.CreateDeliveryDto  true  "Данные доставки"
+// @Success      201       {object}  services.DeliveryDto
+// @Failure      400       {object}  string
+// @Failure      409       {object}  string
+// @Router       /deliveries [post]
+func createDeliveryHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateDeliveryDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateDelivery(r.Context(), dto)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить список доставок
+// @Description  Возвращает все доставки, новые первыми, или доставки одного заказа
+// @Tags         deliveries
+// @Produce      json
+// @Param        order_id  query     int  false  "ID заказа"
+// @Success      200       {array}   services.DeliveryDto
+// @Failure      400       {object}  string
+// @Router       /deliveries [get]
+func getDeliveriesHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var response []services.DeliveryDto
+		var err error
+		if value := r.URL.Query().Get("order_id"); value != "" {
+			orderId, convErr := strconv.Atoi(value)
+			if convErr != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(convErr.Error()))
+				return
+			}
+			response, err = service.GetOrderDeliveries(r.Context(), int32(orderId))
+		} else {
+			response, err = service.GetDeliveries(r.Context())
+		}
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить доставку по id
+// @Description  Возвращает доставку по идентификатору
+// @Tags         deliveries
+// @Produce      json
+// @Param        id   path      int  true  "ID доставки"
+// @Success      200  {object}  services.DeliveryDto
+// @Failure      404  {object}  string
+// @Router       /deliveries/{id} [get]
+func getDeliveryHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetDelivery(r.Context(), int32(id))
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Назначить курьера
+// @Description  Назначает сотрудника курьером доставки, пока она не выехала
+// @Tags         deliveries
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true  "ID доставки"
+// @Param        courier  body      services.AssignCourierDto  true  "Курьер"
+// @Success      200      {object}  services.DeliveryDto
+// @Failure      400      {object}  string
+// @Failure      409      {object}  string
+// @Router       /deliveries/{id}/courier [post]
+func assignCourierHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.AssignCourierDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.AssignCourier(r.Context(), int32(id), dto)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Изменить статус доставки
+// @Description  scheduled → out_for_delivery → delivered; из scheduled и out_for_delivery можно перейти в failed
+// @Tags         deliveries
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                               true  "ID доставки"
+// @Param        status  body      services.UpdateDeliveryStatusDto  true  "Новый статус"
+// @Success      200     {object}  services.DeliveryDto
+// @Failure      409     {object}  string
+// @Router       /deliveries/{id}/status [post]
+func updateDeliveryStatusHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.UpdateDeliveryStatusDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateStatus(r.Context(), int32(id), dto)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Маршрутный лист курьера
+// @Description  Доставки курьера на день в порядке слотов
+// @Tags         deliveries
+// @Produce      json
+// @Param        id    path      int     true   "ID сотрудника-курьера"
+// @Param        date  query     string  false  "Дата, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {object}  services.CourierRouteDto
+// @Failure      400   {object}  string
+// @Router       /deliveries/couriers/{id}/route [get]
+func getCourierRouteHandler(service services.DeliveryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		date := time.Now()
+		if value := r.URL.Query().Get("date"); value != "" {
+			if date, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		response, err := service.GetCourierRoute(r.Context(), int32(id), date)
+		if err != nil {
+			writeDeliveryError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewDeliveryRouter(service services.DeliveryService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/slots", createDeliverySlotHandler(service))
+	r.Get("/slots", getDeliverySlotsHandler(service))
+	r.Get("/couriers/{id}/route", getCourierRouteHandler(service))
+	r.Post("/", createDeliveryHandler(service))
+	r.Get("/", getDeliveriesHandler(service))
+	r.Get("/{id}", getDeliveryHandler(service))
+	r.Post("/{id}/courier", assignCourierHandler(service))
+	r.Post("/{id}/status", updateDeliveryStatusHandler(service))
+
+	return r
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"HomeApplianceStore/internal/services"
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"net/http"
 	"strconv"
@@ -149,6 +150,153 @@ func deleteEmployeeHandler(service services.EmployeeService) http.HandlerFunc {
 	}
 }
 
+func writeEmployeeStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.EmployeeNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.InvalidDateError),
+		errors.Is(err, services.InvalidAssignmentDateError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.EmployeeAlreadyAssignedError),
+		errors.Is(err, services.EmployeeNotAssignedError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Назначить сотрудника в магазин
+// @Description  Назначает или переводит сотрудника в магазин. Текущее назначение закрывается датой начала нового, история сохраняется
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                              true  "ID сотрудника"
+// @Param        assignment  body      services.AssignEmployeeStoreDto  true  "Магазин и дата начала"
+// @Success      201         {object}  services.EmployeeStoreAssignmentDto
+// @Failure      400         {object}  string
+// @Failure      404         {object}  string
+// @Failure      409         {object}  string
+// @Router       /employees/{id}/store [post]
+func assignEmployeeStoreHandler(service services.EmployeeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.AssignEmployeeStoreDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.AssignStore(r.Context(), int32(id), dto)
+		if err != nil {
+			writeEmployeeStoreError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Снять сотрудника с магазина
+// @Description  Закрывает текущее назначение сотрудника указанной датой (не включительно)
+// @Tags         employees
+// @Produce      json
+// @Param        id    path      int     true   "ID сотрудника"
+// @Param        date  query     string  false  "Дата окончания, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {object}  services.EmployeeStoreAssignmentDto
+// @Failure      400   {object}  string
+// @Failure      409   {object}  string
+// @Router       /employees/{id}/store [delete]
+func endEmployeeStoreHandler(service services.EmployeeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.EndStoreAssignment(r.Context(), int32(id), r.URL.Query().Get("date"))
+		if err != nil {
+			writeEmployeeStoreError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      История назначений сотрудника
+// @Description  Возвращает все магазины, в которых работал сотрудник, начиная с последнего
+// @Tags         employees
+// @Produce      json
+// @Param        id   path      int  true  "ID сотрудника"
+// @Success      200  {array}   services.EmployeeStoreAssignmentDto
+// @Failure      400  {object}  string
+// @Router       /employees/{id}/stores [get]
+func getEmployeeStoresHandler(service services.EmployeeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetStoreHistory(r.Context(), int32(id))
+		if err != nil {
+			writeEmployeeStoreError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Комиссия продавца
+// @Description  Комиссия с оплаченных заказов продавца за период за вычетом возвратов, оформленных в периоде. Комиссия в копейках
+// @Tags         employees
+// @Produce      json
+// @Param        id    path      int     true   "ID сотрудника"
+// @Param        from  query     string  false  "Начало периода, YYYY-MM-DD (по умолчанию 30 дней назад)"
+// @Param        to    query     string  false  "Конец периода включительно, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {object}  services.CommissionReportDto
+// @Failure      400   {object}  string
+// @Failure      404   {object}  string
+// @Router       /employees/{id}/commissions [get]
+func getEmployeeCommissionsHandler(service services.EmployeeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetCommissions(r.Context(), int32(id), from, to)
+		if err != nil {
+			writeEmployeeStoreError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
 func NewEmployeeRouter(service services.EmployeeService) http.Handler {
 	r := chi.NewRouter()
 
@@ -157,6 +305,10 @@ func NewEmployeeRouter(service services.EmployeeService) http.Handler {
 	r.Post("/", createEmployeeHandler(service))
 	r.Put("/{id}", updateEmployeeHandler(service))
 	r.Delete("/{id}", deleteEmployeeHandler(service))
+	r.Post("/{id}/store", assignEmployeeStoreHandler(service))
+	r.Delete("/{id}/store", endEmployeeStoreHandler(service))
+	r.Get("/{id}/stores", getEmployeeStoresHandler(service))
+	r.Get("/{id}/commissions", getEmployeeCommissionsHandler(service))
 
 	return r
 }
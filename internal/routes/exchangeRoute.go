@@ -0,0 +1,139 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"log"
+	"net/http"
+)
+
+// 1С разбирает ответы обмена как текст: первая строка — success, failure или progress,
+// следующие — подробности. Поэтому ошибки здесь пишутся с префиксом failure
+func writeExchangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ExchangeAuthError),
+		errors.Is(err, services.ExchangeSessionError):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, services.PermissionDeniedError):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Is(err, services.InvalidExchangeFileError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		log.Printf("1c exchange: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte("failure\n" + err.Error()))
+}
+
+func writeExchangeSuccess(w http.ResponseWriter, lines ...string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("success\n"))
+	for _, line := range lines {
+		w.Write([]byte(line + "\n"))
+	}
+}
+
+// @Summary      Обмен с 1С по CommerceML 2
+// @Description  Точка обмена для 1С: Управление торговлей и похожих конфигураций. Режим выбирается параметрами type и mode:
+// @Description  checkauth — вход по Basic-авторизации сотрудника с правом exchange.1c, возвращает cookie сессии;
+// @Description  init — начало обмена, возвращает zip=no и file_limit; file — приём файла частями в теле запроса, часть больше file_limit отклоняется целиком;
+// @Description  import (type=catalog) — загрузка import.xml (категории и товары) или offers.xml (цены);
+// @Description  query (type=sale) — оплаченные заказы, ещё не принятые 1С; success (type=sale) — подтверждение приёма заказов.
+// @Description  Товары и категории сопоставляются по Ид 1С, а до первого обмена — по артикулу и названию. Остатки из 1С не загружаются.
+// @Tags         exchange
+// @Accept       application/octet-stream
+// @Produce      plain
+// @Param        type      query     string  true   "catalog или sale"
+// @Param        mode      query     string  true   "checkauth, init, file, import, query или success"
+// @Param        filename  query     string  false  "Имя файла для mode=file и mode=import"
+// @Success      200       {string}  string
+// @Failure      400       {string}  string
+// @Failure      401       {string}  string
+// @Failure      403       {string}  string
+// @Router       /1c-exchange [get]
+// @Router       /1c-exchange [post]
+func exchangeHandler(service *services.ExchangeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exchangeType := r.URL.Query().Get("type")
+		if exchangeType != "catalog" && exchangeType != "sale" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("failure\ntype must be catalog or sale"))
+			return
+		}
+		mode := r.URL.Query().Get("mode")
+		if mode == "checkauth" {
+			login, password, ok := r.BasicAuth()
+			if !ok {
+				writeExchangeError(w, services.ExchangeAuthError)
+				return
+			}
+			token, err := service.CheckAuth(r.Context(), login, password)
+			if err != nil {
+				writeExchangeError(w, err)
+				return
+			}
+			writeExchangeSuccess(w, services.ExchangeCookieName, token)
+			return
+		}
+
+		cookie, err := r.Cookie(services.ExchangeCookieName)
+		if err != nil {
+			writeExchangeError(w, services.ExchangeSessionError)
+			return
+		}
+		token := cookie.Value
+		filename := r.URL.Query().Get("filename")
+		switch {
+		case mode == "init":
+			if err := service.Init(token); err != nil {
+				writeExchangeError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "zip=no\nfile_limit=%d\n", services.ExchangeFileLimit)
+		case mode == "file":
+			defer r.Body.Close()
+			if err := service.SaveFile(token, filename, r.Body); err != nil {
+				writeExchangeError(w, err)
+				return
+			}
+			writeExchangeSuccess(w)
+		case mode == "import" && exchangeType == "catalog":
+			result, err := service.ImportFile(r.Context(), token, filename)
+			if err != nil {
+				writeExchangeError(w, err)
+				return
+			}
+			writeExchangeSuccess(w, fmt.Sprintf("categories=%d created=%d updated=%d deleted=%d prices=%d skipped=%d",
+				result.Categories, result.Created, result.Updated, result.Deleted, result.Prices, result.Skipped))
+		case mode == "query" && exchangeType == "sale":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			if err := service.QueryOrders(r.Context(), token, w); err != nil {
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				writeExchangeError(w, err)
+			}
+		case mode == "success" && exchangeType == "sale":
+			if err := service.ConfirmOrders(r.Context(), token); err != nil {
+				writeExchangeError(w, err)
+				return
+			}
+			writeExchangeSuccess(w)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("failure\nunsupported mode " + mode + " for type " + exchangeType))
+		}
+	}
+}
+
+func NewExchangeRouter(service *services.ExchangeService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/", exchangeHandler(service))
+	r.Post("/", exchangeHandler(service))
+
+	return r
+}
@@ -0,0 +1,52 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// @Summary      Выгрузка в CSV, XLSX или JSON Lines
+// @Description  Выгружает те же записи, что возвращает список сущности, читая их из базы страницами по 1000 строк. CSV и JSON Lines отдаются по мере чтения, XLSX — целиком после формирования книги
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      application/x-ndjson
+// @Param        format  query     string  false  "csv (по умолчанию), xlsx или jsonl"
+// @Success      200     {file}    file
+// @Failure      400     {object}  string
+// @Router       /goods/export [get]
+// @Router       /customers/export [get]
+// @Router       /suppliers/export [get]
+// @Router       /stores/export [get]
+func exportHandler(service services.ExportService, entity string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = services.ExportFormatCSV
+		}
+		contentType, ok := services.ExportContentTypes[format]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(services.InvalidExportFormatError.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", entity, format))
+		err := service.Export(r.Context(), entity, format, w)
+		if err == nil {
+			return
+		}
+		// Часть файла уже у клиента: статус не поменять, обрыв выгрузки остаётся только записать в лог
+		if errors.Is(err, services.ExportInterruptedError) {
+			log.Printf("export %s: %v", entity, err)
+			return
+		}
+		w.Header().Del("Content-Disposition")
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
+}
@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+// Заглушка эквайера поверх FakePaymentProvider. Говорит на том же протоколе, что и HTTPPaymentProvider,
+// и подключается только без PAYMENT_GATEWAY_URL, когда магазин сам платит через фейковый эквайер.
+// Уведомления о платежах уходят прямо в PaymentService.HandleWebhook, а не на произвольный адрес.
+// В Swagger не публикуется: это не часть API магазина.
+
+type fakeAcquirerAmount struct {
+	Amount int64 `json:"amount"`
+}
+
+type fakeAcquirerWebhook struct {
+	Status string `json:"status"`
+}
+
+func writeFakeAcquirerResult(w http.ResponseWriter, result services.ProviderPayment, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, services.PaymentDeclinedError):
+			w.WriteHeader(http.StatusPaymentRequired)
+		case errors.Is(err, services.FakePaymentNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusConflict)
+		}
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+func fakeAuthorizeHandler(provider *services.FakePaymentProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request services.PaymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		result, err := provider.Authorize(r.Context(), request)
+		writeFakeAcquirerResult(w, result, err)
+	}
+}
+
+func fakeCaptureHandler(provider *services.FakePaymentProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request fakeAcquirerAmount
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		result, err := provider.Capture(r.Context(), chi.URLParam(r, "id"), request.Amount, r.Header.Get(services.IdempotencyKeyHeader))
+		writeFakeAcquirerResult(w, result, err)
+	}
+}
+
+func fakeRefundHandler(provider *services.FakePaymentProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request fakeAcquirerAmount
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		result, err := provider.Refund(r.Context(), chi.URLParam(r, "id"), request.Amount, r.Header.Get(services.IdempotencyKeyHeader))
+		writeFakeAcquirerResult(w, result, err)
+	}
+}
+
+// fakeWebhookHandler передаёт подписанное уведомление о платеже прямо в обработчик webhook магазина
+func fakeWebhookHandler(provider *services.FakePaymentProvider, payments services.PaymentInterface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request fakeAcquirerWebhook
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		payload, signature, err := provider.Webhook(chi.URLParam(r, "id"), request.Status)
+		if err != nil {
+			writeFakeAcquirerResult(w, services.ProviderPayment{}, err)
+			return
+		}
+		response, err := payments.HandleWebhook(r.Context(), payload, signature)
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewFakeAcquirerRouter(provider *services.FakePaymentProvider, payments services.PaymentInterface) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/authorize", fakeAuthorizeHandler(provider))
+	r.Post("/payments/{id}/capture", fakeCaptureHandler(provider))
+	r.Post("/payments/{id}/refund", fakeRefundHandler(provider))
+	r.Post("/payments/{id}/webhook", fakeWebhookHandler(provider, payments))
+
+	return r
+}
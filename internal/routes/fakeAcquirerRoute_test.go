@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// webhookRecorder принимает уведомления заглушки вместо PaymentService и проверяет их подпись
+type webhookRecorder struct {
+	services.PaymentInterface
+	provider *services.FakePaymentProvider
+	events   []services.PaymentWebhookEvent
+}
+
+func (w *webhookRecorder) HandleWebhook(ctx context.Context, payload []byte, signature string) (services.PaymentDto, error) {
+	event, err := w.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return services.PaymentDto{}, err
+	}
+	w.events = append(w.events, event)
+	return services.PaymentDto{Status: event.Status, CreatedAt: time.Now()}, nil
+}
+
+func TestFakeAcquirerRouter(t *testing.T) {
+	fake := services.NewFakePaymentProvider("secret")
+	fake.DeclineAbove = 1000
+	payments := &webhookRecorder{provider: fake}
+	server := httptest.NewServer(NewFakeAcquirerRouter(fake, payments))
+	defer server.Close()
+	provider := services.HTTPPaymentProvider{BaseURL: server.URL, Secret: "secret", Client: server.Client()}
+	ctx := context.Background()
+
+	if _, err := provider.Authorize(ctx, services.PaymentRequest{Amount: 5000}); !errors.Is(err, services.PaymentDeclinedError) {
+		t.Fatalf("Authorize над лимитом: err = %v, want PaymentDeclinedError", err)
+	}
+	payment, err := provider.Authorize(ctx, services.PaymentRequest{Amount: 700})
+	if err != nil || payment.Status != services.PaymentStatusAuthorized {
+		t.Fatalf("Authorize = %+v, %v", payment, err)
+	}
+	captured, err := provider.Capture(ctx, payment.ExternalId, 700, "payment-1-capture")
+	if err != nil || captured.Status != services.PaymentStatusCaptured {
+		t.Fatalf("Capture = %+v, %v", captured, err)
+	}
+	// Ключ идемпотентности доходит до эквайера в заголовке: повтор не считается вторым списанием
+	if replay, err := provider.Capture(ctx, payment.ExternalId, 700, "payment-1-capture"); err != nil || replay != captured {
+		t.Errorf("повтор Capture = %+v, %v, want %+v", replay, err, captured)
+	}
+	if _, err := provider.Capture(ctx, payment.ExternalId, 700, "payment-2-capture"); err == nil {
+		t.Error("второе списание с другим ключом прошло")
+	}
+
+	response, err := server.Client().Post(server.URL+"/payments/"+payment.ExternalId+"/webhook", "application/json",
+		strings.NewReader(`{"status":"captured","callback_url":"http://127.0.0.1:1/"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("webhook: status = %d", response.StatusCode)
+	}
+	if len(payments.events) != 1 || payments.events[0].ExternalId != payment.ExternalId || payments.events[0].Status != services.PaymentStatusCaptured {
+		t.Errorf("webhook events = %+v", payments.events)
+	}
+
+	response, err = server.Client().Post(server.URL+"/payments/unknown/webhook", "application/json", strings.NewReader(`{"status":"captured"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("webhook неизвестного платежа: status = %d, want 404", response.StatusCode)
+	}
+}
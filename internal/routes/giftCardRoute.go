@@ -0,0 +1,100 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+func writeGiftCardError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.GiftCardNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidGiftCardError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Выпустить подарочную карту
+// @Description  Создаёт подарочную карту с начальным балансом
+// @Tags         gift-cards
+// @Accept       json
+// @Produce      json
+// @Param        card  body      services.CreateGiftCardDto  true  "Код и номинал карты"
+// @Success      201   {object}  services.GiftCardDto
+// @Failure      400   {object}  string
+// @Router       /gift-cards [post]
+func createGiftCardHandler(service services.GiftCardService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateGiftCardDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateGiftCard(r.Context(), dto)
+		if err != nil {
+			writeGiftCardError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить подарочную карту по коду
+// @Description  Возвращает подарочную карту и её остаток
+// @Tags         gift-cards
+// @Produce      json
+// @Param        code  path      string  true  "Код карты"
+// @Success      200   {object}  services.GiftCardDto
+// @Failure      404   {object}  string
+// @Router       /gift-cards/{code} [get]
+func getGiftCardHandler(service services.GiftCardService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetGiftCard(r.Context(), chi.URLParam(r, "code"))
+		if err != nil {
+			writeGiftCardError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить список подарочных карт
+// @Description  Возвращает все действующие подарочные карты
+// @Tags         gift-cards
+// @Produce      json
+// @Success      200  {array}  services.GiftCardDto
+// @Router       /gift-cards [get]
+func getGiftCardsHandler(service services.GiftCardService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetGiftCards(r.Context())
+		if err != nil {
+			writeGiftCardError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewGiftCardRouter(service services.GiftCardService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createGiftCardHandler(service))
+	r.Get("/", getGiftCardsHandler(service))
+	r.Get("/{code}", getGiftCardHandler(service))
+
+	return r
+}
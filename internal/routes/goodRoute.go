@@ -4,8 +4,11 @@ import (
 	"HomeApplianceStore/internal/services"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -103,7 +106,7 @@ func GetProductsHandler(service services.GoodsService) http.HandlerFunc {
 }
 
 // @Summary      Обновить товар
-// @Description  Обновляет данные товара
+// @Description  Обновляет данные товара. Остаток и себестоимость так не меняются: их ведут приёмки, продажи, списания и инвентаризации
 // @Tags         goods
 // @Accept       json
 // @Produce      json
@@ -167,14 +170,195 @@ func DeleteProductHandler(service services.GoodsService) http.HandlerFunc {
 	}
 }
 
-func NewGoodsRouter(service services.GoodsService) http.Handler {
+func writeForecastError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ProductNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.InvalidForecastPeriodError),
+		errors.Is(err, services.InvalidReorderPointError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Прогноз спроса на товар
+// @Description  Прогноз продаж по дням по истории оплаченных заказов за год: модель Хольта-Винтерса с недельной сезонностью, при короткой истории — скользящее среднее. Возвращает рекомендуемую точку заказа
+// @Tags         goods
+// @Produce      json
+// @Param        id        path      int  true   "ID товара"
+// @Param        store_id  query     int  false  "ID магазина, без него — по всей сети"
+// @Param        days      query     int  false  "Горизонт прогноза в днях, по умолчанию 30"
+// @Success      200       {object}  services.ForecastDto
+// @Failure      400       {object}  string
+// @Failure      404       {object}  string
+// @Router       /goods/{id}/forecast [get]
+func GetForecastHandler(service services.ForecastService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		days := 30
+		if value := r.URL.Query().Get("days"); value != "" {
+			if days, err = strconv.Atoi(value); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		response, err := service.GetForecast(r.Context(), int32(id), storeId, days)
+		if err != nil {
+			writeForecastError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Применить прогноз к точке заказа
+// @Description  Записывает рекомендуемые прогнозом минимальный остаток и партию дозаказа в точку заказа товара
+// @Tags         goods
+// @Produce      json
+// @Param        id        path      int  true   "ID товара"
+// @Param        store_id  query     int  false  "ID магазина, без него — порог по всей сети"
+// @Success      200       {object}  services.ReorderPointDto
+// @Failure      400       {object}  string
+// @Failure      404       {object}  string
+// @Router       /goods/{id}/forecast/apply [post]
+func ApplyForecastHandler(service services.ForecastService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.ApplyForecast(r.Context(), int32(id), storeId)
+		if err != nil {
+			writeForecastError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func writeGoodsImportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.InvalidImportFormatError),
+		errors.Is(err, services.InvalidImportFileError),
+		errors.Is(err, services.InvalidImportMappingError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// parseImportOptions читает настройки импорта из query. Формат без ?format= определяется по Content-Type
+func parseImportOptions(r *http.Request) (services.GoodsImportOptionsDto, error) {
+	options := services.GoodsImportOptionsDto{
+		Format:    r.URL.Query().Get("format"),
+		Mapping:   map[string]string{},
+		Delimiter: ',',
+	}
+	if options.Format == "" {
+		switch strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0]) {
+		case "text/csv":
+			options.Format = services.GoodsImportFormatCSV
+		case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+			options.Format = services.GoodsImportFormatXLSX
+		}
+	}
+	if value := r.URL.Query().Get("mapping"); value != "" {
+		for _, pair := range strings.Split(value, ",") {
+			field, column, ok := strings.Cut(pair, ":")
+			if !ok {
+				return options, fmt.Errorf("mapping pair %q must be field:column", pair)
+			}
+			options.Mapping[strings.TrimSpace(field)] = column
+		}
+	}
+	if value := r.URL.Query().Get("delimiter"); value != "" {
+		if utf8.RuneCountInString(value) != 1 {
+			return options, errors.New("delimiter must be a single character")
+		}
+		options.Delimiter, _ = utf8.DecodeRuneInString(value)
+	}
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return options, err
+		}
+		options.DryRun = dryRun
+	}
+	return options, nil
+}
+
+// @Summary      Импорт товаров из CSV или XLSX
+// @Description  Загружает каталог из тела запроса. Колонки сопоставляются с полями по заголовку: article, name, price, category_id, tax_rate_id, brand, другие заголовки задаются через mapping. Товар с существующим артикулом обновляется, меняются только колонки из файла. Новые товары создаются с нулевым остатком и без себестоимости: их задают приёмка и инвентаризация. Строки с ошибками, в том числе битые записи CSV, пропускаются и перечисляются в ответе
+// @Tags         goods
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        format     query     string  false  "csv или xlsx, по умолчанию по Content-Type"
+// @Param        mapping    query     string  false  "Сопоставление поле:заголовок через запятую, например article:Артикул,name:Наименование"
+// @Param        delimiter  query     string  false  "Разделитель колонок CSV, по умолчанию запятая"
+// @Param        dry_run    query     bool    false  "Только проверить файл, ничего не записывая"
+// @Success      200        {object}  services.GoodsImportResultDto
+// @Failure      400        {object}  string
+// @Router       /goods/import [post]
+func ImportGoodsHandler(service services.GoodsImportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		options, err := parseImportOptions(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.ImportGoods(r.Context(), r.Body, options)
+		if err != nil {
+			writeGoodsImportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewGoodsRouter(service services.GoodsService, forecastService services.ForecastService, importService services.GoodsImportService, exportService services.ExportService) http.Handler {
 	r := chi.NewRouter()
 
 	r.Post("/", CreateProductHandler(service))
+	r.Post("/import", ImportGoodsHandler(importService))
+	r.Get("/export", exportHandler(exportService, "goods"))
 	r.Get("/{id}", GetProductHandler(service))
 	r.Get("/", GetProductsHandler(service))
 	r.Put("/", UpdateProductHandler(service))
 	r.Delete("/{id}", DeleteProductHandler(service))
+	r.Get("/{id}/forecast", GetForecastHandler(forecastService))
+	r.Post("/{id}/forecast/apply", ApplyForecastHandler(forecastService))
 
 	return r
 }
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeGoodsReceiptError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.GoodsReceiptNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.PurchaseOrderNotFound),
+		errors.Is(err, services.EmployeeNotInStoreError),
+		errors.Is(err, services.InvalidGoodsReceiptError),
+		errors.Is(err, services.OverDeliveryError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.PurchaseOrderStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Принять товар по заказу поставщику
+// @Description  Фиксирует привезённое, бракованное и недопоставленное количество по строкам заказа. Остаток увеличивается на принятое количество, по браку и недопоставке создаётся претензия поставщику
+// @Tags         goods-receipts
+// @Accept       json
+// @Produce      json
+// @Param        receipt  body      services.CreateGoodsReceiptDto  true  "Данные приёмки"
+// @Success      201      {object}  services.GoodsReceiptDto
+// @Failure      400      {object}  string
+// @Failure      409      {object}  string
+// @Router       /goods-receipts [post]
+func createGoodsReceiptHandler(service services.GoodsReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateGoodsReceiptDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateGoodsReceipt(r.Context(), dto)
+		if err != nil {
+			writeGoodsReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить приёмку
+// @Description  Возвращает приёмку товара со строками
+// @Tags         goods-receipts
+// @Produce      json
+// @Param        id   path      int  true  "ID приёмки"
+// @Success      200  {object}  services.GoodsReceiptDto
+// @Failure      404  {object}  string
+// @Router       /goods-receipts/{id} [get]
+func getGoodsReceiptHandler(service services.GoodsReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetGoodsReceipt(r.Context(), int32(id))
+		if err != nil {
+			writeGoodsReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить приёмки
+// @Description  Возвращает приёмки товара, последние первыми
+// @Tags         goods-receipts
+// @Produce      json
+// @Param        purchase_order_id  query     int  false  "ID заказа поставщику"
+// @Success      200                {array}   services.GoodsReceiptDto
+// @Failure      400                {object}  string
+// @Router       /goods-receipts [get]
+func getGoodsReceiptsHandler(service services.GoodsReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		purchaseOrderId, err := parseOptionalId(r, "purchase_order_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetGoodsReceipts(r.Context(), purchaseOrderId)
+		if err != nil {
+			writeGoodsReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewGoodsReceiptRouter(service services.GoodsReceiptService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createGoodsReceiptHandler(service))
+	r.Get("/", getGoodsReceiptsHandler(service))
+	r.Get("/{id}", getGoodsReceiptHandler(service))
+
+	return r
+}
@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeOrderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.OrderNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.EmptyOrderError),
+		errors.Is(err, services.ProductNotFound),
+		errors.Is(err, services.ServiceNotFound),
+		errors.Is(err, services.EmployeeNotFound),
+		errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.RegisterNotFound),
+		errors.Is(err, services.InvalidRegisterError),
+		errors.Is(err, services.InvalidQuantityError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.InsufficientStockError),
+		errors.Is(err, services.RegisterClosedError),
+		errors.Is(err, services.OrderStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать заказ
+// @Description  Создаёт заказ и резервирует товары на время оплаты
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        order  body      services.CreateOrderDto  true  "Данные заказа"
+// @Success      201    {object}  services.OrderDto
+// @Failure      400    {object}  string
+// @Failure      409    {object}  string
+// @Router       /orders [post]
+func createOrderHandler(service services.OrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateOrderDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateOrder(r.Context(), dto)
+		if err != nil {
+			writeOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить заказ по id
+// @Description  Возвращает заказ с позициями и статусом оплаты
+// @Tags         orders
+// @Produce      json
+// @Param        id   path      int  true  "ID заказа"
+// @Success      200  {object}  services.OrderDto
+// @Failure      404  {object}  string
+// @Router       /orders/{id} [get]
+func getOrderHandler(service services.OrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetOrder(r.Context(), int32(id))
+		if err != nil {
+			writeOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить список заказов
+// @Description  Возвращает все заказы, новые первыми
+// @Tags         orders
+// @Produce      json
+// @Success      200  {array}   services.OrderDto
+// @Router       /orders [get]
+func getOrdersHandler(service services.OrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetOrders(r.Context())
+		if err != nil {
+			writeOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отменить заказ
+// @Description  Отменяет неоплаченный заказ и снимает резервы
+// @Tags         orders
+// @Produce      json
+// @Param        id   path      int  true  "ID заказа"
+// @Success      200  {object}  services.OrderDto
+// @Failure      409  {object}  string
+// @Router       /orders/{id}/cancel [post]
+func cancelOrderHandler(service services.OrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.CancelOrder(r.Context(), int32(id))
+		if err != nil {
+			writeOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewOrderRouter(service services.OrderService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createOrderHandler(service))
+	r.Get("/", getOrdersHandler(service))
+	r.Get("/{id}", getOrderHandler(service))
+	r.Post("/{id}/cancel", cancelOrderHandler(service))
+
+	return r
+}
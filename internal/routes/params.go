@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// parsePeriod читает период отчёта из ?from=YYYY-MM-DD&to=YYYY-MM-DD, обе даты включительно.
+// Возвращает полуинтервал [from, to+1 день). По умолчанию — последние 30 дней. from позже to — ошибка.
+func parsePeriod(r *http.Request) (time.Time, time.Time, error) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := to.AddDate(0, 0, -29)
+	var err error
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// parseOptionalId читает необязательный идентификатор из query, nil — параметр не передан
+func parseOptionalId(r *http.Request, name string) (*int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	result := int32(id)
+	return &result, nil
+}
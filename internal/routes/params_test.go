@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		query   string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{"?from=2025-03-01&to=2025-03-31", "2025-03-01", "2025-04-01", false},
+		{"?from=2025-03-01&to=2025-03-01", "2025-03-01", "2025-03-02", false},
+		{"?from=2025-03-02&to=2025-03-01", "", "", true},
+		{"?from=2025-13-01&to=2025-03-01", "", "", true},
+	}
+	for _, test := range tests {
+		from, to, err := parsePeriod(httptest.NewRequest("GET", "/reports/sales"+test.query, nil))
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+		if from.Format(dateLayout) != test.from || to.Format(dateLayout) != test.to {
+			t.Errorf("%s: [%s, %s), want [%s, %s)", test.query, from.Format(dateLayout), to.Format(dateLayout), test.from, test.to)
+		}
+	}
+}
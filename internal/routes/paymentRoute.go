@@ -0,0 +1,227 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.PaymentNotFound),
+		errors.Is(err, services.OrderNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidTenderError),
+		errors.Is(err, services.TenderTotalMismatchError),
+		errors.Is(err, services.GiftCardNotFound):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.InvalidWebhookSignatureError):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, services.PaymentDeclinedError):
+		w.WriteHeader(http.StatusPaymentRequired)
+	case errors.Is(err, services.PaymentStatusError),
+		errors.Is(err, services.OrderStatusError),
+		errors.Is(err, services.InsufficientBalanceError),
+		errors.Is(err, services.InsufficientGiftCardBalanceError),
+		errors.Is(err, services.ReservationNotActiveError),
+		errors.Is(err, services.RegisterClosedError),
+		errors.Is(err, services.InsufficientStockError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Оплатить заказ
+// @Description  Оплачивает заказ одним или несколькими способами: наличные, карта, баланс, подарочная карта.
+// @Description  Сумма способов оплаты должна совпадать с суммой заказа. Карта авторизуется у платёжного провайдера,
+// @Description  остальные способы проводятся сразу. После авторизации карты резервы заказа продлеваются
+// @Description  на срок авторизации, чтобы списание прошло и после обычного срока резерва.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Param        payment  body      services.CreatePaymentDto  true  "Заказ и способы оплаты"
+// @Success      201      {array}   services.PaymentDto
+// @Failure      400      {object}  string
+// @Failure      402      {object}  string
+// @Failure      409      {object}  string
+// @Router       /payments [post]
+func createPaymentHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreatePaymentDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreatePayment(r.Context(), dto)
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить платежи заказа
+// @Description  Возвращает все платежи по заказу
+// @Tags         payments
+// @Produce      json
+// @Param        order_id  query     int  true  "ID заказа"
+// @Success      200       {array}   services.PaymentDto
+// @Failure      400       {object}  string
+// @Router       /payments [get]
+func getOrderPaymentsHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orderId, err := strconv.Atoi(r.URL.Query().Get("order_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetOrderPayments(r.Context(), int32(orderId))
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить платёж по id
+// @Description  Возвращает платёж по идентификатору
+// @Tags         payments
+// @Produce      json
+// @Param        id   path      int  true  "ID платежа"
+// @Success      200  {object}  services.PaymentDto
+// @Failure      404  {object}  string
+// @Router       /payments/{id} [get]
+func getPaymentHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetPayment(r.Context(), int32(id))
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Списать авторизованный платёж
+// @Description  Захватывает деньги по карте. Когда проведены все способы оплаты, резервы списываются со склада.
+// @Description  На время запроса к эквайеру платёж находится в статусе capturing; если ответ не получен,
+// @Description  вызов можно повторить — списание уйдёт с тем же ключом идемпотентности и не пройдёт дважды.
+// @Tags         payments
+// @Produce      json
+// @Param        id   path      int  true  "ID платежа"
+// @Success      200  {object}  services.PaymentDto
+// @Failure      402  {object}  string
+// @Failure      409  {object}  string
+// @Router       /payments/{id}/capture [post]
+func capturePaymentHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.CapturePayment(r.Context(), int32(id))
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отчёт по способам оплаты
+// @Description  Количество платежей, суммы оплат и возвратов по каждому способу оплаты за период
+// @Tags         payments
+// @Produce      json
+// @Param        from  query     string  false  "Начало периода, YYYY-MM-DD (по умолчанию 30 дней назад)"
+// @Param        to    query     string  false  "Конец периода включительно, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {array}   services.TenderReportDto
+// @Failure      400   {object}  string
+// @Router       /payments/report [get]
+func getTenderReportHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetTenderReport(r.Context(), from, to)
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Уведомление от платёжного провайдера
+// @Description  Принимает подписанный webhook эквайера и обновляет статус платежа
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Param        X-Signature  header    string                        true  "HMAC-SHA256 подпись тела"
+// @Param        event        body      services.PaymentWebhookEvent  true  "Событие"
+// @Success      200          {object}  services.PaymentDto
+// @Failure      401          {object}  string
+// @Router       /payments/webhook [post]
+func paymentWebhookHandler(service services.PaymentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.HandleWebhook(r.Context(), payload, r.Header.Get(services.PaymentSignatureHeader))
+		if err != nil {
+			writePaymentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewPaymentRouter(service services.PaymentService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createPaymentHandler(service))
+	r.Get("/", getOrderPaymentsHandler(service))
+	r.Post("/webhook", paymentWebhookHandler(service))
+	r.Get("/report", getTenderReportHandler(service))
+	r.Get("/{id}", getPaymentHandler(service))
+	r.Post("/{id}/capture", capturePaymentHandler(service))
+
+	return r
+}
@@ -0,0 +1,188 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writePurchaseOrderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.PurchaseOrderNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.SupplierNotFoundError),
+		errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.ProductNotFound),
+		errors.Is(err, services.InvalidPurchaseOrderError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.PurchaseOrderStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать заказ поставщику
+// @Description  Создаёт черновик заказа поставщику
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        purchase_order  body      services.CreatePurchaseOrderDto  true  "Данные заказа"
+// @Success      201             {object}  services.PurchaseOrderDto
+// @Failure      400             {object}  string
+// @Router       /purchase-orders [post]
+func createPurchaseOrderHandler(service services.PurchaseOrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreatePurchaseOrderDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreatePurchaseOrder(r.Context(), dto)
+		if err != nil {
+			writePurchaseOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить заказ поставщику
+// @Description  Возвращает заказ поставщику с товарами
+// @Tags         purchase-orders
+// @Produce      json
+// @Param        id   path      int  true  "ID заказа"
+// @Success      200  {object}  services.PurchaseOrderDto
+// @Failure      404  {object}  string
+// @Router       /purchase-orders/{id} [get]
+func getPurchaseOrderHandler(service services.PurchaseOrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetPurchaseOrder(r.Context(), int32(id))
+		if err != nil {
+			writePurchaseOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить заказы поставщикам
+// @Description  Возвращает заказы поставщикам, последние первыми
+// @Tags         purchase-orders
+// @Produce      json
+// @Param        supplier_id  query     int     false  "ID поставщика"
+// @Param        status       query     string  false  "Статус: draft, sent, partially_received, received или cancelled"
+// @Success      200          {array}   services.PurchaseOrderDto
+// @Failure      400          {object}  string
+// @Router       /purchase-orders [get]
+func getPurchaseOrdersHandler(service services.PurchaseOrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		supplierId, err := parseOptionalId(r, "supplier_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetPurchaseOrders(r.Context(), supplierId, r.URL.Query().Get("status"))
+		if err != nil {
+			writePurchaseOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отправить заказ поставщику
+// @Description  Переводит черновик в статус sent, после чего товар из заказа считается заказанным
+// @Tags         purchase-orders
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID заказа"
+// @Param        send  body      services.SendPurchaseOrderDto  true  "Ожидаемая дата поставки"
+// @Success      200   {object}  services.PurchaseOrderDto
+// @Failure      404   {object}  string
+// @Failure      409   {object}  string
+// @Router       /purchase-orders/{id}/send [post]
+func sendPurchaseOrderHandler(service services.PurchaseOrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.SendPurchaseOrderDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.SendPurchaseOrder(r.Context(), int32(id), dto)
+		if err != nil {
+			writePurchaseOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отменить заказ поставщику
+// @Description  Отменяет черновик или отправленный заказ
+// @Tags         purchase-orders
+// @Produce      json
+// @Param        id   path      int  true  "ID заказа"
+// @Success      200  {object}  services.PurchaseOrderDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /purchase-orders/{id}/cancel [post]
+func cancelPurchaseOrderHandler(service services.PurchaseOrderService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.CancelPurchaseOrder(r.Context(), int32(id))
+		if err != nil {
+			writePurchaseOrderError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewPurchaseOrderRouter(service services.PurchaseOrderService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createPurchaseOrderHandler(service))
+	r.Get("/", getPurchaseOrdersHandler(service))
+	r.Get("/{id}", getPurchaseOrderHandler(service))
+	r.Post("/{id}/send", sendPurchaseOrderHandler(service))
+	r.Post("/{id}/cancel", cancelPurchaseOrderHandler(service))
+
+	return r
+}
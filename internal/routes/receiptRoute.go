@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeReceiptError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ReceiptNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.ReceiptStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Получить чеки заказа
+// @Description  Возвращает чеки продажи и возврата по заказу с фискальными реквизитами
+// @Tags         receipts
+// @Produce      json
+// @Param        order_id  query     int  true  "ID заказа"
+// @Success      200       {array}   services.ReceiptDto
+// @Failure      400       {object}  string
+// @Router       /receipts [get]
+func getOrderReceiptsHandler(service services.ReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orderId, err := strconv.Atoi(r.URL.Query().Get("order_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetOrderReceipts(r.Context(), int32(orderId))
+		if err != nil {
+			writeReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить чек по id
+// @Description  Возвращает чек с документом в структуре тегов ФФД 1.2
+// @Tags         receipts
+// @Produce      json
+// @Param        id   path      int  true  "ID чека"
+// @Success      200  {object}  services.ReceiptDto
+// @Failure      404  {object}  string
+// @Router       /receipts/{id} [get]
+func getReceiptHandler(service services.ReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetReceipt(r.Context(), int32(id))
+		if err != nil {
+			writeReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Зарегистрировать чек
+// @Description  Отправляет чек в фискальный регистратор, не дожидаясь фоновой очереди. Подходит для повтора ошибочных чеков
+// @Description  и чеков в статусе registering: повтор отправляет тот же документ и не пробивает чек дважды
+// @Tags         receipts
+// @Produce      json
+// @Param        id   path      int  true  "ID чека"
+// @Success      200  {object}  services.ReceiptDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /receipts/{id}/register [post]
+func registerReceiptHandler(service services.ReceiptService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.RegisterReceipt(r.Context(), int32(id))
+		if err != nil {
+			writeReceiptError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewReceiptRouter(service services.ReceiptService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/", getOrderReceiptsHandler(service))
+	r.Get("/{id}", getReceiptHandler(service))
+	r.Post("/{id}/register", registerReceiptHandler(service))
+
+	return r
+}
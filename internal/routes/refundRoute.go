@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeRefundError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.RefundNotFound),
+		errors.Is(err, services.OrderNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidRefundAmountError),
+		errors.Is(err, services.RefundQuantityError),
+		errors.Is(err, services.InvalidQuantityError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.OrderStatusError),
+		errors.Is(err, services.RegisterClosedError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Оформить возврат
+// @Description  Возврат товаров или суммы по оплаченному заказу. Деньги возвращаются на исходные способы оплаты:
+// @Description  сначала на карту, затем на подарочные карты и баланс, наличными — в последнюю очередь.
+// @Description  Возврат на карту проводится у эквайера после записи возврата; пока он не подтверждён,
+// @Description  часть возврата остаётся в статусе pending и повторяется в фоне.
+// @Tags         refunds
+// @Accept       json
+// @Produce      json
+// @Param        refund  body      services.CreateRefundDto  true  "Данные возврата"
+// @Success      201     {object}  services.RefundDto
+// @Failure      400     {object}  string
+// @Failure      409     {object}  string
+// @Router       /refunds [post]
+func createRefundHandler(service services.RefundService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateRefundDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateRefund(r.Context(), dto)
+		if err != nil {
+			writeRefundError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить возвраты заказа
+// @Description  Возвращает все возвраты по заказу
+// @Tags         refunds
+// @Produce      json
+// @Param        order_id  query     int  true  "ID заказа"
+// @Success      200       {array}   services.RefundDto
+// @Failure      400       {object}  string
+// @Router       /refunds [get]
+func getOrderRefundsHandler(service services.RefundService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orderId, err := strconv.Atoi(r.URL.Query().Get("order_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetOrderRefunds(r.Context(), int32(orderId))
+		if err != nil {
+			writeRefundError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить возврат по id
+// @Description  Возвращает возврат с товарами и разбивкой по способам оплаты
+// @Tags         refunds
+// @Produce      json
+// @Param        id   path      int  true  "ID возврата"
+// @Success      200  {object}  services.RefundDto
+// @Failure      404  {object}  string
+// @Router       /refunds/{id} [get]
+func getRefundHandler(service services.RefundService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetRefund(r.Context(), int32(id))
+		if err != nil {
+			writeRefundError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewRefundRouter(service services.RefundService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createRefundHandler(service))
+	r.Get("/", getOrderRefundsHandler(service))
+	r.Get("/{id}", getRefundHandler(service))
+
+	return r
+}
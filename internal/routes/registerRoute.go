@@ -0,0 +1,322 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeRegisterError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.RegisterNotFound),
+		errors.Is(err, services.RegisterSessionNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.EmployeeNotInStoreError),
+		errors.Is(err, services.InvalidCashMovementError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.RegisterClosedError),
+		errors.Is(err, services.RegisterAlreadyOpenError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать кассу
+// @Description  Регистрирует кассу в магазине. Касса создаётся закрытой
+// @Tags         registers
+// @Accept       json
+// @Produce      json
+// @Param        register  body      services.CreateRegisterDto  true  "Данные кассы"
+// @Success      201       {object}  services.RegisterDto
+// @Failure      400       {object}  string
+// @Router       /registers [post]
+func createRegisterHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateRegisterDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateRegister(r.Context(), dto)
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить кассу
+// @Description  Возвращает кассу и её открытую смену
+// @Tags         registers
+// @Produce      json
+// @Param        id   path      int  true  "ID кассы"
+// @Success      200  {object}  services.RegisterDto
+// @Failure      404  {object}  string
+// @Router       /registers/{id} [get]
+func getRegisterHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetRegister(r.Context(), int32(id))
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить кассы
+// @Description  Возвращает все кассы или кассы магазина
+// @Tags         registers
+// @Produce      json
+// @Param        store_id  query     int  false  "ID магазина"
+// @Success      200       {array}   services.RegisterDto
+// @Failure      400       {object}  string
+// @Router       /registers [get]
+func getRegistersHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetRegisters(r.Context(), storeId)
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Открыть смену кассы
+// @Description  Открывает смену с разменом в кассе. Открыть кассу может только сотрудник, работающий в её магазине
+// @Tags         registers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "ID кассы"
+// @Param        session  body      services.OpenRegisterDto  true  "Кассир и размен"
+// @Success      201      {object}  services.RegisterSessionDto
+// @Failure      400      {object}  string
+// @Failure      404      {object}  string
+// @Failure      409      {object}  string
+// @Router       /registers/{id}/open [post]
+func openRegisterHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.OpenRegisterDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.OpenRegister(r.Context(), int32(id), dto)
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Закрыть смену кассы
+// @Description  Закрывает смену: сверяет пересчитанные наличные с ожидаемыми и формирует Z-отчёт.
+// @Description  После закрытия продажи и возвраты наличными через кассу невозможны до открытия новой смены.
+// @Tags         registers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true  "ID кассы"
+// @Param        session  body      services.CloseRegisterDto  true  "Кассир и пересчитанные наличные"
+// @Success      200      {object}  services.ZReportDto
+// @Failure      400      {object}  string
+// @Failure      404      {object}  string
+// @Failure      409      {object}  string
+// @Router       /registers/{id}/close [post]
+func closeRegisterHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.CloseRegisterDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CloseRegister(r.Context(), int32(id), dto)
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Внести или изъять наличные
+// @Description  Записывает внесение (cash_in) или изъятие (cash_out) наличных в открытую смену кассы
+// @Tags         registers
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                             true  "ID кассы"
+// @Param        movement  body      services.CreateCashMovementDto  true  "Движение наличных"
+// @Success      201       {object}  services.CashMovementDto
+// @Failure      400       {object}  string
+// @Failure      409       {object}  string
+// @Router       /registers/{id}/cash [post]
+func createCashMovementHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.CreateCashMovementDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.AddCashMovement(r.Context(), int32(id), dto)
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить смены кассы
+// @Description  Возвращает смены кассы, последние первыми
+// @Tags         registers
+// @Produce      json
+// @Param        id   path      int  true  "ID кассы"
+// @Success      200  {array}   services.RegisterSessionDto
+// @Router       /registers/{id}/sessions [get]
+func getRegisterSessionsHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSessions(r.Context(), int32(id))
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить смену кассы
+// @Description  Возвращает смену кассы с движениями наличных
+// @Tags         registers
+// @Produce      json
+// @Param        id   path      int  true  "ID смены"
+// @Success      200  {object}  services.RegisterSessionDto
+// @Failure      404  {object}  string
+// @Router       /registers/sessions/{id} [get]
+func getRegisterSessionHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSession(r.Context(), int32(id))
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить Z-отчёт
+// @Description  Возвращает Z-отчёт закрытой смены кассы
+// @Tags         registers
+// @Produce      json
+// @Param        id   path      int  true  "ID смены"
+// @Success      200  {object}  services.ZReportDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /registers/sessions/{id}/z-report [get]
+func getZReportHandler(service services.RegisterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetZReport(r.Context(), int32(id))
+		if err != nil {
+			writeRegisterError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewRegisterRouter(service services.RegisterService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createRegisterHandler(service))
+	r.Get("/", getRegistersHandler(service))
+	r.Get("/sessions/{id}", getRegisterSessionHandler(service))
+	r.Get("/sessions/{id}/z-report", getZReportHandler(service))
+	r.Get("/{id}", getRegisterHandler(service))
+	r.Post("/{id}/open", openRegisterHandler(service))
+	r.Post("/{id}/close", closeRegisterHandler(service))
+	r.Post("/{id}/cash", createCashMovementHandler(service))
+	r.Get("/{id}/sessions", getRegisterSessionsHandler(service))
+
+	return r
+}
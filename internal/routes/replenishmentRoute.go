@@ -0,0 +1,174 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeReplenishmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ReorderPointNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.SupplierNotFoundError),
+		errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.ProductNotFound),
+		errors.Is(err, services.InvalidReorderPointError),
+		errors.Is(err, services.InvalidPurchaseOrderError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.NoSuggestionsError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Предложения к заказу
+// @Description  Товары ниже точки заказа, сгруппированные по лучшему поставщику. Предложения пересчитываются фоновой задачей раз в час
+// @Tags         replenishment
+// @Produce      json
+// @Param        refresh  query     bool  false  "Пересчитать предложения перед выдачей"
+// @Success      200      {array}   services.SupplierSuggestionsDto
+// @Router       /replenishment/suggestions [get]
+func getSuggestionsHandler(service services.ReplenishmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("refresh") == "true" {
+			if _, err := service.RefreshSuggestions(r.Context()); err != nil {
+				writeReplenishmentError(w, err)
+				return
+			}
+		}
+		response, err := service.GetSuggestions(r.Context())
+		if err != nil {
+			writeReplenishmentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Оформить заказ по предложениям
+// @Description  Создаёт черновик заказа поставщику из всех открытых предложений по нему
+// @Tags         replenishment
+// @Accept       json
+// @Produce      json
+// @Param        convert  body      services.ConvertSuggestionsDto  true  "Поставщик и магазин приёмки"
+// @Success      201      {object}  services.PurchaseOrderDto
+// @Failure      400      {object}  string
+// @Failure      409      {object}  string
+// @Router       /replenishment/suggestions/convert [post]
+func convertSuggestionsHandler(service services.ReplenishmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.ConvertSuggestionsDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.ConvertSuggestions(r.Context(), dto)
+		if err != nil {
+			writeReplenishmentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить точки заказа
+// @Description  Возвращает пороги остатков по сети и по магазинам
+// @Tags         replenishment
+// @Produce      json
+// @Param        good_id  query     int  false  "ID товара"
+// @Success      200      {array}   services.ReorderPointDto
+// @Failure      400      {object}  string
+// @Router       /replenishment/reorder-points [get]
+func getReorderPointsHandler(service services.ReplenishmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		goodId, err := parseOptionalId(r, "good_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetReorderPoints(r.Context(), goodId)
+		if err != nil {
+			writeReplenishmentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Задать точку заказа
+// @Description  Задаёт минимальный остаток и партию дозаказа товара по сети или в магазине, заменяя прежние значения
+// @Tags         replenishment
+// @Accept       json
+// @Produce      json
+// @Param        reorder_point  body      services.SetReorderPointDto  true  "Порог и партия"
+// @Success      200            {object}  services.ReorderPointDto
+// @Failure      400            {object}  string
+// @Router       /replenishment/reorder-points [put]
+func setReorderPointHandler(service services.ReplenishmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.SetReorderPointDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.SetReorderPoint(r.Context(), dto)
+		if err != nil {
+			writeReplenishmentError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить точку заказа
+// @Tags         replenishment
+// @Param        id   path      int  true  "ID точки заказа"
+// @Success      204
+// @Failure      404  {object}  string
+// @Router       /replenishment/reorder-points/{id} [delete]
+func deleteReorderPointHandler(service services.ReplenishmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteReorderPoint(r.Context(), int32(id)); err != nil {
+			writeReplenishmentError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewReplenishmentRouter(service services.ReplenishmentService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/suggestions", getSuggestionsHandler(service))
+	r.Post("/suggestions/convert", convertSuggestionsHandler(service))
+	r.Get("/reorder-points", getReorderPointsHandler(service))
+	r.Put("/reorder-points", setReorderPointHandler(service))
+	r.Delete("/reorder-points/{id}", deleteReorderPointHandler(service))
+
+	return r
+}
@@ -0,0 +1,278 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func writeReportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.InvalidReportGroupingError),
+		errors.Is(err, services.InvalidReportDaysError),
+		errors.Is(err, services.InvalidAnalysisIntervalError),
+		errors.Is(err, services.InvalidAnalysisPeriodError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Оценка товарных запасов
+// @Description  Стоимость остатков по средней взвешенной себестоимости на конец дня по магазинам и категориям. Остатки ведутся по сети, разбивка по магазинам — сальдо движений магазина по журналу остатков.
+// @Description  Оценка точна с того дня, когда появились журнал остатков и учёт себестоимости: остаток, заведённый при создании товара, импортом или до появления журнала, а также проданный до записи продаж в журнал, попадает в нераспределённую по магазинам часть
+// @Tags         reports
+// @Produce      json
+// @Param        date      query     string  false  "Дата оценки, YYYY-MM-DD, по умолчанию сегодня"
+// @Param        store_id  query     int     false  "ID магазина"
+// @Success      200       {object}  services.InventoryValuationDto
+// @Failure      400       {object}  string
+// @Router       /reports/inventory-valuation [get]
+func getInventoryValuationHandler(service services.ReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		var err error
+		if value := r.URL.Query().Get("date"); value != "" {
+			if date, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetInventoryValuation(r.Context(), date, storeId)
+		if err != nil {
+			writeReportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отчёт о продажах
+// @Description  Выручка, количество, себестоимость, маржа и средний чек оплаченных заказов за период с группировкой по времени, магазину, категории, бренду и продавцу.
+// @Description  Выручка валовая: возвраты не вычитаются. Маржа считается только по строкам с известной себестоимостью, выручка остальных строк — в uncosted_revenue
+// @Tags         reports
+// @Produce      json
+// @Param        from         query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to           query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Param        group_by     query     string  false  "Измерения через запятую: day, week или month, store, category, brand, employee"
+// @Param        store_id     query     int     false  "ID магазина"
+// @Param        category_id  query     int     false  "ID категории"
+// @Param        brand        query     string  false  "Бренд"
+// @Param        employee_id  query     int     false  "ID продавца"
+// @Success      200          {object}  services.SalesReportDto
+// @Failure      400          {object}  string
+// @Router       /reports/sales [get]
+func getSalesReportHandler(service services.ReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		filter := services.SalesReportFilterDto{Brand: r.URL.Query().Get("brand")}
+		if filter.StoreId, err = parseOptionalId(r, "store_id"); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if filter.CategoryId, err = parseOptionalId(r, "category_id"); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if filter.EmployeeId, err = parseOptionalId(r, "employee_id"); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		groupBy := []string{}
+		if value := r.URL.Query().Get("group_by"); value != "" {
+			groupBy = strings.Split(value, ",")
+		}
+		response, err := service.GetSalesReport(r.Context(), from, to, groupBy, filter)
+		if err != nil {
+			writeReportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// parseDays читает число дней из ?days=, по умолчанию defaultDays
+func parseDays(r *http.Request, defaultDays int) (int, error) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return defaultDays, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// @Summary      Товары с низким остатком
+// @Description  Товары, доступный остаток которых ниже порога, с запасом в днях при текущих продажах. Без threshold порогом служит минимальный остаток точки заказа
+// @Tags         reports
+// @Produce      json
+// @Param        threshold  query     int  false  "Порог доступного остатка"
+// @Param        days       query     int  false  "За сколько дней считать средние продажи, по умолчанию 30"
+// @Success      200        {array}   services.LowStockItemDto
+// @Failure      400        {object}  string
+// @Router       /reports/low-stock [get]
+func getLowStockHandler(service services.ReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		threshold, err := parseOptionalId(r, "threshold")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		days, err := parseDays(r, 30)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetLowStock(r.Context(), threshold, days)
+		if err != nil {
+			writeReportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Неликвидные товары
+// @Description  Товары на складе без продаж за последние days дней и замороженная в них сумма по себестоимости
+// @Tags         reports
+// @Produce      json
+// @Param        days  query     int  false  "Сколько дней без продаж, по умолчанию 90"
+// @Success      200   {object}  services.DeadStockDto
+// @Failure      400   {object}  string
+// @Router       /reports/dead-stock [get]
+func getDeadStockHandler(service services.ReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		days, err := parseDays(r, 90)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetDeadStock(r.Context(), days)
+		if err != nil {
+			writeReportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func writeAbcXyzCSV(w http.ResponseWriter, analysis services.AbcXyzDto) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=abc-xyz-%s-%s.csv", analysis.From, analysis.To))
+	w.WriteHeader(http.StatusOK)
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"good_id", "article", "name", "revenue", "share", "cumulative_share", "units",
+		"variation", "abc", "xyz", "class"})
+	for _, item := range analysis.Items {
+		variation := ""
+		if item.Variation != nil {
+			variation = strconv.FormatFloat(*item.Variation, 'f', 2, 64)
+		}
+		writer.Write([]string{
+			strconv.Itoa(int(item.GoodId)),
+			item.Article,
+			item.Name,
+			strconv.FormatInt(item.Revenue, 10),
+			strconv.FormatFloat(item.Share, 'f', 2, 64),
+			strconv.FormatFloat(item.CumulativeShare, 'f', 2, 64),
+			strconv.FormatInt(item.Units, 10),
+			variation,
+			item.Abc,
+			item.Xyz,
+			item.Class,
+		})
+	}
+	writer.Flush()
+}
+
+// @Summary      ABC/XYZ-анализ ассортимента
+// @Description  ABC — вклад товара в выручку за период: A до 80% накопленной доли, B до 95%, остальные C. XYZ — стабильность продаж по коэффициенту вариации по неделям или месяцам: X до 10%, Y до 25%, остальные Z. Матрица — число товаров и выручка в каждом из девяти классов.
+// @Description  Период расширяется до целых недель или месяцев, расширенный период возвращается в from и to. Не больше 520 недель или месяцев
+// @Tags         reports
+// @Produce      json
+// @Produce      text/csv
+// @Param        from      query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to        query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Param        interval  query     string  false  "Интервал для XYZ: week (по умолчанию) или month"
+// @Param        format    query     string  false  "json (по умолчанию) или csv"
+// @Success      200       {object}  services.AbcXyzDto
+// @Failure      400       {object}  string
+// @Router       /reports/abc-xyz [get]
+func getAbcXyzHandler(service services.ReportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("format must be json or csv"))
+			return
+		}
+		interval := r.URL.Query().Get("interval")
+		if interval == "" {
+			interval = "week"
+		}
+		response, err := service.GetAbcXyz(r.Context(), from, to, interval)
+		if err != nil {
+			writeReportError(w, err)
+			return
+		}
+		if format == "csv" {
+			writeAbcXyzCSV(w, response)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewReportRouter(service services.ReportService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/inventory-valuation", getInventoryValuationHandler(service))
+	r.Get("/sales", getSalesReportHandler(service))
+	r.Get("/low-stock", getLowStockHandler(service))
+	r.Get("/dead-stock", getDeadStockHandler(service))
+	r.Get("/abc-xyz", getAbcXyzHandler(service))
+
+	return r
+}
@@ -0,0 +1,170 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeReservationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ReservationNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.ProductNotFound),
+		errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.InvalidQuantityError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.InsufficientStockError),
+		errors.Is(err, services.ReservationNotActiveError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Зарезервировать товар
+// @Description  Резервирует количество товара в магазине на время жизни резерва
+// @Tags         reservations
+// @Accept       json
+// @Produce      json
+// @Param        reservation  body      services.CreateReservationDto  true  "Данные резерва"
+// @Success      201          {object}  services.ReservationDto
+// @Failure      400          {object}  string
+// @Failure      409          {object}  string
+// @Router       /reservations [post]
+func createReservationHandler(service services.ReservationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateReservationDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateReservation(r.Context(), dto)
+		if err != nil {
+			writeReservationError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить резерв по id
+// @Description  Возвращает резерв по идентификатору
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "ID резерва"
+// @Success      200  {object}  services.ReservationDto
+// @Failure      404  {object}  string
+// @Router       /reservations/{id} [get]
+func getReservationHandler(service services.ReservationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetReservation(r.Context(), int32(id))
+		if err != nil {
+			writeReservationError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить активные резервы
+// @Description  Возвращает активные резервы, при указании owner — только резервы владельца
+// @Tags         reservations
+// @Produce      json
+// @Param        owner  query     string  false  "Владелец резерва (корзина, заказ)"
+// @Success      200    {array}   services.ReservationDto
+// @Router       /reservations [get]
+func getReservationsHandler(service services.ReservationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetActiveReservations(r.Context(), r.URL.Query().Get("owner"))
+		if err != nil {
+			writeReservationError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Провести резерв
+// @Description  Превращает активный резерв в продажу и списывает товар со склада
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "ID резерва"
+// @Success      200  {object}  services.ReservationDto
+// @Failure      409  {object}  string
+// @Router       /reservations/{id}/commit [post]
+func commitReservationHandler(service services.ReservationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.CommitReservation(r.Context(), int32(id))
+		if err != nil {
+			writeReservationError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Снять резерв
+// @Description  Освобождает активный резерв
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "ID резерва"
+// @Success      200  {object}  services.ReservationDto
+// @Failure      409  {object}  string
+// @Router       /reservations/{id} [delete]
+func releaseReservationHandler(service services.ReservationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.ReleaseReservation(r.Context(), int32(id))
+		if err != nil {
+			writeReservationError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewReservationRouter(service services.ReservationService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createReservationHandler(service))
+	r.Get("/", getReservationsHandler(service))
+	r.Get("/{id}", getReservationHandler(service))
+	r.Post("/{id}/commit", commitReservationHandler(service))
+	r.Delete("/{id}", releaseReservationHandler(service))
+
+	return r
+}
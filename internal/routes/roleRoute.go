@@ -3,6 +3,7 @@ package routes
 import (
 	"HomeApplianceStore/internal/services"
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"net/http"
 	"strconv"
@@ -120,6 +121,95 @@ func updateRoleHandler(roleService *services.RoleService) http.HandlerFunc {
 	}
 }
 
+func writePermissionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.RoleNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.UnknownPermissionError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Получить права роли
+// @Description  Возвращает права, выданные роли
+// @Tags         roles
+// @Produce      json
+// @Param        id   path      int  true  "ID роли"
+// @Success      200  {array}   string
+// @Failure      404  {object}  string
+// @Router       /roles/{id}/permissions [get]
+func getRolePermissionsHandler(roleService *services.RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid id"))
+			return
+		}
+		permissions, err := roleService.GetPermissions(r.Context(), int32(id))
+		if err != nil {
+			writePermissionError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(permissions)
+	}
+}
+
+// @Summary      Выдать право роли
+// @Description  Выдаёт роли право, например write_offs.approve — утверждение списаний
+// @Tags         roles
+// @Produce      json
+// @Param        id          path      int     true  "ID роли"
+// @Param        permission  path      string  true  "Право"
+// @Success      200         {array}   string
+// @Failure      400         {object}  string
+// @Failure      404         {object}  string
+// @Router       /roles/{id}/permissions/{permission} [put]
+func grantRolePermissionHandler(roleService *services.RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid id"))
+			return
+		}
+		permissions, err := roleService.GrantPermission(r.Context(), int32(id), chi.URLParam(r, "permission"))
+		if err != nil {
+			writePermissionError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(permissions)
+	}
+}
+
+// @Summary      Отозвать право роли
+// @Description  Отзывает у роли право
+// @Tags         roles
+// @Param        id          path      int     true  "ID роли"
+// @Param        permission  path      string  true  "Право"
+// @Success      204
+// @Router       /roles/{id}/permissions/{permission} [delete]
+func revokeRolePermissionHandler(roleService *services.RoleService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid id"))
+			return
+		}
+		if err := roleService.RevokePermission(r.Context(), int32(id), chi.URLParam(r, "permission")); err != nil {
+			writePermissionError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func NewRoleRouter(roleService *services.RoleService) http.Handler {
 	r := chi.NewRouter()
 
@@ -127,6 +217,9 @@ func NewRoleRouter(roleService *services.RoleService) http.Handler {
 	r.Get("/{id}", getRoleByIDHandler(roleService))
 	r.Post("/", createRoleHandler(roleService))
 	r.Put("/{id}", updateRoleHandler(roleService))
+	r.Get("/{id}/permissions", getRolePermissionsHandler(roleService))
+	r.Put("/{id}/permissions/{permission}", grantRolePermissionHandler(roleService))
+	r.Delete("/{id}/permissions/{permission}", revokeRolePermissionHandler(roleService))
 
 	return r
 }
@@ -0,0 +1,161 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ServiceNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.InvalidServiceError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать услугу
+// @Description  Добавляет услугу в каталог: установка, подключение, ремонт. Услуги не имеют остатков
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Param        service  body      services.CreateServiceDto  true  "Данные услуги"
+// @Success      201      {object}  services.ServiceDto
+// @Failure      400      {object}  string
+// @Router       /services [post]
+func createServiceHandler(service services.ServiceCatalogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateServiceDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateService(r.Context(), dto)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить услугу по id
+// @Description  Возвращает услугу по идентификатору
+// @Tags         services
+// @Produce      json
+// @Param        id   path      int  true  "ID услуги"
+// @Success      200  {object}  services.ServiceDto
+// @Failure      404  {object}  string
+// @Router       /services/{id} [get]
+func getServiceHandler(service services.ServiceCatalogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetService(r.Context(), int32(id))
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить каталог услуг
+// @Description  Возвращает все действующие услуги
+// @Tags         services
+// @Produce      json
+// @Success      200  {array}  services.ServiceDto
+// @Router       /services [get]
+func getServicesHandler(service services.ServiceCatalogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetServices(r.Context())
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Обновить услугу
+// @Description  Обновляет название, длительность, цену и ставку НДС услуги
+// @Tags         services
+// @Accept       json
+// @Produce      json
+// @Param        service  body      services.UpdateServiceDto  true  "Данные для обновления услуги"
+// @Success      200      {object}  services.ServiceDto
+// @Failure      400      {object}  string
+// @Failure      404      {object}  string
+// @Router       /services [put]
+func updateServiceHandler(service services.ServiceCatalogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.UpdateServiceDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateService(r.Context(), dto)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить услугу
+// @Description  Помечает услугу удалённой
+// @Tags         services
+// @Param        id   path  int  true  "ID услуги"
+// @Success      204
+// @Router       /services/{id} [delete]
+func deleteServiceHandler(service services.ServiceCatalogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteService(r.Context(), int32(id)); err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewServiceRouter(service services.ServiceCatalogService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createServiceHandler(service))
+	r.Get("/", getServicesHandler(service))
+	r.Get("/{id}", getServiceHandler(service))
+	r.Put("/", updateServiceHandler(service))
+	r.Delete("/{id}", deleteServiceHandler(service))
+
+	return r
+}
@@ -0,0 +1,347 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func writeShiftError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ShiftNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.EmployeeNotFound),
+		errors.Is(err, services.EmployeeNotInStoreError),
+		errors.Is(err, services.InvalidShiftError),
+		errors.Is(err, services.InvalidMonthError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.ShiftOverlapError),
+		errors.Is(err, services.ShiftWeeklyHoursError),
+		errors.Is(err, services.ShiftClockError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// formatMinutes переводит минуты в часы с двумя знаками, как их считают в табеле
+func formatMinutes(minutes int32) string {
+	return fmt.Sprintf("%.2f", float64(minutes)/60)
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.DateTime)
+}
+
+func writeTimesheetCSV(w http.ResponseWriter, timesheet services.TimesheetDto) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=timesheet-%d-%s.csv", timesheet.EmployeeId, timesheet.Month))
+	w.WriteHeader(http.StatusOK)
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"date", "shift_id", "store_id", "starts_at", "ends_at", "clock_in_at", "clock_out_at",
+		"planned_hours", "worked_hours"})
+	for _, row := range timesheet.Rows {
+		writer.Write([]string{
+			row.Date,
+			strconv.Itoa(int(row.ShiftId)),
+			strconv.Itoa(int(row.StoreId)),
+			row.StartsAt.Format(time.DateTime),
+			row.EndsAt.Format(time.DateTime),
+			formatOptionalTime(row.ClockInAt),
+			formatOptionalTime(row.ClockOutAt),
+			formatMinutes(row.PlannedMinutes),
+			formatMinutes(row.WorkedMinutes),
+		})
+	}
+	writer.Write([]string{"total", "", "", "", "", "", "",
+		formatMinutes(timesheet.PlannedMinutes), formatMinutes(timesheet.WorkedMinutes)})
+	writer.Flush()
+}
+
+// @Summary      Создать смену
+// @Description  Планирует смену сотрудника в магазине. Смена не длиннее 12 часов, не пересекается с другими сменами сотрудника и укладывается в недельную норму 40 часов
+// @Tags         shifts
+// @Accept       json
+// @Produce      json
+// @Param        shift  body      services.CreateShiftDto  true  "Данные смены"
+// @Success      201    {object}  services.ShiftDto
+// @Failure      400    {object}  string
+// @Failure      409    {object}  string
+// @Router       /shifts [post]
+func createShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateShiftDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateShift(r.Context(), dto)
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить смену
+// @Description  Возвращает смену по идентификатору
+// @Tags         shifts
+// @Produce      json
+// @Param        id   path      int  true  "ID смены"
+// @Success      200  {object}  services.ShiftDto
+// @Failure      404  {object}  string
+// @Router       /shifts/{id} [get]
+func getShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetShift(r.Context(), int32(id))
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить график смен
+// @Description  Смены магазина или сотрудника, начинающиеся в периоде. Нужно указать store_id или employee_id
+// @Tags         shifts
+// @Produce      json
+// @Param        store_id     query     int     false  "ID магазина"
+// @Param        employee_id  query     int     false  "ID сотрудника"
+// @Param        from         query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to           query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Success      200          {array}   services.ShiftDto
+// @Failure      400          {object}  string
+// @Router       /shifts [get]
+func getShiftsHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var response []services.ShiftDto
+		if value := r.URL.Query().Get("employee_id"); value != "" {
+			employeeId, convErr := strconv.Atoi(value)
+			if convErr != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(convErr.Error()))
+				return
+			}
+			response, err = service.GetEmployeeShifts(r.Context(), int32(employeeId), from, to)
+		} else {
+			storeId, convErr := strconv.Atoi(r.URL.Query().Get("store_id"))
+			if convErr != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("store_id or employee_id is required"))
+				return
+			}
+			response, err = service.GetStoreShifts(r.Context(), int32(storeId), from, to)
+		}
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Перенести смену
+// @Description  Меняет магазин и время смены, пока сотрудник не отметил приход
+// @Tags         shifts
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                      true  "ID смены"
+// @Param        shift  body      services.UpdateShiftDto  true  "Новые магазин и время"
+// @Success      200    {object}  services.ShiftDto
+// @Failure      400    {object}  string
+// @Failure      404    {object}  string
+// @Failure      409    {object}  string
+// @Router       /shifts/{id} [put]
+func updateShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.UpdateShiftDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateShift(r.Context(), int32(id), dto)
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить смену
+// @Description  Отменяет смену, на которую сотрудник ещё не пришёл
+// @Tags         shifts
+// @Param        id   path  int  true  "ID смены"
+// @Success      204
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /shifts/{id} [delete]
+func deleteShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteShift(r.Context(), int32(id)); err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// @Summary      Отметить приход
+// @Description  Фиксирует время прихода на смену: не раньше чем за час до начала и не позже её окончания
+// @Tags         shifts
+// @Produce      json
+// @Param        id   path      int  true  "ID смены"
+// @Success      200  {object}  services.ShiftDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /shifts/{id}/clock-in [post]
+func clockInShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.ClockIn(r.Context(), int32(id))
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отметить уход
+// @Description  Фиксирует время ухода со смены после отметки прихода
+// @Tags         shifts
+// @Produce      json
+// @Param        id   path      int  true  "ID смены"
+// @Success      200  {object}  services.ShiftDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /shifts/{id}/clock-out [post]
+func clockOutShiftHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.ClockOut(r.Context(), int32(id))
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Табель сотрудника
+// @Description  Запланированное и фактически отработанное время сотрудника за месяц в JSON или CSV
+// @Tags         shifts
+// @Produce      json
+// @Produce      text/csv
+// @Param        employee_id  query     int     true   "ID сотрудника"
+// @Param        month        query     string  false  "Месяц, YYYY-MM (по умолчанию текущий)"
+// @Param        format       query     string  false  "json (по умолчанию) или csv"
+// @Success      200          {object}  services.TimesheetDto
+// @Failure      400          {object}  string
+// @Router       /shifts/timesheet [get]
+func getTimesheetHandler(service services.ShiftService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		employeeId, err := strconv.Atoi(r.URL.Query().Get("employee_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("format must be json or csv"))
+			return
+		}
+		response, err := service.GetTimesheet(r.Context(), int32(employeeId), r.URL.Query().Get("month"))
+		if err != nil {
+			writeShiftError(w, err)
+			return
+		}
+		if format == "csv" {
+			writeTimesheetCSV(w, response)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewShiftRouter(service services.ShiftService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/timesheet", getTimesheetHandler(service))
+	r.Post("/", createShiftHandler(service))
+	r.Get("/", getShiftsHandler(service))
+	r.Get("/{id}", getShiftHandler(service))
+	r.Put("/{id}", updateShiftHandler(service))
+	r.Delete("/{id}", deleteShiftHandler(service))
+	r.Post("/{id}/clock-in", clockInShiftHandler(service))
+	r.Post("/{id}/clock-out", clockOutShiftHandler(service))
+
+	return r
+}
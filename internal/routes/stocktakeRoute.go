@@ -0,0 +1,291 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeStocktakeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.StocktakeNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.CategoryNotFound),
+		errors.Is(err, services.EmployeeNotInStoreError),
+		errors.Is(err, services.GoodNotInStocktakeError),
+		errors.Is(err, services.InvalidStocktakeCountError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.StocktakeStatusError),
+		errors.Is(err, services.StocktakeInProgressError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Начать инвентаризацию
+// @Description  Создаёт документ инвентаризации магазина и фиксирует ожидаемые остатки всех товаров или товаров категории.
+// @Description  Ожидаемый остаток — остаток этого магазина по журналу движений (продажи, возвраты, приёмки, списания,
+// @Description  инвентаризации), а не остаток всей сети. Товар, попавший на склад до появления журнала, не числится
+// @Description  ни за одним магазином: излишек при утверждении сначала закрепляет его за магазином и не увеличивает остаток сети.
+// @Description  В магазине одновременно может идти только одна инвентаризация
+// @Tags         stocktakes
+// @Accept       json
+// @Produce      json
+// @Param        stocktake  body      services.CreateStocktakeDto  true  "Данные инвентаризации"
+// @Success      201        {object}  services.StocktakeDto
+// @Failure      400        {object}  string
+// @Failure      409        {object}  string
+// @Router       /stocktakes [post]
+func createStocktakeHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateStocktakeDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateStocktake(r.Context(), dto)
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить инвентаризацию
+// @Description  Возвращает документ инвентаризации со строками: ожидаемое и посчитанное количество, расхождения
+// @Tags         stocktakes
+// @Produce      json
+// @Param        id   path      int  true  "ID инвентаризации"
+// @Success      200  {object}  services.StocktakeDto
+// @Failure      404  {object}  string
+// @Router       /stocktakes/{id} [get]
+func getStocktakeHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetStocktake(r.Context(), int32(id))
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить инвентаризации
+// @Description  Возвращает документы инвентаризации без строк, последние первыми
+// @Tags         stocktakes
+// @Produce      json
+// @Param        store_id  query     int  false  "ID магазина"
+// @Success      200       {array}   services.StocktakeDto
+// @Failure      400       {object}  string
+// @Router       /stocktakes [get]
+func getStocktakesHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetStocktakes(r.Context(), storeId)
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Внести подсчёт
+// @Description  Принимает подсчёт сотрудника магазина. Подсчёты разных сотрудников по одному товару складываются,
+// @Description  повторный подсчёт того же сотрудника заменяет предыдущий
+// @Tags         stocktakes
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                         true  "ID инвентаризации"
+// @Param        count  body      services.StocktakeCountDto  true  "Посчитанные товары"
+// @Success      200    {object}  services.StocktakeDto
+// @Failure      400    {object}  string
+// @Failure      404    {object}  string
+// @Failure      409    {object}  string
+// @Router       /stocktakes/{id}/counts [post]
+func addStocktakeCountsHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.StocktakeCountDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.AddCounts(r.Context(), int32(id), dto)
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить расхождения
+// @Description  Возвращает посчитанные товары, количество которых разошлось с ожидаемым
+// @Tags         stocktakes
+// @Produce      json
+// @Param        id   path      int  true  "ID инвентаризации"
+// @Success      200  {array}   services.StocktakeLineDto
+// @Failure      404  {object}  string
+// @Router       /stocktakes/{id}/variances [get]
+func getStocktakeVariancesHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetVariances(r.Context(), int32(id))
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Утвердить инвентаризацию
+// @Description  Проводит расхождения по остаткам товаров и записывает корректировки. Непосчитанные товары не меняются
+// @Tags         stocktakes
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "ID инвентаризации"
+// @Param        approve  body      services.ApproveStocktakeDto  true  "Утверждающий сотрудник"
+// @Success      200      {object}  services.StocktakeDto
+// @Failure      400      {object}  string
+// @Failure      404      {object}  string
+// @Failure      409      {object}  string
+// @Router       /stocktakes/{id}/approve [post]
+func approveStocktakeHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.ApproveStocktakeDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.ApproveStocktake(r.Context(), int32(id), dto)
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отменить инвентаризацию
+// @Description  Отменяет инвентаризацию без изменения остатков
+// @Tags         stocktakes
+// @Produce      json
+// @Param        id   path      int  true  "ID инвентаризации"
+// @Success      200  {object}  services.StocktakeDto
+// @Failure      404  {object}  string
+// @Failure      409  {object}  string
+// @Router       /stocktakes/{id}/cancel [post]
+func cancelStocktakeHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.CancelStocktake(r.Context(), int32(id))
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить корректировки инвентаризации
+// @Description  Возвращает корректировки остатков, проведённые при утверждении инвентаризации
+// @Tags         stocktakes
+// @Produce      json
+// @Param        id   path      int  true  "ID инвентаризации"
+// @Success      200  {array}   services.StockAdjustmentDto
+// @Failure      404  {object}  string
+// @Router       /stocktakes/{id}/adjustments [get]
+func getStocktakeAdjustmentsHandler(service services.StocktakeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetAdjustments(r.Context(), int32(id))
+		if err != nil {
+			writeStocktakeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewStocktakeRouter(service services.StocktakeService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createStocktakeHandler(service))
+	r.Get("/", getStocktakesHandler(service))
+	r.Get("/{id}", getStocktakeHandler(service))
+	r.Post("/{id}/counts", addStocktakeCountsHandler(service))
+	r.Get("/{id}/variances", getStocktakeVariancesHandler(service))
+	r.Post("/{id}/approve", approveStocktakeHandler(service))
+	r.Post("/{id}/cancel", cancelStocktakeHandler(service))
+	r.Get("/{id}/adjustments", getStocktakeAdjustmentsHandler(service))
+
+	return r
+}
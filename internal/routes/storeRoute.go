@@ -172,13 +172,52 @@ func DeleteStoreHandler(service services.StoreService) http.HandlerFunc {
 	})
 }
 
-func NewStoreRouter(service services.StoreService) http.Handler {
+// @Summary      Сотрудники магазина
+// @Description  Возвращает сотрудников, работавших в магазине в указанный день
+// @Tags         stores
+// @Produce      json
+// @Param        id    path      int     true   "ID магазина"
+// @Param        date  query     string  false  "Дата, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {array}   services.StoreEmployeeDto
+// @Failure      400   {object}  string
+// @Failure      404   {object}  string
+// @Router       /stores/{id}/employees [get]
+func GetStoreEmployeesHandler(service services.StoreService) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetStoreEmployees(r.Context(), int32(id), r.URL.Query().Get("date"))
+		if err != nil {
+			switch {
+			case errors.Is(err, services.StoreNotFound):
+				w.WriteHeader(http.StatusNotFound)
+			case errors.Is(err, services.InvalidDateError):
+				w.WriteHeader(http.StatusBadRequest)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+func NewStoreRouter(service services.StoreService, exportService services.ExportService) http.Handler {
 	r := chi.NewRouter()
 	r.Post("/", createStoreHandler(service))
+	r.Get("/export", exportHandler(exportService, "stores"))
 	r.Get("/{id}", GetStoreHandler(service))
 	r.Get("/", GetStoresHandler(service))
 	r.Put("/{id}", UpdateStoreHandler(service))
 	r.Delete("/{id}", DeleteStoreHandler(service))
+	r.Get("/{id}/employees", GetStoreEmployeesHandler(service))
 
 	return r
 }
@@ -0,0 +1,124 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeSupplierClaimError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.SupplierClaimNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.SupplierClaimResolvedError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Получить претензию поставщику
+// @Description  Возвращает претензию по браку и недопоставке со строками
+// @Tags         supplier-claims
+// @Produce      json
+// @Param        id   path      int  true  "ID претензии"
+// @Success      200  {object}  services.SupplierClaimDto
+// @Failure      404  {object}  string
+// @Router       /supplier-claims/{id} [get]
+func getSupplierClaimHandler(service services.SupplierClaimService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSupplierClaim(r.Context(), int32(id))
+		if err != nil {
+			writeSupplierClaimError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить претензии поставщикам
+// @Description  Возвращает претензии поставщикам, последние первыми
+// @Tags         supplier-claims
+// @Produce      json
+// @Param        supplier_id  query     int     false  "ID поставщика"
+// @Param        status       query     string  false  "Статус: open или resolved"
+// @Success      200          {array}   services.SupplierClaimDto
+// @Failure      400          {object}  string
+// @Router       /supplier-claims [get]
+func getSupplierClaimsHandler(service services.SupplierClaimService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		supplierId, err := parseOptionalId(r, "supplier_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSupplierClaims(r.Context(), supplierId, r.URL.Query().Get("status"))
+		if err != nil {
+			writeSupplierClaimError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Закрыть претензию поставщику
+// @Description  Отмечает претензию урегулированной
+// @Tags         supplier-claims
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                               true  "ID претензии"
+// @Param        resolve  body      services.ResolveSupplierClaimDto  true  "Итог претензии"
+// @Success      200      {object}  services.SupplierClaimDto
+// @Failure      404      {object}  string
+// @Failure      409      {object}  string
+// @Router       /supplier-claims/{id}/resolve [post]
+func resolveSupplierClaimHandler(service services.SupplierClaimService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.ResolveSupplierClaimDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.ResolveSupplierClaim(r.Context(), int32(id), dto)
+		if err != nil {
+			writeSupplierClaimError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewSupplierClaimRouter(service services.SupplierClaimService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/", getSupplierClaimsHandler(service))
+	r.Get("/{id}", getSupplierClaimHandler(service))
+	r.Post("/{id}/resolve", resolveSupplierClaimHandler(service))
+
+	return r
+}
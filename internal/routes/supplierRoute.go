@@ -169,11 +169,54 @@ func DeleteSupplierHandler(service services.SupplierService) http.HandlerFunc {
 	}
 }
 
-func NewSupplierRouter(service services.SupplierService) http.Handler {
+// @Summary      Оценка поставщика
+// @Description  Доля поставок в срок, доля выполнения заказов, доля брака, доля возвратов покупателей, средний срок поставки и претензии по заказам, отправленным поставщику за период
+// @Tags         suppliers
+// @Produce      json
+// @Param        id    path      int     true   "ID поставщика"
+// @Param        from  query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to    query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Success      200   {object}  services.SupplierScorecardDto
+// @Failure      400   {object}  string
+// @Router       /suppliers/{id}/scorecard [get]
+func getSupplierScorecardHandler(service services.SupplierService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetSupplierScorecard(r.Context(), int32(id), from, to)
+		if err != nil {
+			if errors.Is(err, services.SupplierNotFoundError) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(err.Error()))
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewSupplierRouter(service services.SupplierService, exportService services.ExportService) http.Handler {
 	router := chi.NewRouter()
 
 	router.Get("/", GetSuppliersHandler(service))
 	router.Post("/", createSupplierHandler(service))
+	router.Get("/export", exportHandler(exportService, "suppliers"))
+	router.Get("/{id}/scorecard", getSupplierScorecardHandler(service))
 	router.Get("/{id}", getSupplierHandler(service))
 	router.Put(`/{id}`, UpdateSupplierHandler(service))
 	router.Delete("/{id}", DeleteSupplierHandler(service))
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeTaxRateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.TaxRateNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.UnsupportedTaxRateError):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать ставку НДС
+// @Description  Создаёт ставку НДС: 20%, 10%, 0% или без НДС (rate = null)
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Param        rate  body      services.CreateTaxRateDto  true  "Данные ставки"
+// @Success      201   {object}  services.TaxRateDto
+// @Failure      400   {object}  string
+// @Router       /tax-rates [post]
+func createTaxRateHandler(service services.TaxRateService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateTaxRateDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateTaxRate(r.Context(), dto)
+		if err != nil {
+			writeTaxRateError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить ставку НДС по id
+// @Description  Возвращает ставку НДС по идентификатору
+// @Tags         tax-rates
+// @Produce      json
+// @Param        id   path      int  true  "ID ставки"
+// @Success      200  {object}  services.TaxRateDto
+// @Failure      404  {object}  string
+// @Router       /tax-rates/{id} [get]
+func getTaxRateHandler(service services.TaxRateService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetTaxRate(r.Context(), int32(id))
+		if err != nil {
+			writeTaxRateError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить список ставок НДС
+// @Description  Возвращает все действующие ставки НДС
+// @Tags         tax-rates
+// @Produce      json
+// @Success      200  {array}  services.TaxRateDto
+// @Router       /tax-rates [get]
+func getTaxRatesHandler(service services.TaxRateService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := service.GetTaxRates(r.Context())
+		if err != nil {
+			writeTaxRateError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Удалить ставку НДС
+// @Description  Помечает ставку НДС удалённой
+// @Tags         tax-rates
+// @Param        id   path  int  true  "ID ставки"
+// @Success      204
+// @Router       /tax-rates/{id} [delete]
+func deleteTaxRateHandler(service services.TaxRateService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := service.DeleteTaxRate(r.Context(), int32(id)); err != nil {
+			writeTaxRateError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewTaxRateRouter(service services.TaxRateService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", createTaxRateHandler(service))
+	r.Get("/", getTaxRatesHandler(service))
+	r.Get("/{id}", getTaxRateHandler(service))
+	r.Delete("/{id}", deleteTaxRateHandler(service))
+
+	return r
+}
@@ -0,0 +1,199 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func writeTechnicianBookingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.TechnicianBookingNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.TechnicianNotFound),
+		errors.Is(err, services.ServiceNotFound),
+		errors.Is(err, services.OrderNotFound),
+		errors.Is(err, services.ServiceNotInOrderError),
+		errors.Is(err, services.InvalidBookingWindowError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.TechnicianBusyError),
+		errors.Is(err, services.TechnicianBookingStatusError),
+		errors.Is(err, services.OrderStatusError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Записать техника
+// @Description  Бронирует сотрудника на окно времени для установки или ремонта. Окно не может пересекаться с другими активными записями техника
+// @Tags         technician-bookings
+// @Accept       json
+// @Produce      json
+// @Param        booking  body      services.CreateTechnicianBookingDto  true  "Данные записи"
+// @Success      201      {object}  services.TechnicianBookingDto
+// @Failure      400      {object}  string
+// @Failure      409      {object}  string
+// @Router       /technician-bookings [post]
+func createTechnicianBookingHandler(service services.TechnicianBookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateTechnicianBookingDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateBooking(r.Context(), dto)
+		if err != nil {
+			writeTechnicianBookingError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить запись техника
+// @Description  Возвращает запись техника по идентификатору
+// @Tags         technician-bookings
+// @Produce      json
+// @Param        id   path      int  true  "ID записи"
+// @Success      200  {object}  services.TechnicianBookingDto
+// @Failure      404  {object}  string
+// @Router       /technician-bookings/{id} [get]
+func getTechnicianBookingHandler(service services.TechnicianBookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetBooking(r.Context(), int32(id))
+		if err != nil {
+			writeTechnicianBookingError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Записи техников по заказу
+// @Description  Возвращает записи техников, созданные под заказ
+// @Tags         technician-bookings
+// @Produce      json
+// @Param        order_id  query     int  true  "ID заказа"
+// @Success      200       {array}   services.TechnicianBookingDto
+// @Failure      400       {object}  string
+// @Router       /technician-bookings [get]
+func getTechnicianBookingsHandler(service services.TechnicianBookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orderId, err := strconv.Atoi(r.URL.Query().Get("order_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetOrderBookings(r.Context(), int32(orderId))
+		if err != nil {
+			writeTechnicianBookingError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Расписание техника
+// @Description  Записи техника на день в порядке начала
+// @Tags         technician-bookings
+// @Produce      json
+// @Param        id    path      int     true   "ID сотрудника-техника"
+// @Param        date  query     string  false  "Дата, YYYY-MM-DD (по умолчанию сегодня)"
+// @Success      200   {object}  services.TechnicianScheduleDto
+// @Failure      400   {object}  string
+// @Router       /technician-bookings/technicians/{id}/schedule [get]
+func getTechnicianScheduleHandler(service services.TechnicianBookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		date := time.Now()
+		if value := r.URL.Query().Get("date"); value != "" {
+			if date, err = time.ParseInLocation(dateLayout, value, time.Local); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		response, err := service.GetSchedule(r.Context(), int32(id), date)
+		if err != nil {
+			writeTechnicianBookingError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Изменить статус записи техника
+// @Description  Запись из scheduled переводится в completed или cancelled, отмена освобождает окно
+// @Tags         technician-bookings
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                                        true  "ID записи"
+// @Param        status  body      services.UpdateTechnicianBookingStatusDto  true  "Новый статус"
+// @Success      200     {object}  services.TechnicianBookingDto
+// @Failure      409     {object}  string
+// @Router       /technician-bookings/{id}/status [post]
+func updateTechnicianBookingStatusHandler(service services.TechnicianBookingService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.UpdateTechnicianBookingStatusDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.UpdateStatus(r.Context(), int32(id), dto)
+		if err != nil {
+			writeTechnicianBookingError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewTechnicianBookingRouter(service services.TechnicianBookingService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/technicians/{id}/schedule", getTechnicianScheduleHandler(service))
+	r.Post("/", createTechnicianBookingHandler(service))
+	r.Get("/", getTechnicianBookingsHandler(service))
+	r.Get("/{id}", getTechnicianBookingHandler(service))
+	r.Post("/{id}/status", updateTechnicianBookingStatusHandler(service))
+
+	return r
+}
@@ -0,0 +1,241 @@
+package routes
+
+import (
+	"HomeApplianceStore/internal/services"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func writeWriteOffError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.WriteOffNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, services.StoreNotFound),
+		errors.Is(err, services.ProductNotFound),
+		errors.Is(err, services.EmployeeNotInStoreError),
+		errors.Is(err, services.InvalidWriteOffError):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, services.PermissionDeniedError),
+		errors.Is(err, services.WriteOffSelfApprovalError):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Is(err, services.WriteOffStatusError),
+		errors.Is(err, services.InsufficientStockError):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(err.Error()))
+}
+
+// @Summary      Создать списание
+// @Description  Создаёт черновик списания повреждённых или утерянных товаров магазина. Остатки меняются только после утверждения
+// @Tags         write-offs
+// @Accept       json
+// @Produce      json
+// @Param        write_off  body      services.CreateWriteOffDto  true  "Данные списания"
+// @Success      201        {object}  services.WriteOffDto
+// @Failure      400        {object}  string
+// @Router       /write-offs [post]
+func createWriteOffHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var dto services.CreateWriteOffDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.CreateWriteOff(r.Context(), dto)
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить списание
+// @Description  Возвращает списание с товарами
+// @Tags         write-offs
+// @Produce      json
+// @Param        id   path      int  true  "ID списания"
+// @Success      200  {object}  services.WriteOffDto
+// @Failure      404  {object}  string
+// @Router       /write-offs/{id} [get]
+func getWriteOffHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetWriteOff(r.Context(), int32(id))
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Получить списания
+// @Description  Возвращает списания, последние первыми
+// @Tags         write-offs
+// @Produce      json
+// @Param        store_id  query     int     false  "ID магазина"
+// @Param        status    query     string  false  "Статус: draft, approved или rejected"
+// @Success      200       {array}   services.WriteOffDto
+// @Failure      400       {object}  string
+// @Router       /write-offs [get]
+func getWriteOffsHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetWriteOffs(r.Context(), storeId, r.URL.Query().Get("status"))
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Утвердить списание
+// @Description  Фиксирует себестоимость товаров и уменьшает остатки. Нужна роль с правом write_offs.approve,
+// @Description  автор списания утвердить его не может. Товар без себестоимости списывается с пустой unit_cost
+// @Tags         write-offs
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                         true  "ID списания"
+// @Param        review  body      services.ReviewWriteOffDto  true  "Утверждающий сотрудник"
+// @Success      200     {object}  services.WriteOffDto
+// @Failure      403     {object}  string
+// @Failure      404     {object}  string
+// @Failure      409     {object}  string
+// @Router       /write-offs/{id}/approve [post]
+func approveWriteOffHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.ReviewWriteOffDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.ApproveWriteOff(r.Context(), int32(id), dto)
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отклонить списание
+// @Description  Отклоняет черновик списания без изменения остатков. Нужна роль с правом write_offs.approve
+// @Tags         write-offs
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                         true  "ID списания"
+// @Param        review  body      services.ReviewWriteOffDto  true  "Проверяющий сотрудник"
+// @Success      200     {object}  services.WriteOffDto
+// @Failure      403     {object}  string
+// @Failure      404     {object}  string
+// @Failure      409     {object}  string
+// @Router       /write-offs/{id}/reject [post]
+func rejectWriteOffHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		var dto services.ReviewWriteOffDto
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer r.Body.Close()
+		response, err := service.RejectWriteOff(r.Context(), int32(id), dto)
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// @Summary      Отчёт по списаниям
+// @Description  Себестоимость утверждённых списаний по магазинам и причинам за период.
+// @Description  Товар без известной себестоимости в cost не входит, его количество — в uncosted_quantity
+// @Tags         write-offs
+// @Produce      json
+// @Param        store_id  query     int     false  "ID магазина"
+// @Param        from      query     string  false  "Начало периода, YYYY-MM-DD"
+// @Param        to        query     string  false  "Конец периода включительно, YYYY-MM-DD"
+// @Success      200       {object}  services.WriteOffReportDto
+// @Failure      400       {object}  string
+// @Router       /write-offs/report [get]
+func getWriteOffReportHandler(service services.WriteOffService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parsePeriod(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		storeId, err := parseOptionalId(r, "store_id")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		response, err := service.GetReport(r.Context(), storeId, from, to)
+		if err != nil {
+			writeWriteOffError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func NewWriteOffRouter(service services.WriteOffService) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/report", getWriteOffReportHandler(service))
+	r.Post("/", createWriteOffHandler(service))
+	r.Get("/", getWriteOffsHandler(service))
+	r.Get("/{id}", getWriteOffHandler(service))
+	r.Post("/{id}/approve", approveWriteOffHandler(service))
+	r.Post("/{id}/reject", rejectWriteOffHandler(service))
+
+	return r
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type CategoryDto struct {
+	Id        int32     `json:"id"`
+	Name      string    `json:"name"`
+	TaxRateId *int32    `json:"tax_rate_id"`
+	CreatedAt time.Time `json:"created_at"`
+	IsAlive   bool      `json:"is_alive"`
+}
+
+type CreateCategoryDto struct {
+	Name      string `json:"name"`
+	TaxRateId *int32 `json:"tax_rate_id"`
+}
+
+type UpdateCategoryDto struct {
+	Id        int32  `json:"id"`
+	Name      string `json:"name"`
+	TaxRateId *int32 `json:"tax_rate_id"`
+	IsAlive   bool   `json:"is_alive"`
+}
+
+type CategoryInterface interface {
+	CreateCategory(ctx context.Context, dto CreateCategoryDto) (CategoryDto, error)
+	GetCategory(ctx context.Context, id int32) (CategoryDto, error)
+	GetCategories(ctx context.Context) ([]CategoryDto, error)
+	UpdateCategory(ctx context.Context, dto UpdateCategoryDto) (CategoryDto, error)
+	DeleteCategory(ctx context.Context, id int32) error
+}
+
+type CategoryService struct {
+	Queries gen.Queries
+}
+
+var CategoryNotFound = errors.New("category not found")
+
+func ToCategoryDto(category gen.Category) CategoryDto {
+	return CategoryDto{
+		Id:        category.ID,
+		Name:      category.Name,
+		TaxRateId: fromInt4(category.TaxRateID),
+		CreatedAt: category.CreatedAt.Time,
+		IsAlive:   category.IsAlive,
+	}
+}
+
+func (c CategoryService) CreateCategory(ctx context.Context, dto CreateCategoryDto) (CategoryDto, error) {
+	category, err := c.Queries.CreateCategory(ctx, gen.CreateCategoryParams{
+		Name:      dto.Name,
+		TaxRateID: toInt4(dto.TaxRateId),
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:   true,
+	})
+	if err != nil {
+		return CategoryDto{}, err
+	}
+	return ToCategoryDto(category), nil
+}
+
+func (c CategoryService) GetCategory(ctx context.Context, id int32) (CategoryDto, error) {
+	category, err := c.Queries.GetCategory(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CategoryDto{}, CategoryNotFound
+		}
+		return CategoryDto{}, err
+	}
+	return ToCategoryDto(category), nil
+}
+
+func (c CategoryService) GetCategories(ctx context.Context) ([]CategoryDto, error) {
+	categories, err := c.Queries.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]CategoryDto, len(categories))
+	for i, category := range categories {
+		response[i] = ToCategoryDto(category)
+	}
+	return response, nil
+}
+
+func (c CategoryService) UpdateCategory(ctx context.Context, dto UpdateCategoryDto) (CategoryDto, error) {
+	category, err := c.Queries.UpdateCategory(ctx, gen.UpdateCategoryParams{
+		ID:        dto.Id,
+		Name:      dto.Name,
+		TaxRateID: toInt4(dto.TaxRateId),
+		IsAlive:   dto.IsAlive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CategoryDto{}, CategoryNotFound
+		}
+		return CategoryDto{}, err
+	}
+	return ToCategoryDto(category), nil
+}
+
+func (c CategoryService) DeleteCategory(ctx context.Context, id int32) error {
+	return c.Queries.DeleteCategory(ctx, id)
+}
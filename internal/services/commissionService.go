@@ -0,0 +1,279 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// Ставка комиссии хранится в сотых долях процента: 250 — это 2,5%
+const commissionRateScale = 10000
+
+type CommissionRuleDto struct {
+	Id         int32     `json:"id"`
+	RoleId     *int32    `json:"role_id"`
+	CategoryId *int32    `json:"category_id"`
+	RateBps    int32     `json:"rate_bps"`
+	CreatedAt  time.Time `json:"created_at"`
+	IsAlive    bool      `json:"is_alive"`
+}
+
+type CreateCommissionRuleDto struct {
+	// Роль продавца, null — правило для любой роли
+	RoleId *int32 `json:"role_id"`
+	// Категория товара, null — правило для любых товаров и услуг
+	CategoryId *int32 `json:"category_id"`
+	// Ставка в сотых долях процента, 250 = 2,5%
+	RateBps int32 `json:"rate_bps"`
+}
+
+type UpdateCommissionRuleDto struct {
+	Id         int32  `json:"id"`
+	RoleId     *int32 `json:"role_id"`
+	CategoryId *int32 `json:"category_id"`
+	RateBps    int32  `json:"rate_bps"`
+	IsAlive    bool   `json:"is_alive"`
+}
+
+// CommissionReportDto — комиссия продавца за период. Суммы продаж и возвратов в рублях, комиссия в копейках.
+type CommissionReportDto struct {
+	EmployeeId        int32  `json:"employee_id"`
+	From              string `json:"from"`
+	To                string `json:"to"`
+	Orders            int32  `json:"orders"`
+	SalesAmount       int64  `json:"sales_amount"`
+	ReturnsAmount     int64  `json:"returns_amount"`
+	SalesCommission   int64  `json:"sales_commission"`
+	ReturnsCommission int64  `json:"returns_commission"`
+	// Начислено: комиссия с продаж за вычетом комиссии с возвратов
+	Earned int64 `json:"earned"`
+}
+
+type CommissionRuleInterface interface {
+	CreateRule(ctx context.Context, dto CreateCommissionRuleDto) (CommissionRuleDto, error)
+	GetRule(ctx context.Context, id int32) (CommissionRuleDto, error)
+	GetRules(ctx context.Context) ([]CommissionRuleDto, error)
+	UpdateRule(ctx context.Context, dto UpdateCommissionRuleDto) (CommissionRuleDto, error)
+	DeleteRule(ctx context.Context, id int32) error
+}
+
+type CommissionRuleService struct {
+	Queries gen.Queries
+}
+
+var CommissionRuleNotFound = errors.New("commission rule not found")
+var InvalidCommissionRuleError = errors.New("commission rate must be between 0 and 10000 basis points")
+var RoleNotFound = errors.New("role not found")
+
+func ToCommissionRuleDto(rule gen.CommissionRule) CommissionRuleDto {
+	return CommissionRuleDto{
+		Id:         rule.ID,
+		RoleId:     fromInt4(rule.RoleID),
+		CategoryId: fromInt4(rule.CategoryID),
+		RateBps:    rule.RateBps,
+		CreatedAt:  rule.CreatedAt.Time,
+		IsAlive:    rule.IsAlive,
+	}
+}
+
+func (c CommissionRuleService) validateRule(ctx context.Context, roleId *int32, categoryId *int32, rateBps int32) error {
+	if rateBps < 0 || rateBps > commissionRateScale {
+		return InvalidCommissionRuleError
+	}
+	if roleId != nil {
+		if _, err := c.Queries.GetRole(ctx, *roleId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return RoleNotFound
+			}
+			return err
+		}
+	}
+	if categoryId != nil {
+		if _, err := c.Queries.GetCategory(ctx, *categoryId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return CategoryNotFound
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (c CommissionRuleService) CreateRule(ctx context.Context, dto CreateCommissionRuleDto) (CommissionRuleDto, error) {
+	if err := c.validateRule(ctx, dto.RoleId, dto.CategoryId, dto.RateBps); err != nil {
+		return CommissionRuleDto{}, err
+	}
+	rule, err := c.Queries.CreateCommissionRule(ctx, gen.CreateCommissionRuleParams{
+		RoleID:     toInt4(dto.RoleId),
+		CategoryID: toInt4(dto.CategoryId),
+		RateBps:    dto.RateBps,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:    true,
+	})
+	if err != nil {
+		return CommissionRuleDto{}, err
+	}
+	return ToCommissionRuleDto(rule), nil
+}
+
+func (c CommissionRuleService) GetRule(ctx context.Context, id int32) (CommissionRuleDto, error) {
+	rule, err := c.Queries.GetCommissionRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CommissionRuleDto{}, CommissionRuleNotFound
+		}
+		return CommissionRuleDto{}, err
+	}
+	return ToCommissionRuleDto(rule), nil
+}
+
+func (c CommissionRuleService) GetRules(ctx context.Context) ([]CommissionRuleDto, error) {
+	rules, err := c.Queries.ListCommissionRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]CommissionRuleDto, len(rules))
+	for i, rule := range rules {
+		response[i] = ToCommissionRuleDto(rule)
+	}
+	return response, nil
+}
+
+func (c CommissionRuleService) UpdateRule(ctx context.Context, dto UpdateCommissionRuleDto) (CommissionRuleDto, error) {
+	if err := c.validateRule(ctx, dto.RoleId, dto.CategoryId, dto.RateBps); err != nil {
+		return CommissionRuleDto{}, err
+	}
+	rule, err := c.Queries.UpdateCommissionRule(ctx, gen.UpdateCommissionRuleParams{
+		ID:         dto.Id,
+		RoleID:     toInt4(dto.RoleId),
+		CategoryID: toInt4(dto.CategoryId),
+		RateBps:    dto.RateBps,
+		IsAlive:    dto.IsAlive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CommissionRuleDto{}, CommissionRuleNotFound
+		}
+		return CommissionRuleDto{}, err
+	}
+	return ToCommissionRuleDto(rule), nil
+}
+
+func (c CommissionRuleService) DeleteRule(ctx context.Context, id int32) error {
+	return c.Queries.DeleteCommissionRule(ctx, id)
+}
+
+// commissionRate выбирает самое точное подходящее правило: роль и категория, затем только категория,
+// затем только роль, затем общее правило. Если ни одно не подходит, комиссии нет.
+func commissionRate(rules []gen.CommissionRule, roleId int32, categoryId pgtype.Int4) int32 {
+	var rate int32
+	bestScore := 0
+	for _, rule := range rules {
+		if rule.RoleID.Valid && rule.RoleID.Int32 != roleId {
+			continue
+		}
+		if rule.CategoryID.Valid && (!categoryId.Valid || rule.CategoryID.Int32 != categoryId.Int32) {
+			continue
+		}
+		score := 1
+		if rule.CategoryID.Valid {
+			score += 2
+		}
+		if rule.RoleID.Valid {
+			score++
+		}
+		if score > bestScore {
+			rate, bestScore = rule.RateBps, score
+		}
+	}
+	return rate
+}
+
+// commission считает комиссию в копейках с суммы в рублях с округлением до копейки
+func commission(amount int64, rateBps int32) int64 {
+	return (kopecks(amount)*int64(rateBps) + commissionRateScale/2) / commissionRateScale
+}
+
+// refundCommission делит комиссию заказа пропорционально сумме возврата с округлением до копейки
+func refundCommission(orderCommission int64, amount int64, orderTotal int64) int64 {
+	return (orderCommission*amount + orderTotal/2) / orderTotal
+}
+
+// GetCommissions считает комиссию продавца за [from, to): с оплаченных заказов, оформленных в периоде,
+// за вычетом возвратов, оформленных в периоде. Возврат товара вычитается по ставке этого товара,
+// возврат суммой — по средней ставке заказа. Применяются действующие правила и текущая роль сотрудника.
+func (e EmployeeService) GetCommissions(ctx context.Context, employeeId int32, from time.Time, to time.Time) (CommissionReportDto, error) {
+	employee, err := e.Queries.GetEmployee(ctx, employeeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CommissionReportDto{}, EmployeeNotFound
+		}
+		return CommissionReportDto{}, err
+	}
+	rules, err := e.Queries.ListCommissionRules(ctx)
+	if err != nil {
+		return CommissionReportDto{}, err
+	}
+	seller := pgtype.Int4{Int32: employeeId, Valid: true}
+	period := gen.ListEmployeeSaleLinesParams{
+		EmployeeID: seller,
+		DateFrom:   pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: to, Valid: true},
+	}
+	response := CommissionReportDto{
+		EmployeeId: employeeId,
+		From:       from.Format(DateLayout),
+		To:         to.AddDate(0, 0, -1).Format(DateLayout),
+	}
+
+	sales, err := e.Queries.ListEmployeeSaleLines(ctx, period)
+	if err != nil {
+		return CommissionReportDto{}, err
+	}
+	orders := map[int32]bool{}
+	for _, line := range sales {
+		amount := fromNumeric(line.Price) * int64(line.Quantity)
+		orders[line.OrderID] = true
+		response.SalesAmount += amount
+		response.SalesCommission += commission(amount, commissionRate(rules, employee.RoleID, line.CategoryID))
+	}
+	response.Orders = int32(len(orders))
+
+	returns, err := e.Queries.ListEmployeeRefundLines(ctx, gen.ListEmployeeRefundLinesParams(period))
+	if err != nil {
+		return CommissionReportDto{}, err
+	}
+	for _, line := range returns {
+		amount := fromNumeric(line.Price) * int64(line.Quantity)
+		response.ReturnsAmount += amount
+		response.ReturnsCommission += commission(amount, commissionRate(rules, employee.RoleID, line.CategoryID))
+	}
+
+	refunds, err := e.Queries.ListEmployeeAmountRefunds(ctx, gen.ListEmployeeAmountRefundsParams(period))
+	if err != nil {
+		return CommissionReportDto{}, err
+	}
+	for _, refund := range refunds {
+		amount := fromNumeric(refund.Amount)
+		orderTotal := fromNumeric(refund.OrderTotal)
+		response.ReturnsAmount += amount
+		if orderTotal == 0 {
+			continue
+		}
+		lines, err := e.Queries.ListOrderSaleLines(ctx, refund.OrderID)
+		if err != nil {
+			return CommissionReportDto{}, err
+		}
+		var orderCommission int64
+		for _, line := range lines {
+			orderCommission += commission(fromNumeric(line.Price)*int64(line.Quantity), commissionRate(rules, employee.RoleID, line.CategoryID))
+		}
+		response.ReturnsCommission += refundCommission(orderCommission, amount, orderTotal)
+	}
+
+	response.Earned = response.SalesCommission - response.ReturnsCommission
+	return response, nil
+}
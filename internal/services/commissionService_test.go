@@ -0,0 +1,83 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestCommissionRate(t *testing.T) {
+	role := func(id int32) pgtype.Int4 { return pgtype.Int4{Int32: id, Valid: true} }
+	category := role
+	general := gen.CommissionRule{RateBps: 100}
+	byRole := gen.CommissionRule{RoleID: role(2), RateBps: 200}
+	byCategory := gen.CommissionRule{CategoryID: category(5), RateBps: 300}
+	byBoth := gen.CommissionRule{RoleID: role(2), CategoryID: category(5), RateBps: 400}
+	otherRole := gen.CommissionRule{RoleID: role(3), CategoryID: category(5), RateBps: 900}
+	tests := []struct {
+		name       string
+		rules      []gen.CommissionRule
+		roleId     int32
+		categoryId pgtype.Int4
+		want       int32
+	}{
+		{"нет правил", nil, 2, category(5), 0},
+		{"только общее", []gen.CommissionRule{general}, 2, category(5), 100},
+		{"роль точнее общего", []gen.CommissionRule{general, byRole}, 2, category(5), 200},
+		{"категория точнее роли", []gen.CommissionRule{byRole, byCategory, general}, 2, category(5), 300},
+		{"роль и категория точнее всех", []gen.CommissionRule{byCategory, byBoth, byRole, general}, 2, category(5), 400},
+		{"правило чужой роли не подходит", []gen.CommissionRule{otherRole, general}, 2, category(5), 100},
+		{"другая категория — по роли", []gen.CommissionRule{byBoth, byCategory, byRole}, 2, category(6), 200},
+		{"товар без категории", []gen.CommissionRule{byCategory, general}, 2, pgtype.Int4{}, 100},
+		{"подходящих правил нет", []gen.CommissionRule{otherRole, byCategory}, 2, category(6), 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := commissionRate(test.rules, test.roleId, test.categoryId); got != test.want {
+				t.Errorf("commissionRate = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCommission(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		rateBps int32
+		want    int64
+	}{
+		{10000, 250, 25000},
+		{0, 250, 0},
+		{10000, 0, 0},
+		{3, 150, 5},
+		{1, 50, 1},
+		{1, 49, 0},
+	}
+	for _, test := range tests {
+		if got := commission(test.amount, test.rateBps); got != test.want {
+			t.Errorf("commission(%d, %d) = %d, want %d", test.amount, test.rateBps, got, test.want)
+		}
+	}
+}
+
+func TestRefundCommission(t *testing.T) {
+	tests := []struct {
+		name            string
+		orderCommission int64
+		amount          int64
+		orderTotal      int64
+		want            int64
+	}{
+		{"полный возврат", 5000, 2000, 2000, 5000},
+		{"половина заказа", 5000, 1000, 2000, 2500},
+		{"округление вниз", 100, 1, 3, 33},
+		{"округление половины", 3, 1, 2, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := refundCommission(test.orderCommission, test.amount, test.orderTotal); got != test.want {
+				t.Errorf("refundCommission = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type CustomerAddressDto struct {
+	Id          int32     `json:"id"`
+	CustomerId  int32     `json:"customer_id"`
+	Label       string    `json:"label"`
+	City        string    `json:"city"`
+	Street      string    `json:"street"`
+	Apartment   string    `json:"apartment,omitempty"`
+	Floor       *int32    `json:"floor"`
+	HasElevator bool      `json:"has_elevator"`
+	PostalCode  string    `json:"postal_code,omitempty"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateCustomerAddressDto struct {
+	// Метка адреса: «дом», «работа», «дача»
+	Label       string `json:"label"`
+	City        string `json:"city"`
+	Street      string `json:"street"`
+	Apartment   string `json:"apartment"`
+	Floor       *int32 `json:"floor"`
+	HasElevator bool   `json:"has_elevator"`
+	PostalCode  string `json:"postal_code"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+type UpdateCustomerAddressDto struct {
+	Label       string `json:"label"`
+	City        string `json:"city"`
+	Street      string `json:"street"`
+	Apartment   string `json:"apartment"`
+	Floor       *int32 `json:"floor"`
+	HasElevator bool   `json:"has_elevator"`
+	PostalCode  string `json:"postal_code"`
+}
+
+type CustomerAddressInterface interface {
+	CreateAddress(ctx context.Context, customerId int32, dto CreateCustomerAddressDto) (CustomerAddressDto, error)
+	GetAddress(ctx context.Context, customerId int32, id int32) (CustomerAddressDto, error)
+	GetAddresses(ctx context.Context, customerId int32) ([]CustomerAddressDto, error)
+	UpdateAddress(ctx context.Context, customerId int32, id int32, dto UpdateCustomerAddressDto) (CustomerAddressDto, error)
+	DeleteAddress(ctx context.Context, customerId int32, id int32) error
+	SetDefaultAddress(ctx context.Context, customerId int32, id int32) (CustomerAddressDto, error)
+}
+
+var CustomerNotFound = errors.New("customer not found")
+var CustomerAddressNotFound = errors.New("customer address not found")
+var InvalidAddressError = errors.New("city and street are required")
+var InvalidContactError = errors.New("invalid phone or email")
+
+func ToCustomerAddressDto(address gen.CustomerAddress) CustomerAddressDto {
+	return CustomerAddressDto{
+		Id:          address.ID,
+		CustomerId:  address.CustomerID,
+		Label:       address.Label,
+		City:        address.City,
+		Street:      address.Street,
+		Apartment:   address.Apartment.String,
+		Floor:       fromInt4(address.Floor),
+		HasElevator: address.HasElevator,
+		PostalCode:  address.PostalCode.String,
+		IsDefault:   address.IsDefault,
+		CreatedAt:   address.CreatedAt.Time,
+	}
+}
+
+// formatAddress собирает адрес в одну строку для доставки, этаж и лифт нужны курьеру при подъёме техники
+func formatAddress(address gen.CustomerAddress) string {
+	parts := []string{}
+	if address.PostalCode.Valid {
+		parts = append(parts, address.PostalCode.String)
+	}
+	parts = append(parts, address.City, address.Street)
+	if address.Apartment.Valid {
+		parts = append(parts, "кв. "+address.Apartment.String)
+	}
+	if address.Floor.Valid {
+		parts = append(parts, fmt.Sprintf("этаж %d", address.Floor.Int32))
+		if address.HasElevator {
+			parts = append(parts, "есть лифт")
+		} else {
+			parts = append(parts, "без лифта")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// validateContacts проверяет телефон (10–15 цифр, допускаются +, пробелы, скобки и дефисы) и email, пустые значения разрешены
+func validateContacts(phone string, email string) error {
+	if phone != "" {
+		digits := 0
+		for _, r := range phone {
+			switch {
+			case r >= '0' && r <= '9':
+				digits++
+			case strings.ContainsRune("+-() ", r):
+			default:
+				return InvalidContactError
+			}
+		}
+		if digits < 10 || digits > 15 {
+			return InvalidContactError
+		}
+	}
+	if email != "" {
+		parsed, err := mail.ParseAddress(email)
+		if err != nil || parsed.Address != email {
+			return InvalidContactError
+		}
+	}
+	return nil
+}
+
+func (c CustomerService) checkCustomer(ctx context.Context, q *gen.Queries, customerId int32) error {
+	if _, err := q.GetCustomer(ctx, customerId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CustomerNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (c CustomerService) CreateAddress(ctx context.Context, customerId int32, dto CreateCustomerAddressDto) (CustomerAddressDto, error) {
+	if dto.City == "" || dto.Street == "" {
+		return CustomerAddressDto{}, InvalidAddressError
+	}
+	tx, err := c.DB.Begin(ctx)
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := c.Queries.WithTx(tx)
+
+	if err := c.checkCustomer(ctx, q, customerId); err != nil {
+		return CustomerAddressDto{}, err
+	}
+	existing, err := q.ListCustomerAddresses(ctx, customerId)
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	// Первый адрес клиента сразу становится адресом по умолчанию
+	isDefault := dto.IsDefault || len(existing) == 0
+	if isDefault {
+		if err := q.ClearDefaultCustomerAddress(ctx, customerId); err != nil {
+			return CustomerAddressDto{}, err
+		}
+	}
+	address, err := q.CreateCustomerAddress(ctx, gen.CreateCustomerAddressParams{
+		CustomerID:  customerId,
+		Label:       dto.Label,
+		City:        dto.City,
+		Street:      dto.Street,
+		Apartment:   pgtype.Text{String: dto.Apartment, Valid: dto.Apartment != ""},
+		Floor:       toInt4(dto.Floor),
+		HasElevator: dto.HasElevator,
+		PostalCode:  pgtype.Text{String: dto.PostalCode, Valid: dto.PostalCode != ""},
+		IsDefault:   isDefault,
+		CreatedAt:   pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:     true,
+	})
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return CustomerAddressDto{}, err
+	}
+	return ToCustomerAddressDto(address), nil
+}
+
+func (c CustomerService) GetAddress(ctx context.Context, customerId int32, id int32) (CustomerAddressDto, error) {
+	address, err := c.Queries.GetCustomerAddress(ctx, gen.GetCustomerAddressParams{ID: id, CustomerID: customerId})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CustomerAddressDto{}, CustomerAddressNotFound
+		}
+		return CustomerAddressDto{}, err
+	}
+	return ToCustomerAddressDto(address), nil
+}
+
+func (c CustomerService) GetAddresses(ctx context.Context, customerId int32) ([]CustomerAddressDto, error) {
+	if err := c.checkCustomer(ctx, &c.Queries, customerId); err != nil {
+		return nil, err
+	}
+	addresses, err := c.Queries.ListCustomerAddresses(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]CustomerAddressDto, len(addresses))
+	for i, address := range addresses {
+		response[i] = ToCustomerAddressDto(address)
+	}
+	return response, nil
+}
+
+func (c CustomerService) UpdateAddress(ctx context.Context, customerId int32, id int32, dto UpdateCustomerAddressDto) (CustomerAddressDto, error) {
+	if dto.City == "" || dto.Street == "" {
+		return CustomerAddressDto{}, InvalidAddressError
+	}
+	if _, err := c.GetAddress(ctx, customerId, id); err != nil {
+		return CustomerAddressDto{}, err
+	}
+	address, err := c.Queries.UpdateCustomerAddress(ctx, gen.UpdateCustomerAddressParams{
+		ID:          id,
+		Label:       dto.Label,
+		City:        dto.City,
+		Street:      dto.Street,
+		Apartment:   pgtype.Text{String: dto.Apartment, Valid: dto.Apartment != ""},
+		Floor:       toInt4(dto.Floor),
+		HasElevator: dto.HasElevator,
+		PostalCode:  pgtype.Text{String: dto.PostalCode, Valid: dto.PostalCode != ""},
+	})
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	return ToCustomerAddressDto(address), nil
+}
+
+// DeleteAddress скрывает адрес; если он был адресом по умолчанию, им становится следующий из книги
+func (c CustomerService) DeleteAddress(ctx context.Context, customerId int32, id int32) error {
+	tx, err := c.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := c.Queries.WithTx(tx)
+
+	address, err := q.GetCustomerAddress(ctx, gen.GetCustomerAddressParams{ID: id, CustomerID: customerId})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CustomerAddressNotFound
+		}
+		return err
+	}
+	if err := q.DeleteCustomerAddress(ctx, id); err != nil {
+		return err
+	}
+	if address.IsDefault {
+		rest, err := q.ListCustomerAddresses(ctx, customerId)
+		if err != nil {
+			return err
+		}
+		if len(rest) > 0 {
+			if _, err := q.SetDefaultCustomerAddress(ctx, rest[0].ID); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (c CustomerService) SetDefaultAddress(ctx context.Context, customerId int32, id int32) (CustomerAddressDto, error) {
+	tx, err := c.DB.Begin(ctx)
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := c.Queries.WithTx(tx)
+
+	if _, err := q.GetCustomerAddress(ctx, gen.GetCustomerAddressParams{ID: id, CustomerID: customerId}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CustomerAddressDto{}, CustomerAddressNotFound
+		}
+		return CustomerAddressDto{}, err
+	}
+	if err := q.ClearDefaultCustomerAddress(ctx, customerId); err != nil {
+		return CustomerAddressDto{}, err
+	}
+	address, err := q.SetDefaultCustomerAddress(ctx, id)
+	if err != nil {
+		return CustomerAddressDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return CustomerAddressDto{}, err
+	}
+	return ToCustomerAddressDto(address), nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestFormatAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address gen.CustomerAddress
+		want    string
+	}{
+		{
+			name:    "только город и улица",
+			address: gen.CustomerAddress{City: "Казань", Street: "ул. Баумана, 1"},
+			want:    "Казань, ул. Баумана, 1",
+		},
+		{
+			name: "все поля, есть лифт",
+			address: gen.CustomerAddress{
+				City:        "Москва",
+				Street:      "Тверская ул., 7",
+				Apartment:   pgtype.Text{String: "12", Valid: true},
+				Floor:       pgtype.Int4{Int32: 5, Valid: true},
+				HasElevator: true,
+				PostalCode:  pgtype.Text{String: "125009", Valid: true},
+			},
+			want: "125009, Москва, Тверская ул., 7, кв. 12, этаж 5, есть лифт",
+		},
+		{
+			name: "этаж без лифта",
+			address: gen.CustomerAddress{
+				City:   "Тула",
+				Street: "пр. Ленина, 3",
+				Floor:  pgtype.Int4{Int32: 4, Valid: true},
+			},
+			want: "Тула, пр. Ленина, 3, этаж 4, без лифта",
+		},
+		{
+			name:    "лифт без этажа не печатается",
+			address: gen.CustomerAddress{City: "Тула", Street: "пр. Ленина, 3", HasElevator: true},
+			want:    "Тула, пр. Ленина, 3",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := formatAddress(test.address); got != test.want {
+				t.Errorf("formatAddress = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateContacts(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		email string
+		want  error
+	}{
+		{"пустые контакты", "", "", nil},
+		{"телефон с форматированием", "+7 (912) 345-67-89", "", nil},
+		{"международный телефон", "+441234567890123", "", nil},
+		{"короткий телефон", "12345", "", InvalidContactError},
+		{"слишком длинный телефон", "1234567890123456", "", InvalidContactError},
+		{"буквы в телефоне", "+7 912 ABC 67 89", "", InvalidContactError},
+		{"email", "", "ivan@example.com", nil},
+		{"email без домена", "", "ivan@", InvalidContactError},
+		{"email с именем", "", "Иван <ivan@example.com>", InvalidContactError},
+		{"верный телефон и неверный email", "89123456789", "ivan", InvalidContactError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateContacts(test.phone, test.email); !errors.Is(err, test.want) {
+				t.Errorf("validateContacts(%q, %q) = %v, want %v", test.phone, test.email, err, test.want)
+			}
+		})
+	}
+}
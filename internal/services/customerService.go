@@ -4,6 +4,7 @@ import (
 	"HomeApplianceStore/pkg/gen"
 	"context"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"math/big"
 	"time"
 )
@@ -18,14 +19,20 @@ type CustomerInt interface {
 
 type CustomerService struct {
 	Queries gen.Queries
+	DB      *pgxpool.Pool
 }
 
 func (c CustomerService) CreateCustomer(ctx context.Context, request CreateCustomerDto) (CustomerDto, error) {
+	if err := validateContacts(request.Phone, request.Email); err != nil {
+		return CustomerDto{}, err
+	}
 	customer, err := c.Queries.CreateCustomer(ctx, gen.CreateCustomerParams{
 		AccountID: request.AccountId,
 		Balance:   pgtype.Numeric{Int: big.NewInt(int64(request.Balance))},
 		CreatedAt: pgtype.Timestamp{Time: time.Now()},
 		IsAlive:   true,
+		Phone:     pgtype.Text{String: request.Phone, Valid: request.Phone != ""},
+		Email:     pgtype.Text{String: request.Email, Valid: request.Email != ""},
 	})
 	if err != nil {
 		return CustomerDto{}, err
@@ -39,6 +46,8 @@ func (c CustomerService) CreateCustomer(ctx context.Context, request CreateCusto
 		Id:        customer.ID,
 		Account:   accountDto,
 		Balance:   customer.Balance.Int.Int64(),
+		Phone:     customer.Phone.String,
+		Email:     customer.Email.String,
 		CreatedAt: customer.CreatedAt.Time,
 		IsAlive:   account.IsAlive,
 	}
@@ -59,6 +68,8 @@ func (c CustomerService) GetCustomer(ctx context.Context, id int32) (CustomerDto
 			IsAlive:   customer.AccountIsAlive,
 		},
 		Balance:   customer.Balance.Int.Int64(),
+		Phone:     customer.Phone.String,
+		Email:     customer.Email.String,
 		CreatedAt: customer.CreatedAt.Time,
 		IsAlive:   customer.IsAlive,
 	}
@@ -81,6 +92,8 @@ func (c CustomerService) GetCustomers(ctx context.Context) ([]CustomerDto, error
 				IsAlive:   customer.IsAlive,
 			},
 			Balance:   customer.Balance.Int.Int64(),
+			Phone:     customer.Phone.String,
+			Email:     customer.Email.String,
 			CreatedAt: customer.CreatedAt.Time,
 			IsAlive:   customer.IsAlive,
 		}
@@ -89,10 +102,15 @@ func (c CustomerService) GetCustomers(ctx context.Context) ([]CustomerDto, error
 }
 
 func (c CustomerService) UpdateCustomer(ctx context.Context, request UpdateCustomerDto) (CustomerDto, error) {
+	if err := validateContacts(request.Phone, request.Email); err != nil {
+		return CustomerDto{}, err
+	}
 	customer, err := c.Queries.UpdateCustomer(ctx, gen.UpdateCustomerParams{
 		ID:      request.Id,
 		Balance: pgtype.Numeric{Int: request.Balance},
 		IsAlive: request.IsAlive,
+		Phone:   pgtype.Text{String: request.Phone, Valid: request.Phone != ""},
+		Email:   pgtype.Text{String: request.Email, Valid: request.Email != ""},
 	})
 	if err != nil {
 		return CustomerDto{}, err
@@ -106,6 +124,8 @@ func (c CustomerService) UpdateCustomer(ctx context.Context, request UpdateCusto
 		Id:        customer.ID,
 		Account:   accountDto,
 		Balance:   customer.Balance.Int.Int64(),
+		Phone:     customer.Phone.String,
+		Email:     customer.Email.String,
 		CreatedAt: customer.CreatedAt.Time,
 	}
 	return response, nil
@@ -123,6 +143,8 @@ type CustomerDto struct {
 	Id        int32      `json:"id"`
 	Account   AccountDto `json:"account"`
 	Balance   int64      `json:"balance"`
+	Phone     string     `json:"phone,omitempty"`
+	Email     string     `json:"email,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	IsAlive   bool       `json:"is_alive"`
 }
@@ -130,6 +152,8 @@ type CustomerDto struct {
 type CreateCustomerDto struct {
 	AccountId int32
 	Balance   int
+	Phone     string `json:"phone"`
+	Email     string `json:"email"`
 }
 
 type UpdateCustomerDto struct {
@@ -137,4 +161,6 @@ type UpdateCustomerDto struct {
 	AccountId int32
 	Balance   *big.Int
 	IsAlive   bool
+	Phone     string `json:"phone"`
+	Email     string `json:"email"`
 }
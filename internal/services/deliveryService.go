@@ -0,0 +1,441 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
+	"time"
+)
+
+const (
+	DeliveryStatusScheduled      = "scheduled"
+	DeliveryStatusOutForDelivery = "out_for_delivery"
+	DeliveryStatusDelivered      = "delivered"
+	DeliveryStatusFailed         = "failed"
+)
+
+// Допустимые переходы статусов доставки, delivered и failed — конечные
+var deliveryTransitions = map[string][]string{
+	DeliveryStatusScheduled:      {DeliveryStatusOutForDelivery, DeliveryStatusFailed},
+	DeliveryStatusOutForDelivery: {DeliveryStatusDelivered, DeliveryStatusFailed},
+}
+
+type DeliverySlotDto struct {
+	Id        int32     `json:"id"`
+	StoreId   int32     `json:"store_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Capacity  int32     `json:"capacity"`
+	Booked    int32     `json:"booked"`
+	Available int32     `json:"available"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateDeliverySlotDto struct {
+	StoreId  int32     `json:"store_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Сколько доставок магазин может выполнить в этот интервал
+	Capacity int32 `json:"capacity"`
+}
+
+type DeliveryDto struct {
+	Id        int32     `json:"id"`
+	OrderId   int32     `json:"order_id"`
+	SlotId    int32     `json:"slot_id"`
+	CourierId *int32    `json:"courier_id"`
+	Address   string    `json:"address"`
+	Comment   string    `json:"comment,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateDeliveryDto struct {
+	OrderId int32 `json:"order_id"`
+	SlotId  int32 `json:"slot_id"`
+	// Адрес можно не указывать: тогда берётся AddressId или адрес по умолчанию из книги адресов клиента
+	Address   string `json:"address"`
+	AddressId *int32 `json:"address_id"`
+	Comment   string `json:"comment"`
+}
+
+type AssignCourierDto struct {
+	CourierId int32 `json:"courier_id"`
+}
+
+type UpdateDeliveryStatusDto struct {
+	Status string `json:"status"`
+}
+
+type CourierRouteStopDto struct {
+	Sequence     int32       `json:"sequence"`
+	SlotStartsAt time.Time   `json:"slot_starts_at"`
+	SlotEndsAt   time.Time   `json:"slot_ends_at"`
+	Delivery     DeliveryDto `json:"delivery"`
+}
+
+type CourierRouteDto struct {
+	CourierId int32                 `json:"courier_id"`
+	Date      string                `json:"date"`
+	Stops     []CourierRouteStopDto `json:"stops"`
+}
+
+type DeliveryInterface interface {
+	CreateSlot(ctx context.Context, dto CreateDeliverySlotDto) (DeliverySlotDto, error)
+	GetSlots(ctx context.Context, storeId int32, from time.Time, to time.Time) ([]DeliverySlotDto, error)
+	CreateDelivery(ctx context.Context, dto CreateDeliveryDto) (DeliveryDto, error)
+	GetDelivery(ctx context.Context, id int32) (DeliveryDto, error)
+	GetDeliveries(ctx context.Context) ([]DeliveryDto, error)
+	GetOrderDeliveries(ctx context.Context, orderId int32) ([]DeliveryDto, error)
+	AssignCourier(ctx context.Context, id int32, dto AssignCourierDto) (DeliveryDto, error)
+	UpdateStatus(ctx context.Context, id int32, dto UpdateDeliveryStatusDto) (DeliveryDto, error)
+	GetCourierRoute(ctx context.Context, courierId int32, date time.Time) (CourierRouteDto, error)
+}
+
+type DeliveryService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var DeliveryNotFound = errors.New("delivery not found")
+var DeliverySlotNotFound = errors.New("delivery slot not found")
+var CourierNotFound = errors.New("courier not found")
+var InvalidDeliverySlotError = errors.New("invalid delivery slot")
+var DeliverySlotFullError = errors.New("delivery slot is fully booked")
+var DeliveryExistsError = errors.New("order already has an active delivery")
+var DeliveryStatusError = errors.New("delivery status transition is not allowed")
+
+func ToDeliveryDto(delivery gen.Delivery) DeliveryDto {
+	return DeliveryDto{
+		Id:        delivery.ID,
+		OrderId:   delivery.OrderID,
+		SlotId:    delivery.SlotID,
+		CourierId: fromInt4(delivery.CourierID),
+		Address:   delivery.Address,
+		Comment:   delivery.Comment.String,
+		Status:    delivery.Status,
+		CreatedAt: delivery.CreatedAt.Time,
+		UpdatedAt: delivery.UpdatedAt.Time,
+	}
+}
+
+func (d DeliveryService) CreateSlot(ctx context.Context, dto CreateDeliverySlotDto) (DeliverySlotDto, error) {
+	if dto.Capacity <= 0 || !dto.EndsAt.After(dto.StartsAt) {
+		return DeliverySlotDto{}, InvalidDeliverySlotError
+	}
+	if _, err := d.Queries.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliverySlotDto{}, StoreNotFound
+		}
+		return DeliverySlotDto{}, err
+	}
+	slot, err := d.Queries.CreateDeliverySlot(ctx, gen.CreateDeliverySlotParams{
+		StoreID:   dto.StoreId,
+		StartsAt:  pgtype.Timestamp{Time: dto.StartsAt, Valid: true},
+		EndsAt:    pgtype.Timestamp{Time: dto.EndsAt, Valid: true},
+		Capacity:  dto.Capacity,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return DeliverySlotDto{}, err
+	}
+	return DeliverySlotDto{
+		Id:        slot.ID,
+		StoreId:   slot.StoreID,
+		StartsAt:  slot.StartsAt.Time,
+		EndsAt:    slot.EndsAt.Time,
+		Capacity:  slot.Capacity,
+		Available: slot.Capacity,
+		CreatedAt: slot.CreatedAt.Time,
+	}, nil
+}
+
+// GetSlots возвращает слоты магазина, начинающиеся в [from, to), с числом занятых мест
+func (d DeliveryService) GetSlots(ctx context.Context, storeId int32, from time.Time, to time.Time) ([]DeliverySlotDto, error) {
+	slots, err := d.Queries.ListDeliverySlots(ctx, gen.ListDeliverySlotsParams{
+		StoreID:  storeId,
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]DeliverySlotDto, len(slots))
+	for i, slot := range slots {
+		response[i] = DeliverySlotDto{
+			Id:        slot.ID,
+			StoreId:   slot.StoreID,
+			StartsAt:  slot.StartsAt.Time,
+			EndsAt:    slot.EndsAt.Time,
+			Capacity:  slot.Capacity,
+			Booked:    slot.Booked,
+			Available: max(slot.Capacity-slot.Booked, 0),
+			CreatedAt: slot.CreatedAt.Time,
+		}
+	}
+	return response, nil
+}
+
+// CreateDelivery бронирует место в слоте магазина заказа. Слот блокируется на время проверки вместимости.
+func (d DeliveryService) CreateDelivery(ctx context.Context, dto CreateDeliveryDto) (DeliveryDto, error) {
+	tx, err := d.DB.Begin(ctx)
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := d.Queries.WithTx(tx)
+
+	order, err := q.GetOrderForUpdate(ctx, dto.OrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, OrderNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	if order.Status == OrderStatusCancelled {
+		return DeliveryDto{}, OrderStatusError
+	}
+	address := dto.Address
+	if address == "" {
+		address, err = customerDeliveryAddress(ctx, q, order, dto.AddressId)
+		if err != nil {
+			return DeliveryDto{}, err
+		}
+	}
+	deliveries, err := q.ListDeliveriesByOrder(ctx, order.ID)
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	for _, delivery := range deliveries {
+		if delivery.Status == DeliveryStatusScheduled || delivery.Status == DeliveryStatusOutForDelivery {
+			return DeliveryDto{}, DeliveryExistsError
+		}
+	}
+
+	slot, err := q.GetDeliverySlotForUpdate(ctx, dto.SlotId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, DeliverySlotNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	booked, err := q.CountBookedDeliveries(ctx, slot.ID)
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	if err := checkSlotBookable(slot, order.StoreID, booked, time.Now()); err != nil {
+		return DeliveryDto{}, err
+	}
+
+	delivery, err := q.CreateDelivery(ctx, gen.CreateDeliveryParams{
+		OrderID:   order.ID,
+		SlotID:    slot.ID,
+		Address:   address,
+		Comment:   pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		Status:    DeliveryStatusScheduled,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return DeliveryDto{}, err
+	}
+	return ToDeliveryDto(delivery), nil
+}
+
+// checkSlotBookable проверяет, что на слот можно записать доставку заказа: слот того же магазина,
+// ещё не начался и в нём остались места
+func checkSlotBookable(slot gen.DeliverySlot, storeId int32, booked int32, now time.Time) error {
+	if slot.StoreID != storeId || !slot.StartsAt.Time.After(now) {
+		return InvalidDeliverySlotError
+	}
+	if booked >= slot.Capacity {
+		return DeliverySlotFullError
+	}
+	return nil
+}
+
+// checkDeliveryTransition проверяет переход статуса: везти заказ можно только с назначенным курьером
+func checkDeliveryTransition(delivery gen.Delivery, status string) error {
+	if !slices.Contains(deliveryTransitions[delivery.Status], status) {
+		return DeliveryStatusError
+	}
+	if status == DeliveryStatusOutForDelivery && !delivery.CourierID.Valid {
+		return DeliveryStatusError
+	}
+	return nil
+}
+
+// customerDeliveryAddress берёт адрес из книги адресов покупателя заказа: указанный или адрес по умолчанию
+func customerDeliveryAddress(ctx context.Context, q *gen.Queries, order gen.Order, addressId *int32) (string, error) {
+	if !order.CustomerID.Valid {
+		return "", InvalidAddressError
+	}
+	if addressId != nil {
+		address, err := q.GetCustomerAddress(ctx, gen.GetCustomerAddressParams{ID: *addressId, CustomerID: order.CustomerID.Int32})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", CustomerAddressNotFound
+			}
+			return "", err
+		}
+		return formatAddress(address), nil
+	}
+	addresses, err := q.ListCustomerAddresses(ctx, order.CustomerID.Int32)
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 || !addresses[0].IsDefault {
+		return "", InvalidAddressError
+	}
+	return formatAddress(addresses[0]), nil
+}
+
+func (d DeliveryService) GetDelivery(ctx context.Context, id int32) (DeliveryDto, error) {
+	delivery, err := d.Queries.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, DeliveryNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	return ToDeliveryDto(delivery), nil
+}
+
+func (d DeliveryService) GetDeliveries(ctx context.Context) ([]DeliveryDto, error) {
+	deliveries, err := d.Queries.ListDeliveries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]DeliveryDto, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = ToDeliveryDto(delivery)
+	}
+	return response, nil
+}
+
+func (d DeliveryService) GetOrderDeliveries(ctx context.Context, orderId int32) ([]DeliveryDto, error) {
+	deliveries, err := d.Queries.ListDeliveriesByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]DeliveryDto, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = ToDeliveryDto(delivery)
+	}
+	return response, nil
+}
+
+// AssignCourier назначает курьером сотрудника. Переназначить можно, пока доставка не выехала.
+func (d DeliveryService) AssignCourier(ctx context.Context, id int32, dto AssignCourierDto) (DeliveryDto, error) {
+	tx, err := d.DB.Begin(ctx)
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := d.Queries.WithTx(tx)
+
+	delivery, err := q.GetDeliveryForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, DeliveryNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	if delivery.Status != DeliveryStatusScheduled {
+		return DeliveryDto{}, DeliveryStatusError
+	}
+	courier, err := q.GetEmployee(ctx, dto.CourierId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, CourierNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	if !courier.IsAlive {
+		return DeliveryDto{}, CourierNotFound
+	}
+	delivery, err = q.AssignDeliveryCourier(ctx, gen.AssignDeliveryCourierParams{
+		ID:        delivery.ID,
+		CourierID: pgtype.Int4{Int32: courier.ID, Valid: true},
+	})
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return DeliveryDto{}, err
+	}
+	return ToDeliveryDto(delivery), nil
+}
+
+// UpdateStatus двигает доставку по статусам. Выехать без назначенного курьера нельзя.
+func (d DeliveryService) UpdateStatus(ctx context.Context, id int32, dto UpdateDeliveryStatusDto) (DeliveryDto, error) {
+	tx, err := d.DB.Begin(ctx)
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := d.Queries.WithTx(tx)
+
+	delivery, err := q.GetDeliveryForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DeliveryDto{}, DeliveryNotFound
+		}
+		return DeliveryDto{}, err
+	}
+	if err := checkDeliveryTransition(delivery, dto.Status); err != nil {
+		return DeliveryDto{}, err
+	}
+	delivery, err = q.UpdateDeliveryStatus(ctx, gen.UpdateDeliveryStatusParams{ID: delivery.ID, Status: dto.Status})
+	if err != nil {
+		return DeliveryDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return DeliveryDto{}, err
+	}
+	return ToDeliveryDto(delivery), nil
+}
+
+// GetCourierRoute возвращает маршрутный лист курьера на день: доставки в порядке слотов
+func (d DeliveryService) GetCourierRoute(ctx context.Context, courierId int32, date time.Time) (CourierRouteDto, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	rows, err := d.Queries.ListCourierRoute(ctx, gen.ListCourierRouteParams{
+		CourierID: pgtype.Int4{Int32: courierId, Valid: true},
+		DateFrom:  pgtype.Timestamp{Time: day, Valid: true},
+		DateTo:    pgtype.Timestamp{Time: day.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		return CourierRouteDto{}, err
+	}
+	response := CourierRouteDto{
+		CourierId: courierId,
+		Date:      day.Format("2006-01-02"),
+		Stops:     make([]CourierRouteStopDto, len(rows)),
+	}
+	for i, row := range rows {
+		response.Stops[i] = CourierRouteStopDto{
+			Sequence:     int32(i + 1),
+			SlotStartsAt: row.SlotStartsAt.Time,
+			SlotEndsAt:   row.SlotEndsAt.Time,
+			Delivery: ToDeliveryDto(gen.Delivery{
+				ID:        row.ID,
+				OrderID:   row.OrderID,
+				SlotID:    row.SlotID,
+				CourierID: row.CourierID,
+				Address:   row.Address,
+				Comment:   row.Comment,
+				Status:    row.Status,
+				CreatedAt: row.CreatedAt,
+				UpdatedAt: row.UpdatedAt,
+			}),
+		}
+	}
+	return response, nil
+}
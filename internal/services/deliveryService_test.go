@@ -0,0 +1,66 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+	"time"
+)
+
+func TestCheckSlotBookable(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	slot := gen.DeliverySlot{
+		StoreID:  1,
+		StartsAt: pgtype.Timestamp{Time: now.Add(2 * time.Hour), Valid: true},
+		Capacity: 3,
+	}
+	started := slot
+	started.StartsAt = pgtype.Timestamp{Time: now, Valid: true}
+	tests := []struct {
+		name    string
+		slot    gen.DeliverySlot
+		storeId int32
+		booked  int32
+		want    error
+	}{
+		{"свободный слот", slot, 1, 0, nil},
+		{"последнее место", slot, 1, 2, nil},
+		{"мест нет", slot, 1, 3, DeliverySlotFullError},
+		{"слот другого магазина", slot, 2, 0, InvalidDeliverySlotError},
+		{"слот уже начался", started, 1, 0, InvalidDeliverySlotError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkSlotBookable(test.slot, test.storeId, test.booked, now); !errors.Is(err, test.want) {
+				t.Errorf("checkSlotBookable = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckDeliveryTransition(t *testing.T) {
+	courier := pgtype.Int4{Int32: 5, Valid: true}
+	tests := []struct {
+		from    string
+		courier pgtype.Int4
+		to      string
+		want    error
+	}{
+		{DeliveryStatusScheduled, courier, DeliveryStatusOutForDelivery, nil},
+		{DeliveryStatusScheduled, pgtype.Int4{}, DeliveryStatusOutForDelivery, DeliveryStatusError},
+		{DeliveryStatusScheduled, pgtype.Int4{}, DeliveryStatusFailed, nil},
+		{DeliveryStatusScheduled, courier, DeliveryStatusDelivered, DeliveryStatusError},
+		{DeliveryStatusOutForDelivery, courier, DeliveryStatusDelivered, nil},
+		{DeliveryStatusOutForDelivery, courier, DeliveryStatusFailed, nil},
+		{DeliveryStatusOutForDelivery, courier, DeliveryStatusScheduled, DeliveryStatusError},
+		{DeliveryStatusDelivered, courier, DeliveryStatusFailed, DeliveryStatusError},
+		{DeliveryStatusFailed, courier, DeliveryStatusScheduled, DeliveryStatusError},
+	}
+	for _, test := range tests {
+		delivery := gen.Delivery{Status: test.from, CourierID: test.courier}
+		if err := checkDeliveryTransition(delivery, test.to); !errors.Is(err, test.want) {
+			t.Errorf("%s -> %s (курьер %v): err = %v, want %v", test.from, test.to, test.courier.Valid, err, test.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"HomeApplianceStore/pkg/gen"
 	"context"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"time"
 )
 
@@ -17,6 +18,7 @@ type EmployeeInterface interface {
 
 type EmployeeService struct {
 	Queries gen.Queries
+	DB      *pgxpool.Pool
 }
 
 func (e EmployeeService) CreateEmployee(ctx context.Context, request CreateEmployeeRequest) (EmployeeDto, error) {
@@ -91,17 +93,19 @@ func (e EmployeeService) DeleteEmployee(ctx context.Context, id int32) error {
 }
 
 type EmployeeDto struct {
-	Id        int32      `json:"id"`
-	Account   AccountDto `json:"account"`
-	Role      RoleDto    `json:"role"`
-	CreatedAt time.Time  `json:"created_at"`
-	IsAlive   bool       `json:"is_alive"`
+	Id      int32      `json:"id"`
+	Account AccountDto `json:"account"`
+	Role    RoleDto    `json:"role"`
+	// Текущий магазин сотрудника, null — не назначен
+	Store     *EmployeeStoreDto `json:"store,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	IsAlive   bool              `json:"is_alive"`
 }
 
 func ToEmployeeDtoAny(row any) EmployeeDto {
 	switch r := row.(type) {
 	case gen.GetEmployeeRow:
-		return convertRow(
+		response := convertRow(
 			r.ID,
 			r.AccountID,
 			r.AccountLogin,
@@ -113,8 +117,10 @@ func ToEmployeeDtoAny(row any) EmployeeDto {
 			r.CreatedAt.Time,
 			r.IsAlive,
 		)
+		response.Store = toEmployeeStoreDto(r.CurrentStoreID, r.CurrentStoreAddress, r.CurrentStoreSince)
+		return response
 	case gen.ListEmployeesRow:
-		return convertRow(
+		response := convertRow(
 			r.ID,
 			r.AccountID,
 			r.AccountLogin,
@@ -126,6 +132,8 @@ func ToEmployeeDtoAny(row any) EmployeeDto {
 			r.CreatedAt.Time,
 			r.IsAlive,
 		)
+		response.Store = toEmployeeStoreDto(r.CurrentStoreID, r.CurrentStoreAddress, r.CurrentStoreSince)
+		return response
 	default:
 		panic("unsupported type")
 	}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// EmployeeStoreDto — магазин, в котором сотрудник работает сейчас
+type EmployeeStoreDto struct {
+	StoreId int32  `json:"store_id"`
+	Address string `json:"address"`
+	Since   string `json:"since"`
+}
+
+// EmployeeStoreAssignmentDto — запись истории назначений, дата окончания не включительно
+type EmployeeStoreAssignmentDto struct {
+	Id           int32   `json:"id"`
+	EmployeeId   int32   `json:"employee_id"`
+	StoreId      int32   `json:"store_id"`
+	StoreAddress string  `json:"store_address,omitempty"`
+	StartsOn     string  `json:"starts_on"`
+	EndsOn       *string `json:"ends_on"`
+}
+
+type AssignEmployeeStoreDto struct {
+	StoreId int32 `json:"store_id"`
+	// Дата начала работы в магазине, YYYY-MM-DD, по умолчанию сегодня
+	StartsOn string `json:"starts_on"`
+}
+
+type StoreEmployeeDto struct {
+	Employee     EmployeeDto `json:"employee"`
+	AssignedFrom string      `json:"assigned_from"`
+	AssignedTo   *string     `json:"assigned_to"`
+}
+
+var EmployeeNotFound = errors.New("employee not found")
+var EmployeeAlreadyAssignedError = errors.New("employee is already assigned to this store")
+var InvalidAssignmentDateError = errors.New("assignment cannot start before the current one")
+var EmployeeNotAssignedError = errors.New("employee is not assigned to any store")
+
+func toEmployeeStoreDto(storeId pgtype.Int4, address pgtype.Text, since pgtype.Date) *EmployeeStoreDto {
+	if !storeId.Valid {
+		return nil
+	}
+	return &EmployeeStoreDto{
+		StoreId: storeId.Int32,
+		Address: address.String,
+		Since:   since.Time.Format(DateLayout),
+	}
+}
+
+func ToEmployeeStoreAssignmentDto(assignment gen.EmployeeStore) EmployeeStoreAssignmentDto {
+	return EmployeeStoreAssignmentDto{
+		Id:         assignment.ID,
+		EmployeeId: assignment.EmployeeID,
+		StoreId:    assignment.StoreID,
+		StartsOn:   assignment.StartsOn.Time.Format(DateLayout),
+		EndsOn:     fromDate(assignment.EndsOn),
+	}
+}
+
+// checkEmployeeInStore проверяет, что сотрудник работает в магазине сегодня
+func checkEmployeeInStore(ctx context.Context, q *gen.Queries, employeeId int32, storeId int32) error {
+	today, err := parseDate("")
+	if err != nil {
+		return err
+	}
+	assignments, err := q.CountEmployeeStoreAssignments(ctx, gen.CountEmployeeStoreAssignmentsParams{
+		EmployeeID: employeeId,
+		StoreID:    storeId,
+		OnDate:     today,
+	})
+	if err != nil {
+		return err
+	}
+	if assignments == 0 {
+		return EmployeeNotInStoreError
+	}
+	return nil
+}
+
+// checkTransfer проверяет перевод из текущего назначения в магазин storeId с даты startsOn.
+// replace означает, что текущее назначение начато в тот же день и заменяется, а не закрывается.
+func checkTransfer(current gen.EmployeeStore, storeId int32, startsOn pgtype.Date) (replace bool, err error) {
+	switch {
+	case current.StoreID == storeId:
+		return false, EmployeeAlreadyAssignedError
+	case startsOn.Time.Before(current.StartsOn.Time):
+		return false, InvalidAssignmentDateError
+	}
+	return startsOn.Time.Equal(current.StartsOn.Time), nil
+}
+
+// checkAssignmentEnd проверяет, что назначение закрывается позже дня, в который оно началось
+func checkAssignmentEnd(current gen.EmployeeStore, endsOn pgtype.Date) error {
+	if !endsOn.Time.After(current.StartsOn.Time) {
+		return InvalidAssignmentDateError
+	}
+	return nil
+}
+
+// AssignStore назначает сотрудника в магазин. При переводе текущее назначение закрывается датой
+// начала нового, так что история остаётся непрерывной. Назначение, начатое в тот же день, заменяется.
+func (e EmployeeService) AssignStore(ctx context.Context, employeeId int32, dto AssignEmployeeStoreDto) (EmployeeStoreAssignmentDto, error) {
+	startsOn, err := parseDate(dto.StartsOn)
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	tx, err := e.DB.Begin(ctx)
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := e.Queries.WithTx(tx)
+
+	if _, err := q.LockEmployee(ctx, employeeId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EmployeeStoreAssignmentDto{}, EmployeeNotFound
+		}
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	store, err := q.GetStore(ctx, dto.StoreId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EmployeeStoreAssignmentDto{}, StoreNotFound
+		}
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	if !store.IsAlive {
+		return EmployeeStoreAssignmentDto{}, StoreNotFound
+	}
+
+	current, err := q.GetCurrentEmployeeStoreForUpdate(ctx, employeeId)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return EmployeeStoreAssignmentDto{}, err
+	default:
+		replace, err := checkTransfer(current, dto.StoreId, startsOn)
+		if err != nil {
+			return EmployeeStoreAssignmentDto{}, err
+		}
+		if replace {
+			err = q.DeleteEmployeeStore(ctx, current.ID)
+		} else {
+			_, err = q.CloseEmployeeStore(ctx, gen.CloseEmployeeStoreParams{ID: current.ID, EndsOn: startsOn})
+		}
+		if err != nil {
+			return EmployeeStoreAssignmentDto{}, err
+		}
+	}
+
+	assignment, err := q.CreateEmployeeStore(ctx, gen.CreateEmployeeStoreParams{
+		EmployeeID: employeeId,
+		StoreID:    store.ID,
+		StartsOn:   startsOn,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	response := ToEmployeeStoreAssignmentDto(assignment)
+	response.StoreAddress = store.Address
+	return response, nil
+}
+
+// EndStoreAssignment закрывает текущее назначение, например при увольнении
+func (e EmployeeService) EndStoreAssignment(ctx context.Context, employeeId int32, endsOn string) (EmployeeStoreAssignmentDto, error) {
+	date, err := parseDate(endsOn)
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	tx, err := e.DB.Begin(ctx)
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := e.Queries.WithTx(tx)
+
+	current, err := q.GetCurrentEmployeeStoreForUpdate(ctx, employeeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EmployeeStoreAssignmentDto{}, EmployeeNotAssignedError
+		}
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	if err := checkAssignmentEnd(current, date); err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	closed, err := q.CloseEmployeeStore(ctx, gen.CloseEmployeeStoreParams{ID: current.ID, EndsOn: date})
+	if err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return EmployeeStoreAssignmentDto{}, err
+	}
+	return ToEmployeeStoreAssignmentDto(closed), nil
+}
+
+func (e EmployeeService) GetStoreHistory(ctx context.Context, employeeId int32) ([]EmployeeStoreAssignmentDto, error) {
+	rows, err := e.Queries.ListEmployeeStores(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]EmployeeStoreAssignmentDto, len(rows))
+	for i, row := range rows {
+		response[i] = EmployeeStoreAssignmentDto{
+			Id:           row.ID,
+			EmployeeId:   row.EmployeeID,
+			StoreId:      row.StoreID,
+			StoreAddress: row.StoreAddress,
+			StartsOn:     row.StartsOn.Time.Format(DateLayout),
+			EndsOn:       fromDate(row.EndsOn),
+		}
+	}
+	return response, nil
+}
+
+// GetStoreEmployees возвращает сотрудников, работавших в магазине в указанный день (по умолчанию сегодня)
+func (s StoreService) GetStoreEmployees(ctx context.Context, storeId int32, date string) ([]StoreEmployeeDto, error) {
+	onDate, err := parseDate(date)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Queries.GetStore(ctx, storeId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, StoreNotFound
+		}
+		return nil, err
+	}
+	rows, err := s.Queries.ListStoreEmployees(ctx, gen.ListStoreEmployeesParams{StoreID: storeId, OnDate: onDate})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]StoreEmployeeDto, len(rows))
+	for i, r := range rows {
+		response[i] = StoreEmployeeDto{
+			Employee: convertRow(
+				r.ID,
+				r.AccountID,
+				r.AccountLogin,
+				r.AccountCreatedAt.Time,
+				r.AccountIsAlive,
+				r.RoleID,
+				r.RoleName,
+				r.RoleCreatedAt.Time,
+				r.CreatedAt.Time,
+				r.IsAlive,
+			),
+			AssignedFrom: r.AssignedFrom.Time.Format(DateLayout),
+			AssignedTo:   fromDate(r.AssignedTo),
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+	"time"
+)
+
+func testDate(year int, month time.Month, day int) pgtype.Date {
+	return pgtype.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func TestCheckTransfer(t *testing.T) {
+	current := gen.EmployeeStore{ID: 1, EmployeeID: 7, StoreID: 2, StartsOn: testDate(2024, 3, 1)}
+	tests := []struct {
+		name        string
+		storeId     int32
+		startsOn    pgtype.Date
+		wantReplace bool
+		wantErr     error
+	}{
+		{"перевод позже начала закрывает назначение", 3, testDate(2024, 4, 1), false, nil},
+		{"перевод в день начала заменяет назначение", 3, testDate(2024, 3, 1), true, nil},
+		{"тот же магазин", 2, testDate(2024, 4, 1), false, EmployeeAlreadyAssignedError},
+		{"перевод раньше начала", 3, testDate(2024, 2, 28), false, InvalidAssignmentDateError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			replace, err := checkTransfer(current, test.storeId, test.startsOn)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("checkTransfer error = %v, want %v", err, test.wantErr)
+			}
+			if replace != test.wantReplace {
+				t.Errorf("checkTransfer replace = %v, want %v", replace, test.wantReplace)
+			}
+		})
+	}
+}
+
+func TestCheckAssignmentEnd(t *testing.T) {
+	current := gen.EmployeeStore{ID: 1, EmployeeID: 7, StoreID: 2, StartsOn: testDate(2024, 3, 1)}
+	tests := []struct {
+		name   string
+		endsOn pgtype.Date
+		want   error
+	}{
+		{"на следующий день", testDate(2024, 3, 2), nil},
+		{"в день начала", testDate(2024, 3, 1), InvalidAssignmentDateError},
+		{"раньше начала", testDate(2024, 2, 1), InvalidAssignmentDateError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkAssignmentEnd(current, test.endsOn); !errors.Is(err, test.want) {
+				t.Errorf("checkAssignmentEnd = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestToEmployeeStoreDto(t *testing.T) {
+	if got := toEmployeeStoreDto(pgtype.Int4{}, pgtype.Text{}, pgtype.Date{}); got != nil {
+		t.Errorf("toEmployeeStoreDto без магазина = %+v, want nil", got)
+	}
+	got := toEmployeeStoreDto(pgtype.Int4{Int32: 2, Valid: true}, pgtype.Text{String: "ул. Ленина, 1", Valid: true}, testDate(2024, 3, 1))
+	want := EmployeeStoreDto{StoreId: 2, Address: "ул. Ленина, 1", Since: "2024-03-01"}
+	if got == nil || *got != want {
+		t.Errorf("toEmployeeStoreDto = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,678 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Cookie, по которой 1С узнаёт сессию после checkauth
+	ExchangeCookieName = "exchange_session"
+	// Наибольший кусок файла, который 1С отправляет одним запросом mode=file
+	ExchangeFileLimit = 100 << 20
+	// Сессия живёт час после последнего запроса
+	exchangeSessionTTL = time.Hour
+	// Сколько заказов отдаётся в 1С за один обмен, остальные уйдут в следующий
+	exchangeOrdersLimit = 500
+	// Версия схемы CommerceML в выгрузке заказов
+	commerceMLVersion = "2.05"
+)
+
+// ExchangeImportResultDto — итог загрузки одного файла из 1С
+type ExchangeImportResultDto struct {
+	Categories int `json:"categories"`
+	Created    int `json:"created"`
+	Updated    int `json:"updated"`
+	Deleted    int `json:"deleted"`
+	Prices     int `json:"prices"`
+	// Предложения по товарам, которых нет в каталоге
+	Skipped int `json:"skipped"`
+}
+
+type ExchangeInterface interface {
+	CheckAuth(ctx context.Context, login string, password string) (string, error)
+	Init(token string) error
+	SaveFile(token string, filename string, body io.Reader) error
+	ImportFile(ctx context.Context, token string, filename string) (ExchangeImportResultDto, error)
+	QueryOrders(ctx context.Context, token string, w io.Writer) error
+	ConfirmOrders(ctx context.Context, token string) error
+}
+
+// ExchangeService реализует обмен с 1С по протоколу CommerceML 2: 1С авторизуется, загружает
+// import.xml и offers.xml с каталогом и ценами и забирает оплаченные заказы
+type ExchangeService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+	// Каталог, в который складываются файлы от 1С, по подкаталогу на сессию
+	Dir string
+
+	mu       sync.Mutex
+	sessions map[string]*exchangeSession
+}
+
+type exchangeSession struct {
+	employeeId int32
+	expiresAt  time.Time
+	// Заказы, отданные в последнем mode=query и ещё не подтверждённые 1С
+	orderIds []int32
+}
+
+var ExchangeAuthError = errors.New("invalid login or password")
+var ExchangeSessionError = errors.New("exchange session is missing or expired")
+var InvalidExchangeFileError = errors.New("invalid exchange file")
+
+func NewExchangeService(queries gen.Queries, db *pgxpool.Pool, dir string) *ExchangeService {
+	return &ExchangeService{Queries: queries, DB: db, Dir: dir, sessions: map[string]*exchangeSession{}}
+}
+
+// CheckAuth проверяет логин и пароль сотрудника с правом обмена и открывает сессию
+func (s *ExchangeService) CheckAuth(ctx context.Context, login string, password string) (string, error) {
+	employeeId, err := s.Queries.GetEmployeeByCredentials(ctx, gen.GetEmployeeByCredentialsParams{Login: login, Password: password})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ExchangeAuthError
+		}
+		return "", err
+	}
+	if err := checkPermission(ctx, &s.Queries, employeeId, PermissionExchange); err != nil {
+		return "", err
+	}
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for key, session := range s.sessions {
+		if now.After(session.expiresAt) {
+			delete(s.sessions, key)
+			os.RemoveAll(filepath.Join(s.Dir, key))
+		}
+	}
+	s.sessions[token] = &exchangeSession{employeeId: employeeId, expiresAt: now.Add(exchangeSessionTTL)}
+	return token, nil
+}
+
+// session находит сессию по cookie и продлевает её
+func (s *ExchangeService) session(token string) (*exchangeSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[token]
+	if !ok || time.Now().After(session.expiresAt) {
+		return nil, ExchangeSessionError
+	}
+	session.expiresAt = time.Now().Add(exchangeSessionTTL)
+	return session, nil
+}
+
+// Init начинает новый обмен: файлы, оставшиеся от прошлого обмена этой сессии, удаляются
+func (s *ExchangeService) Init(token string) error {
+	if _, err := s.session(token); err != nil {
+		return err
+	}
+	dir := filepath.Join(s.Dir, token)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, 0o750)
+}
+
+// exchangePath возвращает путь файла внутри каталога сессии. 1С присылает и вложенные пути
+// вроде import_files/ab/abcd.jpg, но выйти за пределы каталога сессии имя файла не может
+func (s *ExchangeService) exchangePath(token string, filename string) (string, error) {
+	dir := filepath.Join(s.Dir, token)
+	path := filepath.Join(dir, filepath.FromSlash(filename))
+	relative, err := filepath.Rel(dir, path)
+	if filename == "" || err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+		return "", fmt.Errorf("%w: bad filename %q", InvalidExchangeFileError, filename)
+	}
+	return path, nil
+}
+
+// SaveFile дописывает кусок файла: большие файлы 1С присылает несколькими запросами подряд.
+// Кусок больше ExchangeFileLimit отклоняется, а не обрезается: обрезанный XML загрузился бы не полностью
+func (s *ExchangeService) SaveFile(token string, filename string, body io.Reader) error {
+	if _, err := s.session(token); err != nil {
+		return err
+	}
+	path, err := s.exchangePath(token, filename)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err := copyExchangeChunk(file, body, ExchangeFileLimit); err != nil {
+		// Отклонённый кусок не оставляется в файле, иначе следующий кусок допишется после него
+		file.Truncate(info.Size())
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// copyExchangeChunk копирует кусок файла и возвращает ошибку, если он длиннее limit.
+// Читается limit+1 байт, чтобы отличить кусок ровно в limit от слишком длинного
+func copyExchangeChunk(dst io.Writer, src io.Reader, limit int64) error {
+	written, err := io.Copy(dst, io.LimitReader(src, limit+1))
+	if err != nil {
+		return err
+	}
+	if written > limit {
+		return fmt.Errorf("%w: chunk is larger than %d bytes", InvalidExchangeFileError, limit)
+	}
+	return nil
+}
+
+type cmlGroup struct {
+	Id     string     `xml:"Ид"`
+	Name   string     `xml:"Наименование"`
+	Groups []cmlGroup `xml:"Группы>Группа"`
+}
+
+type cmlClassifier struct {
+	Groups []cmlGroup `xml:"Группы>Группа"`
+}
+
+type cmlProduct struct {
+	Id           string   `xml:"Ид"`
+	Article      string   `xml:"Артикул"`
+	Name         string   `xml:"Наименование"`
+	Groups       []string `xml:"Группы>Ид"`
+	Manufacturer string   `xml:"Изготовитель>Наименование"`
+	// Удалённые в 1С товары приходят со статусом «Удален»
+	Status string `xml:"Статус,attr"`
+}
+
+type cmlPriceType struct {
+	Id   string `xml:"Ид"`
+	Name string `xml:"Наименование"`
+}
+
+type cmlOffer struct {
+	Id      string `xml:"Ид"`
+	Article string `xml:"Артикул"`
+	Prices  []struct {
+		PriceTypeId string `xml:"ИдТипаЦены"`
+		Value       string `xml:"ЦенаЗаЕдиницу"`
+	} `xml:"Цены>Цена"`
+}
+
+// exchangeQueries — запросы, которыми загрузка каталога читает и меняет товары и категории
+type exchangeQueries interface {
+	ListExchangeCategories(ctx context.Context) ([]gen.ListExchangeCategoriesRow, error)
+	ListExchangeGoods(ctx context.Context) ([]gen.ListExchangeGoodsRow, error)
+	CreateExchangeCategory(ctx context.Context, arg gen.CreateExchangeCategoryParams) (int32, error)
+	UpdateExchangeCategory(ctx context.Context, arg gen.UpdateExchangeCategoryParams) error
+	CreateExchangeGood(ctx context.Context, arg gen.CreateExchangeGoodParams) (int32, error)
+	UpdateExchangeGood(ctx context.Context, arg gen.UpdateExchangeGoodParams) error
+	UpdateGoodPrice(ctx context.Context, arg gen.UpdateGoodPriceParams) error
+	DeleteGood(ctx context.Context, id int32) error
+}
+
+// exchangeCatalog — товары и категории магазина, сопоставленные с идентификаторами 1С
+type exchangeCatalog struct {
+	q exchangeQueries
+	// Ид 1С → id записи и, для записей, которые ещё не обменивались, название или артикул → id
+	categories    map[string]int32
+	categoryNames map[string]int32
+	goods         map[string]int32
+	goodArticles  map[string]int32
+	priceTypeId   string
+	result        ExchangeImportResultDto
+}
+
+func loadExchangeCatalog(ctx context.Context, q exchangeQueries) (*exchangeCatalog, error) {
+	catalog := &exchangeCatalog{
+		q:             q,
+		categories:    map[string]int32{},
+		categoryNames: map[string]int32{},
+		goods:         map[string]int32{},
+		goodArticles:  map[string]int32{},
+	}
+	categories, err := q.ListExchangeCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if category.ExternalID.Valid {
+			catalog.categories[category.ExternalID.String] = category.ID
+		} else if _, ok := catalog.categoryNames[category.Name]; !ok {
+			catalog.categoryNames[category.Name] = category.ID
+		}
+	}
+	goods, err := q.ListExchangeGoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, good := range goods {
+		if good.ExternalID.Valid {
+			catalog.goods[good.ExternalID.String] = good.ID
+		} else if _, ok := catalog.goodArticles[good.Article]; !ok {
+			catalog.goodArticles[good.Article] = good.ID
+		}
+	}
+	return catalog, nil
+}
+
+// importGroups сохраняет группы классификатора как категории. Вложенность групп не сохраняется:
+// категории в магазине плоские
+func (c *exchangeCatalog) importGroups(ctx context.Context, groups []cmlGroup) error {
+	for _, group := range groups {
+		externalId := pgtype.Text{String: group.Id, Valid: true}
+		id, ok := c.categories[group.Id]
+		if !ok {
+			id, ok = c.categoryNames[group.Name]
+			delete(c.categoryNames, group.Name)
+		}
+		if ok {
+			if err := c.q.UpdateExchangeCategory(ctx, gen.UpdateExchangeCategoryParams{ID: id, Name: group.Name, ExternalID: externalId}); err != nil {
+				return err
+			}
+		} else {
+			var err error
+			id, err = c.q.CreateExchangeCategory(ctx, gen.CreateExchangeCategoryParams{
+				Name:       group.Name,
+				CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+				IsAlive:    true,
+				ExternalID: externalId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		c.categories[group.Id] = id
+		c.result.Categories++
+		if err := c.importGroups(ctx, group.Groups); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importProduct создаёт или обновляет товар. Новый товар получает цену из offers.xml, а остаток — приёмкой
+func (c *exchangeCatalog) importProduct(ctx context.Context, product cmlProduct) error {
+	if product.Id == "" {
+		return fmt.Errorf("%w: product without Ид", InvalidExchangeFileError)
+	}
+	id, ok := c.goods[product.Id]
+	if !ok && product.Article != "" {
+		id, ok = c.goodArticles[product.Article]
+		delete(c.goodArticles, product.Article)
+	}
+	if product.Status == "Удален" {
+		if ok {
+			c.result.Deleted++
+			delete(c.goods, product.Id)
+			return c.q.DeleteGood(ctx, id)
+		}
+		return nil
+	}
+	article := product.Article
+	if article == "" {
+		article = product.Id
+	}
+	var categoryId pgtype.Int4
+	if len(product.Groups) > 0 {
+		if category, found := c.categories[product.Groups[0]]; found {
+			categoryId = pgtype.Int4{Int32: category, Valid: true}
+		}
+	}
+	brand := pgtype.Text{String: product.Manufacturer, Valid: product.Manufacturer != ""}
+	externalId := pgtype.Text{String: product.Id, Valid: true}
+	if ok {
+		c.result.Updated++
+		c.goods[product.Id] = id
+		return c.q.UpdateExchangeGood(ctx, gen.UpdateExchangeGoodParams{
+			ID:         id,
+			Article:    article,
+			Name:       product.Name,
+			CategoryID: categoryId,
+			Brand:      brand,
+			ExternalID: externalId,
+		})
+	}
+	id, err := c.q.CreateExchangeGood(ctx, gen.CreateExchangeGoodParams{
+		Article:    article,
+		Price:      toNumeric(0),
+		Name:       product.Name,
+		IsAlive:    true,
+		CategoryID: categoryId,
+		Brand:      brand,
+		ExternalID: externalId,
+	})
+	if err != nil {
+		return err
+	}
+	c.result.Created++
+	c.goods[product.Id] = id
+	return nil
+}
+
+// importOffer записывает цену первого типа цен пакета предложений
+func (c *exchangeCatalog) importOffer(ctx context.Context, offer cmlOffer) error {
+	// Ид предложения по характеристике товара — «Ид товара#Ид характеристики»
+	productId, _, _ := strings.Cut(offer.Id, "#")
+	id, ok := c.goods[productId]
+	if !ok && offer.Article != "" {
+		id, ok = c.goodArticles[offer.Article]
+	}
+	if !ok {
+		c.result.Skipped++
+		return nil
+	}
+	for _, price := range offer.Prices {
+		if c.priceTypeId != "" && price.PriceTypeId != c.priceTypeId {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(price.Value), ",", "."), 64)
+		if err != nil || value < 0 {
+			return fmt.Errorf("%w: bad price %q of offer %s", InvalidExchangeFileError, price.Value, offer.Id)
+		}
+		c.result.Prices++
+		return c.q.UpdateGoodPrice(ctx, gen.UpdateGoodPriceParams{ID: id, Price: toNumeric(int64(math.Round(value)))})
+	}
+	return nil
+}
+
+// importXML читает import.xml или offers.xml потоком по элементам и загружает группы, товары и цены
+func (c *exchangeCatalog) importXML(ctx context.Context, r io.Reader) error {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", InvalidExchangeFileError, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "Классификатор":
+			var classifier cmlClassifier
+			if err := decoder.DecodeElement(&classifier, &start); err != nil {
+				return fmt.Errorf("%w: %v", InvalidExchangeFileError, err)
+			}
+			err = c.importGroups(ctx, classifier.Groups)
+		case "Товар":
+			var product cmlProduct
+			if err := decoder.DecodeElement(&product, &start); err != nil {
+				return fmt.Errorf("%w: %v", InvalidExchangeFileError, err)
+			}
+			err = c.importProduct(ctx, product)
+		case "ТипЦены":
+			var priceType cmlPriceType
+			if err := decoder.DecodeElement(&priceType, &start); err != nil {
+				return fmt.Errorf("%w: %v", InvalidExchangeFileError, err)
+			}
+			// Магазин продаёт по одной цене — берётся первый тип цен пакета
+			if c.priceTypeId == "" {
+				c.priceTypeId = priceType.Id
+			}
+		case "Предложение":
+			var offer cmlOffer
+			if err := decoder.DecodeElement(&offer, &start); err != nil {
+				return fmt.Errorf("%w: %v", InvalidExchangeFileError, err)
+			}
+			err = c.importOffer(ctx, offer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportFile загружает ранее переданный файл каталога или пакета предложений. Файл читается потоком
+// по элементам, так что размер каталога ограничен только диском. Остатки из offers.xml не загружаются:
+// остаток меняется только приёмкой и инвентаризацией
+func (s *ExchangeService) ImportFile(ctx context.Context, token string, filename string) (ExchangeImportResultDto, error) {
+	if _, err := s.session(token); err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	path, err := s.exchangePath(token, filename)
+	if err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ExchangeImportResultDto{}, fmt.Errorf("%w: %s was not uploaded", InvalidExchangeFileError, filename)
+		}
+		return ExchangeImportResultDto{}, err
+	}
+	defer file.Close()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	catalog, err := loadExchangeCatalog(ctx, s.Queries.WithTx(tx))
+	if err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	if err := catalog.importXML(ctx, file); err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ExchangeImportResultDto{}, err
+	}
+	return catalog.result, nil
+}
+
+type cmlCommercialInformation struct {
+	XMLName       xml.Name      `xml:"КоммерческаяИнформация"`
+	SchemaVersion string        `xml:"ВерсияСхемы,attr"`
+	CreatedAt     string        `xml:"ДатаФормирования,attr"`
+	Documents     []cmlDocument `xml:"Документ"`
+}
+
+type cmlRequisite struct {
+	Name  string `xml:"Наименование"`
+	Value string `xml:"Значение"`
+}
+
+type cmlCounterparty struct {
+	Id       string       `xml:"Ид"`
+	Name     string       `xml:"Наименование"`
+	FullName string       `xml:"ПолноеНаименование"`
+	Role     string       `xml:"Роль"`
+	Contacts []cmlContact `xml:"Контакты>Контакт"`
+}
+
+type cmlContact struct {
+	Type  string `xml:"Тип"`
+	Value string `xml:"Значение"`
+}
+
+type cmlUnit struct {
+	Code     string `xml:"Код,attr"`
+	FullName string `xml:"НаименованиеПолное,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type cmlOrderLine struct {
+	Id         string         `xml:"Ид"`
+	Article    string         `xml:"Артикул,omitempty"`
+	Name       string         `xml:"Наименование"`
+	Unit       cmlUnit        `xml:"БазоваяЕдиница"`
+	Price      int64          `xml:"ЦенаЗаЕдиницу"`
+	Quantity   int32          `xml:"Количество"`
+	Sum        int64          `xml:"Сумма"`
+	Requisites []cmlRequisite `xml:"ЗначенияРеквизитов>ЗначениеРеквизита"`
+}
+
+type cmlDocument struct {
+	Id             string            `xml:"Ид"`
+	Number         string            `xml:"Номер"`
+	Date           string            `xml:"Дата"`
+	Time           string            `xml:"Время"`
+	Operation      string            `xml:"ХозОперация"`
+	Role           string            `xml:"Роль"`
+	Currency       string            `xml:"Валюта"`
+	Rate           int               `xml:"Курс"`
+	Sum            int64             `xml:"Сумма"`
+	Counterparties []cmlCounterparty `xml:"Контрагенты>Контрагент"`
+	Lines          []cmlOrderLine    `xml:"Товары>Товар"`
+	Requisites     []cmlRequisite    `xml:"ЗначенияРеквизитов>ЗначениеРеквизита"`
+}
+
+// cmlLine — строка заказа с видом номенклатуры, по которому 1С отличает товары от услуг
+func cmlLine(id string, article string, name string, price int64, quantity int32, kind string) cmlOrderLine {
+	return cmlOrderLine{
+		Id:       id,
+		Article:  article,
+		Name:     name,
+		Unit:     cmlUnit{Code: "796", FullName: "Штука", Name: "шт"},
+		Price:    price,
+		Quantity: quantity,
+		Sum:      price * int64(quantity),
+		Requisites: []cmlRequisite{
+			{Name: "ВидНоменклатуры", Value: kind},
+			{Name: "ТипНоменклатуры", Value: kind},
+		},
+	}
+}
+
+func (s *ExchangeService) orderDocument(ctx context.Context, order gen.Order) (cmlDocument, error) {
+	document := cmlDocument{
+		Id:        strconv.Itoa(int(order.ID)),
+		Number:    strconv.Itoa(int(order.ID)),
+		Date:      order.CreatedAt.Time.Format(DateLayout),
+		Time:      order.CreatedAt.Time.Format(time.TimeOnly),
+		Operation: "Заказ товара",
+		Role:      "Продавец",
+		Currency:  "руб",
+		Rate:      1,
+		Sum:       fromNumeric(order.Total),
+		Requisites: []cmlRequisite{
+			{Name: "Статус заказа", Value: order.Status},
+			{Name: "Магазин", Value: strconv.Itoa(int(order.StoreID))},
+		},
+	}
+	counterparty := cmlCounterparty{Id: "retail", Name: "Розничный покупатель", FullName: "Розничный покупатель", Role: "Покупатель"}
+	if order.CustomerID.Valid {
+		customer, err := s.Queries.GetCustomer(ctx, order.CustomerID.Int32)
+		if err != nil {
+			return cmlDocument{}, err
+		}
+		counterparty.Id = strconv.Itoa(int(customer.ID))
+		counterparty.Name, counterparty.FullName = customer.AccountLogin, customer.AccountLogin
+		if customer.Phone.Valid {
+			counterparty.Contacts = []cmlContact{{Type: "Телефон рабочий", Value: customer.Phone.String}}
+		}
+		if customer.Email.Valid {
+			counterparty.Contacts = append(counterparty.Contacts, cmlContact{Type: "Почта", Value: customer.Email.String})
+		}
+	}
+	document.Counterparties = []cmlCounterparty{counterparty}
+
+	items, err := s.Queries.ListExchangeOrderItems(ctx, order.ID)
+	if err != nil {
+		return cmlDocument{}, err
+	}
+	for _, item := range items {
+		// Товары, пришедшие из 1С, узнаются по её Ид, остальные — по id магазина
+		id := strconv.Itoa(int(item.GoodID))
+		if item.ExternalID.Valid {
+			id = item.ExternalID.String
+		}
+		document.Lines = append(document.Lines, cmlLine(id, item.Article, item.Name, fromNumeric(item.Price), item.Quantity, "Товар"))
+	}
+	services, err := s.Queries.ListExchangeOrderServices(ctx, order.ID)
+	if err != nil {
+		return cmlDocument{}, err
+	}
+	for _, service := range services {
+		id := "service-" + strconv.Itoa(int(service.ServiceID))
+		document.Lines = append(document.Lines, cmlLine(id, "", service.Name, fromNumeric(service.Price), service.Quantity, "Услуга"))
+	}
+	return document, nil
+}
+
+// QueryOrders пишет в w оплаченные заказы, которые 1С ещё не приняла. Заказы помечаются
+// переданными только после ConfirmOrders, поэтому при сбое обмена они уйдут повторно
+func (s *ExchangeService) QueryOrders(ctx context.Context, token string, w io.Writer) error {
+	session, err := s.session(token)
+	if err != nil {
+		return err
+	}
+	orders, err := s.Queries.ListExchangeOrders(ctx, gen.ListExchangeOrdersParams{Status: OrderStatusPaid, Limit: exchangeOrdersLimit})
+	if err != nil {
+		return err
+	}
+	information := cmlCommercialInformation{
+		SchemaVersion: commerceMLVersion,
+		CreatedAt:     time.Now().Format("2006-01-02T15:04:05"),
+		Documents:     make([]cmlDocument, len(orders)),
+	}
+	orderIds := make([]int32, len(orders))
+	for i, order := range orders {
+		if information.Documents[i], err = s.orderDocument(ctx, order); err != nil {
+			return err
+		}
+		orderIds[i] = order.ID
+	}
+
+	s.mu.Lock()
+	session.orderIds = orderIds
+	s.mu.Unlock()
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	return encoder.Encode(information)
+}
+
+// ConfirmOrders помечает заказы из последнего QueryOrders принятыми 1С
+func (s *ExchangeService) ConfirmOrders(ctx context.Context, token string) error {
+	session, err := s.session(token)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	orderIds := session.orderIds
+	session.orderIds = nil
+	s.mu.Unlock()
+	if len(orderIds) == 0 {
+		return nil
+	}
+	return s.Queries.MarkOrdersExchanged(ctx, gen.MarkOrdersExchangedParams{
+		Ids:         orderIds,
+		ExchangedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+}
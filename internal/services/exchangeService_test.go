@@ -0,0 +1,286 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// exchangeStore — каталог в памяти вместо базы для проверки загрузки файлов 1С
+type exchangeStore struct {
+	categories []gen.ListExchangeCategoriesRow
+	goods      []*exchangeStoreGood
+}
+
+type exchangeStoreGood struct {
+	id         int32
+	article    string
+	name       string
+	externalId string
+	categoryId int32
+	brand      string
+	price      int64
+	alive      bool
+}
+
+func (s *exchangeStore) good(id int32) *exchangeStoreGood {
+	for _, good := range s.goods {
+		if good.id == id {
+			return good
+		}
+	}
+	return nil
+}
+
+func (s *exchangeStore) ListExchangeCategories(ctx context.Context) ([]gen.ListExchangeCategoriesRow, error) {
+	return s.categories, nil
+}
+
+func (s *exchangeStore) ListExchangeGoods(ctx context.Context) ([]gen.ListExchangeGoodsRow, error) {
+	var rows []gen.ListExchangeGoodsRow
+	for _, good := range s.goods {
+		if good.alive {
+			row := gen.ListExchangeGoodsRow{ID: good.id, Article: good.article}
+			row.ExternalID.String, row.ExternalID.Valid = good.externalId, good.externalId != ""
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *exchangeStore) CreateExchangeCategory(ctx context.Context, arg gen.CreateExchangeCategoryParams) (int32, error) {
+	id := int32(len(s.categories) + 1)
+	s.categories = append(s.categories, gen.ListExchangeCategoriesRow{ID: id, Name: arg.Name, ExternalID: arg.ExternalID})
+	return id, nil
+}
+
+func (s *exchangeStore) UpdateExchangeCategory(ctx context.Context, arg gen.UpdateExchangeCategoryParams) error {
+	s.categories[arg.ID-1] = gen.ListExchangeCategoriesRow{ID: arg.ID, Name: arg.Name, ExternalID: arg.ExternalID}
+	return nil
+}
+
+func (s *exchangeStore) CreateExchangeGood(ctx context.Context, arg gen.CreateExchangeGoodParams) (int32, error) {
+	id := int32(len(s.goods) + 1)
+	s.goods = append(s.goods, &exchangeStoreGood{
+		id:         id,
+		article:    arg.Article,
+		name:       arg.Name,
+		externalId: arg.ExternalID.String,
+		categoryId: arg.CategoryID.Int32,
+		brand:      arg.Brand.String,
+		price:      fromNumeric(arg.Price),
+		alive:      arg.IsAlive,
+	})
+	return id, nil
+}
+
+func (s *exchangeStore) UpdateExchangeGood(ctx context.Context, arg gen.UpdateExchangeGoodParams) error {
+	good := s.good(arg.ID)
+	good.article, good.name, good.externalId = arg.Article, arg.Name, arg.ExternalID.String
+	good.categoryId, good.brand = arg.CategoryID.Int32, arg.Brand.String
+	return nil
+}
+
+func (s *exchangeStore) UpdateGoodPrice(ctx context.Context, arg gen.UpdateGoodPriceParams) error {
+	s.good(arg.ID).price = fromNumeric(arg.Price)
+	return nil
+}
+
+func (s *exchangeStore) DeleteGood(ctx context.Context, id int32) error {
+	s.good(id).alive = false
+	return nil
+}
+
+// importExchangeFile повторяет ImportFile без транзакции: каталог читается заново для каждого файла
+func importExchangeFile(t *testing.T, store *exchangeStore, name string) ExchangeImportResultDto {
+	t.Helper()
+	file, err := os.Open(filepath.Join("..", "..", "testdata", "commerceml", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	catalog, err := loadExchangeCatalog(context.Background(), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.importXML(context.Background(), file); err != nil {
+		t.Fatal(err)
+	}
+	return catalog.result
+}
+
+func TestExchangeImportCommerceML(t *testing.T) {
+	store := &exchangeStore{
+		categories: []gen.ListExchangeCategoriesRow{{ID: 1, Name: "Холодильники"}},
+		goods: []*exchangeStoreGood{
+			{id: 1, article: "RF-4020", name: "Холодильник", price: 40000, alive: true},
+			{id: 2, article: "MW-2000", name: "Микроволновка", price: 9000, alive: true},
+		},
+	}
+
+	result := importExchangeFile(t, store, "import.xml")
+	want := ExchangeImportResultDto{Categories: 4, Created: 2, Updated: 1, Deleted: 1}
+	if result != want {
+		t.Errorf("import.xml: result = %+v, want %+v", result, want)
+	}
+	if len(store.categories) != 4 || store.categories[0].ExternalID.String != "c2a1f0e4-0002-11ef-8000-000000000002" {
+		t.Errorf("категория без Ид не сопоставлена по названию: %+v", store.categories)
+	}
+	fridge := store.good(1)
+	if fridge.externalId != "7d3e5b10-0001-11ef-8000-000000000101" || fridge.name != "Холодильник двухкамерный 185 см" ||
+		fridge.categoryId != 1 || fridge.brand != "Polair" {
+		t.Errorf("товар без Ид не сопоставлен по артикулу: %+v", *fridge)
+	}
+	if store.good(2).alive {
+		t.Error("удалённый в 1С товар остался в каталоге")
+	}
+	if kettle := store.good(4); kettle == nil || kettle.article != "7d3e5b10-0003-11ef-8000-000000000103" || kettle.price != 0 {
+		t.Errorf("товар без артикула: %+v", kettle)
+	}
+
+	result = importExchangeFile(t, store, "offers.xml")
+	want = ExchangeImportResultDto{Prices: 3}
+	if result != want {
+		t.Errorf("offers.xml: result = %+v, want %+v", result, want)
+	}
+	// Берётся первый тип цен пакета (розничная), цена округляется до рубля
+	prices := map[int32]int64{1: 45990, 3: 32491, 4: 2490}
+	for id, price := range prices {
+		if got := store.good(id).price; got != price {
+			t.Errorf("цена товара %d = %d, want %d", id, got, price)
+		}
+	}
+}
+
+func TestExchangeImportOffers(t *testing.T) {
+	offers := func(offer string) string {
+		return `<КоммерческаяИнформация><ПакетПредложений><ТипыЦен><ТипЦены><Ид>retail</Ид></ТипЦены></ТипыЦен>` +
+			`<Предложения><Предложение>` + offer + `</Предложение></Предложения></ПакетПредложений></КоммерческаяИнформация>`
+	}
+	tests := []struct {
+		name    string
+		xml     string
+		want    ExchangeImportResultDto
+		price   int64
+		wantErr bool
+	}{
+		{
+			name:  "по артикулу товара без Ид",
+			xml:   offers(`<Ид>unknown</Ид><Артикул>A-1</Артикул><Цены><Цена><ИдТипаЦены>retail</ИдТипаЦены><ЦенаЗаЕдиницу>99.4</ЦенаЗаЕдиницу></Цена></Цены>`),
+			want:  ExchangeImportResultDto{Prices: 1},
+			price: 99,
+		},
+		{
+			name:  "товара нет в каталоге",
+			xml:   offers(`<Ид>unknown</Ид><Артикул>B-2</Артикул><Цены><Цена><ИдТипаЦены>retail</ИдТипаЦены><ЦенаЗаЕдиницу>10</ЦенаЗаЕдиницу></Цена></Цены>`),
+			want:  ExchangeImportResultDto{Skipped: 1},
+			price: 500,
+		},
+		{
+			name:  "нет цены нужного типа",
+			xml:   offers(`<Ид>unknown</Ид><Артикул>A-1</Артикул><Цены><Цена><ИдТипаЦены>wholesale</ИдТипаЦены><ЦенаЗаЕдиницу>10</ЦенаЗаЕдиницу></Цена></Цены>`),
+			price: 500,
+		},
+		{
+			name:    "неверная цена",
+			xml:     offers(`<Ид>unknown</Ид><Артикул>A-1</Артикул><Цены><Цена><ИдТипаЦены>retail</ИдТипаЦены><ЦенаЗаЕдиницу>-1</ЦенаЗаЕдиницу></Цена></Цены>`),
+			wantErr: true,
+		},
+		{
+			name:    "битый XML",
+			xml:     `<КоммерческаяИнформация><ПакетПредложений>`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &exchangeStore{goods: []*exchangeStoreGood{{id: 1, article: "A-1", price: 500, alive: true}}}
+			catalog, err := loadExchangeCatalog(context.Background(), store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = catalog.importXML(context.Background(), strings.NewReader(test.xml))
+			if test.wantErr {
+				if !errors.Is(err, InvalidExchangeFileError) {
+					t.Fatalf("err = %v, want InvalidExchangeFileError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if catalog.result != test.want {
+				t.Errorf("result = %+v, want %+v", catalog.result, test.want)
+			}
+			if got := store.good(1).price; got != test.price {
+				t.Errorf("price = %d, want %d", got, test.price)
+			}
+		})
+	}
+}
+
+func TestExchangePath(t *testing.T) {
+	service := &ExchangeService{Dir: "exchange"}
+	dir := filepath.Join("exchange", "token")
+	tests := []struct {
+		name     string
+		filename string
+		want     string
+	}{
+		{"файл каталога", "import.xml", filepath.Join(dir, "import.xml")},
+		{"вложенный путь картинки", "import_files/ab/abcd.jpg", filepath.Join(dir, "import_files", "ab", "abcd.jpg")},
+		{"абсолютный путь остаётся внутри сессии", "/etc/passwd", filepath.Join(dir, "etc", "passwd")},
+		{"лишние сегменты внутри сессии", "import_files/../offers.xml", filepath.Join(dir, "offers.xml")},
+		{"пустое имя", "", ""},
+		{"каталог сессии", ".", ""},
+		{"выход из каталога сессии", "../other/import.xml", ""},
+		{"выход через вложенный путь", "import_files/../../../etc/passwd", ""},
+		{"выход в корень каталога обмена", "..", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := service.exchangePath("token", test.filename)
+			if test.want == "" {
+				if !errors.Is(err, InvalidExchangeFileError) {
+					t.Fatalf("exchangePath = %q, %v, want InvalidExchangeFileError", got, err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("exchangePath = %q, %v, want %q", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestCopyExchangeChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		chunk   string
+		wantErr bool
+	}{
+		{"меньше предела", "abc", false},
+		{"ровно предел", "abcd", false},
+		{"больше предела", "abcde", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var dst bytes.Buffer
+			err := copyExchangeChunk(&dst, strings.NewReader(test.chunk), 4)
+			if test.wantErr {
+				if !errors.Is(err, InvalidExchangeFileError) {
+					t.Fatalf("err = %v, want InvalidExchangeFileError", err)
+				}
+				return
+			}
+			if err != nil || dst.String() != test.chunk {
+				t.Errorf("copied %q, %v, want %q", dst.String(), err, test.chunk)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatXLSX  = "xlsx"
+	ExportFormatJSONL = "jsonl"
+)
+
+// ExportContentTypes — Content-Type ответа для каждого формата выгрузки
+var ExportContentTypes = map[string]string{
+	ExportFormatCSV:   "text/csv; charset=utf-8",
+	ExportFormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportFormatJSONL: "application/x-ndjson",
+}
+
+// Строк, читаемых из базы за один запрос
+const exportPageSize = 1000
+
+type ExportInterface interface {
+	Export(ctx context.Context, entity string, format string, w io.Writer) error
+}
+
+type ExportService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var InvalidExportEntityError = errors.New("entity cannot be exported")
+var InvalidExportFormatError = errors.New("format must be csv, xlsx or jsonl")
+
+// ExportInterruptedError — ошибка после того, как часть выгрузки уже ушла клиенту
+var ExportInterruptedError = errors.New("export interrupted")
+
+// exportEntity описывает колонки выгрузки и чтение страницы строк после строки с id = after.
+// Страница возвращает значения строк и id последней строки
+type exportEntity struct {
+	columns []string
+	page    func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error)
+}
+
+func exportTimestamp(value pgtype.Timestamp) any {
+	if !value.Valid {
+		return nil
+	}
+	return value.Time
+}
+
+func exportText(value pgtype.Text) any {
+	if !value.Valid {
+		return nil
+	}
+	return value.String
+}
+
+func exportInt4(value pgtype.Int4) any {
+	if !value.Valid {
+		return nil
+	}
+	return value.Int32
+}
+
+func exportNumeric(value pgtype.Numeric) any {
+	if !value.Valid {
+		return nil
+	}
+	return fromNumeric(value)
+}
+
+// exportEntities — сущности, которые можно выгрузить. В выгрузку попадают те же записи, что и в списки
+var exportEntities = map[string]exportEntity{
+	"goods": {
+		columns: []string{"id", "article", "name", "price", "quantity", "category_id", "tax_rate_id", "cost", "brand"},
+		page: func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+			goods, err := q.ExportGoods(ctx, gen.ExportGoodsParams{ID: after, Limit: exportPageSize})
+			if err != nil || len(goods) == 0 {
+				return nil, after, err
+			}
+			rows := make([][]any, len(goods))
+			for i, good := range goods {
+				rows[i] = []any{good.ID, good.Article, good.Name, fromNumeric(good.Price), good.Quantity,
+					exportInt4(good.CategoryID), exportInt4(good.TaxRateID), exportNumeric(good.Cost), exportText(good.Brand)}
+			}
+			return rows, goods[len(goods)-1].ID, nil
+		},
+	},
+	"customers": {
+		columns: []string{"id", "account_id", "login", "balance", "phone", "email", "created_at"},
+		page: func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+			customers, err := q.ExportCustomers(ctx, gen.ExportCustomersParams{ID: after, Limit: exportPageSize})
+			if err != nil || len(customers) == 0 {
+				return nil, after, err
+			}
+			rows := make([][]any, len(customers))
+			for i, customer := range customers {
+				rows[i] = []any{customer.ID, customer.AccountID, customer.AccountLogin, fromNumeric(customer.Balance),
+					exportText(customer.Phone), exportText(customer.Email), exportTimestamp(customer.CreatedAt)}
+			}
+			return rows, customers[len(customers)-1].ID, nil
+		},
+	},
+	"suppliers": {
+		columns: []string{"id", "account_id", "login", "created_at"},
+		page: func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+			suppliers, err := q.ExportSuppliers(ctx, gen.ExportSuppliersParams{ID: after, Limit: exportPageSize})
+			if err != nil || len(suppliers) == 0 {
+				return nil, after, err
+			}
+			rows := make([][]any, len(suppliers))
+			for i, supplier := range suppliers {
+				rows[i] = []any{supplier.ID, supplier.AccountID, supplier.AccountLogin, exportTimestamp(supplier.CreatedAt)}
+			}
+			return rows, suppliers[len(suppliers)-1].ID, nil
+		},
+	},
+	"stores": {
+		columns: []string{"id", "address", "created_at", "updated_at"},
+		page: func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+			stores, err := q.ExportStores(ctx, gen.ExportStoresParams{ID: after, Limit: exportPageSize})
+			if err != nil || len(stores) == 0 {
+				return nil, after, err
+			}
+			rows := make([][]any, len(stores))
+			for i, store := range stores {
+				rows[i] = []any{store.ID, store.Address, exportTimestamp(store.CreatedAt), exportTimestamp(store.UpdatedAt)}
+			}
+			return rows, stores[len(stores)-1].ID, nil
+		},
+	},
+}
+
+// exportWriter пишет строки выгрузки в одном из форматов
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+// exportCell переводит значение в текст ячейки: пустая строка для null, время без часового пояса
+func exportCell(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int32:
+		return strconv.Itoa(int(value))
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case time.Time:
+		return value.Format(time.DateTime)
+	}
+	return fmt.Sprint(value)
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (e *csvExportWriter) WriteHeader(columns []string) error {
+	e.record = make([]string, len(columns))
+	return e.writer.Write(columns)
+}
+
+func (e *csvExportWriter) WriteRow(values []any) error {
+	for i, value := range values {
+		e.record[i] = exportCell(value)
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// jsonlExportWriter пишет каждую строку отдельным JSON-объектом с колонками в качестве ключей
+type jsonlExportWriter struct {
+	encoder *json.Encoder
+	columns []string
+}
+
+func (e *jsonlExportWriter) WriteHeader(columns []string) error {
+	e.columns = columns
+	return nil
+}
+
+func (e *jsonlExportWriter) WriteRow(values []any) error {
+	row := make(map[string]any, len(values))
+	for i, value := range values {
+		if moment, ok := value.(time.Time); ok {
+			value = moment.Format(time.DateTime)
+		}
+		row[e.columns[i]] = value
+	}
+	return e.encoder.Encode(row)
+}
+
+func (e *jsonlExportWriter) Close() error {
+	return nil
+}
+
+// xlsxExportWriter пишет лист потоково: excelize держит в памяти только часть строк, остальное во временном файле.
+// Книга — zip-архив, поэтому клиенту она уходит целиком при закрытии
+type xlsxExportWriter struct {
+	book   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	w      io.Writer
+}
+
+func (e *xlsxExportWriter) WriteHeader(columns []string) error {
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	return e.WriteRow(header)
+}
+
+func (e *xlsxExportWriter) WriteRow(values []any) error {
+	e.row++
+	cells := make([]any, len(values))
+	for i, value := range values {
+		if moment, ok := value.(time.Time); ok {
+			value = moment.Format(time.DateTime)
+		}
+		cells[i] = value
+	}
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, cells)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.book.Write(e.w)
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(w)}, nil
+	case ExportFormatJSONL:
+		return &jsonlExportWriter{encoder: json.NewEncoder(w)}, nil
+	case ExportFormatXLSX:
+		book := excelize.NewFile()
+		stream, err := book.NewStreamWriter(book.GetSheetName(0))
+		if err != nil {
+			book.Close()
+			return nil, err
+		}
+		return &xlsxExportWriter{book: book, stream: stream, w: w}, nil
+	}
+	return nil, InvalidExportFormatError
+}
+
+// countingWriter запоминает, начали ли данные уходить клиенту
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+// Export выгружает записи entity в w страницами по exportPageSize строк, не загружая всю таблицу в память.
+// Страницы читаются в одной транзакции REPEATABLE READ, поэтому выгрузка — согласованный снимок данных
+func (s ExportService) Export(ctx context.Context, entity string, format string, w io.Writer) error {
+	definition, ok := exportEntities[entity]
+	if !ok {
+		return InvalidExportEntityError
+	}
+	out := &countingWriter{w: w}
+	writer, err := newExportWriter(format, out)
+	if err != nil {
+		return err
+	}
+	if xlsx, ok := writer.(*xlsxExportWriter); ok {
+		// Удаляет временные файлы листа, в том числе если выгрузка прервалась
+		defer xlsx.book.Close()
+	}
+	tx, err := s.DB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if err := s.exportRows(ctx, q, definition, writer); err != nil {
+		if out.written > 0 {
+			return fmt.Errorf("%w: %v", ExportInterruptedError, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s ExportService) exportRows(ctx context.Context, q *gen.Queries, definition exportEntity, writer exportWriter) error {
+	if err := writer.WriteHeader(definition.columns); err != nil {
+		return err
+	}
+	var after int32
+	for {
+		rows, last, err := definition.page(ctx, q, after)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportPageSize {
+			break
+		}
+		after = last
+	}
+	return writer.Close()
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExportCell(t *testing.T) {
+	moment := time.Date(2024, 5, 10, 14, 30, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"null", nil, ""},
+		{"строка", "Холодильник", "Холодильник"},
+		{"int32", int32(42), "42"},
+		{"int64", int64(-150000), "-150000"},
+		{"время", moment, "2024-05-10 14:30:05"},
+		{"null из базы", exportText(pgtype.Text{}), ""},
+		{"сумма из базы", exportNumeric(toNumeric(1999)), "1999"},
+		{"прочее", true, "true"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := exportCell(test.value); got != test.want {
+				t.Errorf("exportCell = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// writeExport пишет заголовок и строки выгрузки в формате format
+func writeExport(t *testing.T, format string, columns []string, rows [][]any) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer, err := newExportWriter(format, &buffer)
+	if err != nil {
+		t.Fatalf("newExportWriter(%s) = %v", format, err)
+	}
+	if err := writer.WriteHeader(columns); err != nil {
+		t.Fatalf("WriteHeader = %v", err)
+	}
+	for _, row := range rows {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close = %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestExportWriters(t *testing.T) {
+	columns := []string{"id", "name", "brand", "created_at"}
+	rows := [][]any{
+		{int32(1), "Чайник, 1.7 л", nil, time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)},
+		{int32(2), "Утюг \"Pro\"", "Tefal", nil},
+	}
+
+	t.Run(ExportFormatCSV, func(t *testing.T) {
+		got := string(writeExport(t, ExportFormatCSV, columns, rows))
+		want := "id,name,brand,created_at\n" +
+			"1,\"Чайник, 1.7 л\",,2024-05-10 09:00:00\n" +
+			"2,\"Утюг \"\"Pro\"\"\",Tefal,\n"
+		if got != want {
+			t.Errorf("csv = %q, want %q", got, want)
+		}
+	})
+
+	t.Run(ExportFormatJSONL, func(t *testing.T) {
+		got := string(writeExport(t, ExportFormatJSONL, columns, rows))
+		want := `{"brand":null,"created_at":"2024-05-10 09:00:00","id":1,"name":"Чайник, 1.7 л"}` + "\n" +
+			`{"brand":"Tefal","created_at":null,"id":2,"name":"Утюг \"Pro\""}` + "\n"
+		if got != want {
+			t.Errorf("jsonl = %q, want %q", got, want)
+		}
+	})
+
+	t.Run(ExportFormatXLSX, func(t *testing.T) {
+		book, err := excelize.OpenReader(bytes.NewReader(writeExport(t, ExportFormatXLSX, columns, rows)))
+		if err != nil {
+			t.Fatalf("OpenReader = %v", err)
+		}
+		defer book.Close()
+		got, err := book.GetRows(book.GetSheetName(0))
+		if err != nil {
+			t.Fatalf("GetRows = %v", err)
+		}
+		want := [][]string{
+			columns,
+			{"1", "Чайник, 1.7 л", "", "2024-05-10 09:00:00"},
+			{"2", "Утюг \"Pro\"", "Tefal"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("xlsx rows = %d, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if !slices.Equal(got[i], want[i]) {
+				t.Errorf("xlsx row %d = %q, want %q", i, got[i], want[i])
+			}
+		}
+	})
+
+	if _, err := newExportWriter("pdf", &bytes.Buffer{}); !errors.Is(err, InvalidExportFormatError) {
+		t.Errorf("newExportWriter(pdf) = %v, want %v", err, InvalidExportFormatError)
+	}
+}
+
+func TestExportRowsPaging(t *testing.T) {
+	total := exportPageSize*2 + 3
+	var requested []int32
+	definition := exportEntity{
+		columns: []string{"id"},
+		page: func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+			requested = append(requested, after)
+			var rows [][]any
+			for id := after + 1; id <= int32(total) && len(rows) < exportPageSize; id++ {
+				rows = append(rows, []any{id})
+			}
+			if len(rows) == 0 {
+				return nil, after, nil
+			}
+			return rows, rows[len(rows)-1][0].(int32), nil
+		},
+	}
+	var buffer bytes.Buffer
+	writer, _ := newExportWriter(ExportFormatCSV, &buffer)
+	if err := (ExportService{}).exportRows(context.Background(), nil, definition, writer); err != nil {
+		t.Fatalf("exportRows = %v", err)
+	}
+	if want := []int32{0, exportPageSize, exportPageSize * 2}; !slices.Equal(requested, want) {
+		t.Errorf("exportRows pages after = %v, want %v", requested, want)
+	}
+	if lines := bytes.Count(buffer.Bytes(), []byte("\n")); lines != total+1 {
+		t.Errorf("exportRows lines = %d, want %d", lines, total+1)
+	}
+
+	failure := errors.New("connection lost")
+	definition.page = func(ctx context.Context, q *gen.Queries, after int32) ([][]any, int32, error) {
+		return nil, after, failure
+	}
+	writer, _ = newExportWriter(ExportFormatCSV, &bytes.Buffer{})
+	if err := (ExportService{}).exportRows(context.Background(), nil, definition, writer); !errors.Is(err, failure) {
+		t.Errorf("exportRows = %v, want %v", err, failure)
+	}
+}
+
+func TestExportEntities(t *testing.T) {
+	for _, entity := range []string{"goods", "customers", "suppliers", "stores"} {
+		definition, ok := exportEntities[entity]
+		if !ok {
+			t.Errorf("exportEntities[%s] missing", entity)
+			continue
+		}
+		if len(definition.columns) == 0 || definition.columns[0] != "id" {
+			t.Errorf("exportEntities[%s] columns = %v, want id first", entity, definition.columns)
+		}
+	}
+	if err := (ExportService{}).Export(context.Background(), "accounts", ExportFormatCSV, &bytes.Buffer{}); !errors.Is(err, InvalidExportEntityError) {
+		t.Errorf("Export(accounts) = %v, want %v", err, InvalidExportEntityError)
+	}
+	if err := (ExportService{}).Export(context.Background(), "goods", "pdf", &bytes.Buffer{}); !errors.Is(err, InvalidExportFormatError) {
+		t.Errorf("Export(goods, pdf) = %v, want %v", err, InvalidExportFormatError)
+	}
+}
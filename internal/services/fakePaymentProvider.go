@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FakePaymentProvider — эквайер в памяти для локальной разработки и тестов
+type FakePaymentProvider struct {
+	Secret string
+	// Авторизации на сумму больше лимита отклоняются, 0 — без лимита
+	DeclineAbove int64
+
+	mu       sync.Mutex
+	seq      int
+	payments map[string]*fakePayment
+	// Результаты списаний и возвратов по ключу идемпотентности: повтор запроса не проводит операцию второй раз
+	results map[string]ProviderPayment
+}
+
+type fakePayment struct {
+	amount   int64
+	captured int64
+	refunded int64
+	status   string
+}
+
+var FakePaymentNotFound = errors.New("fake payment not found")
+var FakePaymentStateError = errors.New("fake payment is in wrong state")
+
+func NewFakePaymentProvider(secret string) *FakePaymentProvider {
+	return &FakePaymentProvider{Secret: secret, payments: map[string]*fakePayment{}, results: map[string]ProviderPayment{}}
+}
+
+func (f *FakePaymentProvider) Name() string {
+	return "fake"
+}
+
+func (f *FakePaymentProvider) Authorize(ctx context.Context, request PaymentRequest) (ProviderPayment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if request.Amount <= 0 || (f.DeclineAbove > 0 && request.Amount > f.DeclineAbove) {
+		return ProviderPayment{}, PaymentDeclinedError
+	}
+	f.seq++
+	externalId := fmt.Sprintf("fake_%d", f.seq)
+	f.payments[externalId] = &fakePayment{amount: request.Amount, status: PaymentStatusAuthorized}
+	return ProviderPayment{ExternalId: externalId, Status: PaymentStatusAuthorized, Amount: request.Amount}, nil
+}
+
+func (f *FakePaymentProvider) Capture(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if result, ok := f.results[idempotencyKey]; ok && idempotencyKey != "" {
+		return result, nil
+	}
+	payment, ok := f.payments[externalId]
+	if !ok {
+		return ProviderPayment{}, FakePaymentNotFound
+	}
+	if payment.status != PaymentStatusAuthorized || amount <= 0 || amount > payment.amount {
+		return ProviderPayment{}, FakePaymentStateError
+	}
+	payment.captured = amount
+	payment.status = PaymentStatusCaptured
+	return f.remember(idempotencyKey, ProviderPayment{ExternalId: externalId, Status: payment.status, Amount: amount}), nil
+}
+
+func (f *FakePaymentProvider) Refund(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if result, ok := f.results[idempotencyKey]; ok && idempotencyKey != "" {
+		return result, nil
+	}
+	payment, ok := f.payments[externalId]
+	if !ok {
+		return ProviderPayment{}, FakePaymentNotFound
+	}
+	if payment.captured == 0 || amount <= 0 || payment.refunded+amount > payment.captured {
+		return ProviderPayment{}, FakePaymentStateError
+	}
+	payment.refunded += amount
+	payment.status = PaymentStatusPartiallyRefunded
+	if payment.refunded == payment.captured {
+		payment.status = PaymentStatusRefunded
+	}
+	return f.remember(idempotencyKey, ProviderPayment{ExternalId: externalId, Status: payment.status, Amount: amount}), nil
+}
+
+func (f *FakePaymentProvider) remember(idempotencyKey string, result ProviderPayment) ProviderPayment {
+	if idempotencyKey != "" {
+		f.results[idempotencyKey] = result
+	}
+	return result
+}
+
+func (f *FakePaymentProvider) VerifyWebhook(payload []byte, signature string) (PaymentWebhookEvent, error) {
+	return verifyWebhook(f.Secret, payload, signature)
+}
+
+// Webhook формирует подписанное уведомление о платеже так, как его прислал бы эквайер
+func (f *FakePaymentProvider) Webhook(externalId string, status string) ([]byte, string, error) {
+	f.mu.Lock()
+	payment, ok := f.payments[externalId]
+	f.mu.Unlock()
+	if !ok {
+		return nil, "", FakePaymentNotFound
+	}
+	payload, err := json.Marshal(PaymentWebhookEvent{ExternalId: externalId, Status: status, Amount: payment.amount})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, signPayload(f.Secret, payload), nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakePaymentProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakePaymentProvider("secret")
+	provider.DeclineAbove = 1000
+
+	if _, err := provider.Authorize(ctx, PaymentRequest{Amount: 1001}); !errors.Is(err, PaymentDeclinedError) {
+		t.Fatalf("Authorize над лимитом: err = %v, want PaymentDeclinedError", err)
+	}
+	if _, err := provider.Authorize(ctx, PaymentRequest{Amount: 0}); !errors.Is(err, PaymentDeclinedError) {
+		t.Fatalf("Authorize на ноль: err = %v, want PaymentDeclinedError", err)
+	}
+	payment, err := provider.Authorize(ctx, PaymentRequest{Amount: 1000})
+	if err != nil || payment.Status != PaymentStatusAuthorized {
+		t.Fatalf("Authorize = %+v, %v", payment, err)
+	}
+
+	if _, err := provider.Refund(ctx, payment.ExternalId, 100, "refund-early"); !errors.Is(err, FakePaymentStateError) {
+		t.Errorf("Refund до списания: err = %v, want FakePaymentStateError", err)
+	}
+	if _, err := provider.Capture(ctx, payment.ExternalId, 1001, "capture-over"); !errors.Is(err, FakePaymentStateError) {
+		t.Errorf("Capture больше авторизации: err = %v, want FakePaymentStateError", err)
+	}
+	if _, err := provider.Capture(ctx, "unknown", 100, "capture-unknown"); !errors.Is(err, FakePaymentNotFound) {
+		t.Errorf("Capture неизвестного платежа: err = %v, want FakePaymentNotFound", err)
+	}
+	captured, err := provider.Capture(ctx, payment.ExternalId, 1000, "capture")
+	if err != nil || captured.Status != PaymentStatusCaptured || captured.Amount != 1000 {
+		t.Fatalf("Capture = %+v, %v", captured, err)
+	}
+	// Повтор с тем же ключом возвращает прошлый результат, с новым ключом списывать уже нечего
+	if replay, err := provider.Capture(ctx, payment.ExternalId, 1000, "capture"); err != nil || replay != captured {
+		t.Errorf("повторный Capture с тем же ключом = %+v, %v, want %+v", replay, err, captured)
+	}
+	if _, err := provider.Capture(ctx, payment.ExternalId, 1000, "capture-again"); !errors.Is(err, FakePaymentStateError) {
+		t.Errorf("повторный Capture с другим ключом: err = %v, want FakePaymentStateError", err)
+	}
+
+	refunds := []struct {
+		key    string
+		amount int64
+		status string
+		err    error
+	}{
+		{"refund-1", 400, PaymentStatusPartiallyRefunded, nil},
+		{"refund-1", 400, PaymentStatusPartiallyRefunded, nil},
+		{"refund-2", 700, "", FakePaymentStateError},
+		{"refund-3", 600, PaymentStatusRefunded, nil},
+		{"refund-4", 1, "", FakePaymentStateError},
+	}
+	for _, refund := range refunds {
+		result, err := provider.Refund(ctx, payment.ExternalId, refund.amount, refund.key)
+		if !errors.Is(err, refund.err) || result.Status != refund.status {
+			t.Errorf("Refund(%s, %d) = %q, %v, want %q, %v", refund.key, refund.amount, result.Status, err, refund.status, refund.err)
+		}
+	}
+}
+
+func TestFakePaymentWebhookSignature(t *testing.T) {
+	provider := NewFakePaymentProvider("secret")
+	payment, err := provider.Authorize(context.Background(), PaymentRequest{Amount: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, signature, err := provider.Webhook(payment.ExternalId, PaymentStatusCaptured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := provider.VerifyWebhook(payload, signature)
+	if err != nil || event.ExternalId != payment.ExternalId || event.Status != PaymentStatusCaptured || event.Amount != 500 {
+		t.Fatalf("VerifyWebhook = %+v, %v", event, err)
+	}
+	if _, _, err := provider.Webhook("unknown", PaymentStatusCaptured); !errors.Is(err, FakePaymentNotFound) {
+		t.Errorf("Webhook неизвестного платежа: err = %v, want FakePaymentNotFound", err)
+	}
+
+	// Неверная подпись отклоняется до обращения к базе, поэтому сервису не нужен пул соединений
+	service := PaymentService{Provider: provider}
+	tampered := append([]byte{}, payload...)
+	tampered[len(tampered)-2] = '9'
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+	}{
+		{"без подписи", payload, ""},
+		{"подпись другим ключом", payload, signPayload("other-secret", payload)},
+		{"изменённое тело", tampered, signature},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := service.HandleWebhook(context.Background(), test.payload, test.signature); !errors.Is(err, InvalidWebhookSignatureError) {
+				t.Errorf("HandleWebhook: err = %v, want InvalidWebhookSignatureError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// FiscalRegistrar — онлайн-касса (ККТ) или облачный фискальный сервис по 54-ФЗ.
+// Документы передаются в структуре тегов ФФД 1.2, суммы в копейках. Повторная отправка с тем же
+// ключом идемпотентности возвращает уже зарегистрированный чек, а не пробивает его второй раз.
+type FiscalRegistrar interface {
+	Register(ctx context.Context, document FiscalDocument, idempotencyKey string) (FiscalResult, error)
+}
+
+// Признак расчета, тег 1054
+const (
+	CalculationSale       = 1
+	CalculationSaleReturn = 2
+)
+
+// Ставка НДС, тег 1199
+const (
+	FiscalVat20   = 1
+	FiscalVat10   = 2
+	FiscalVat0    = 5
+	FiscalVatNone = 6
+)
+
+const (
+	// Система налогообложения, тег 1055: общая
+	FiscalTaxSystemGeneral = 1
+	// Признак предмета расчета, тег 1212
+	FiscalItemGood    = 1
+	FiscalItemService = 4
+	// Платёж: выплаты без товара и услуги, например компенсация покупателю
+	FiscalItemPayment = 10
+	// Признак способа расчета, тег 1214: полный расчет
+	FiscalFullPayment = 4
+	// Мера количества предмета расчета, тег 2108: штуки
+	FiscalMeasurePiece = 0
+)
+
+// FiscalItem — предмет расчета, тег 1059
+type FiscalItem struct {
+	Name          string `json:"1030"`
+	Price         int64  `json:"1079"`
+	Quantity      int32  `json:"1023"`
+	MeasureUnit   int32  `json:"2108"`
+	Amount        int64  `json:"1043"`
+	VatCode       int32  `json:"1199"`
+	VatAmount     int64  `json:"1200"`
+	ItemType      int32  `json:"1212"`
+	PaymentMethod int32  `json:"1214"`
+}
+
+// FiscalDocument — кассовый чек (БСО не поддерживаются)
+type FiscalDocument struct {
+	CalculationType int32        `json:"1054"`
+	TaxSystem       int32        `json:"1055"`
+	UserName        string       `json:"1048"`
+	UserInn         string       `json:"1018"`
+	Address         string       `json:"1009"`
+	Place           string       `json:"1187"`
+	Items           []FiscalItem `json:"1059"`
+	Total           int64        `json:"1020"`
+	Cash            int64        `json:"1031"`
+	Electronic      int64        `json:"1081"`
+	Prepaid         int64        `json:"1215"`
+	Vat20           int64        `json:"1102,omitempty"`
+	Vat10           int64        `json:"1103,omitempty"`
+	AmountVat0      int64        `json:"1104,omitempty"`
+	AmountVatNone   int64        `json:"1105,omitempty"`
+}
+
+// FiscalResult — реквизиты зарегистрированного чека
+type FiscalResult struct {
+	FiscalDocumentNumber int32     `json:"1040"`
+	FiscalSign           string    `json:"1077"`
+	FnNumber             string    `json:"1041"`
+	ShiftNumber          int32     `json:"1038"`
+	DateTime             time.Time `json:"1012"`
+}
+
+// Касса отказалась регистрировать документ. Повторять без исправления документа бессмысленно.
+var FiscalDocumentRejectedError = errors.New("fiscal document rejected by registrar")
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FiscalRegistrarEmulator — эмулятор ККТ для локальной разработки: проверяет чек
+// как настоящая касса и выдаёт фискальный признак, но никуда его не передаёт
+type FiscalRegistrarEmulator struct {
+	FnNumber string
+	Secret   string
+
+	mu  sync.Mutex
+	seq int32
+	// Зарегистрированные чеки по ключу идемпотентности
+	results map[string]FiscalResult
+}
+
+func NewFiscalRegistrarEmulator(fnNumber string, secret string) *FiscalRegistrarEmulator {
+	return &FiscalRegistrarEmulator{FnNumber: fnNumber, Secret: secret, results: map[string]FiscalResult{}}
+}
+
+func (e *FiscalRegistrarEmulator) Register(ctx context.Context, document FiscalDocument, idempotencyKey string) (FiscalResult, error) {
+	if err := checkFiscalDocument(document); err != nil {
+		return FiscalResult{}, err
+	}
+	payload, err := json.Marshal(document)
+	if err != nil {
+		return FiscalResult{}, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if result, ok := e.results[idempotencyKey]; ok && idempotencyKey != "" {
+		return result, nil
+	}
+	e.seq++
+	number := e.seq
+
+	// ФПД — 32-битное число, которое ФН вычисляет над документом своим ключом
+	mac := hmac.New(sha256.New, []byte(e.Secret))
+	mac.Write([]byte(fmt.Sprintf("%s:%d:", e.FnNumber, number)))
+	mac.Write(payload)
+	sign := binary.BigEndian.Uint32(mac.Sum(nil))
+
+	result := FiscalResult{
+		FiscalDocumentNumber: number,
+		FiscalSign:           fmt.Sprintf("%010d", sign),
+		FnNumber:             e.FnNumber,
+		ShiftNumber:          1,
+		DateTime:             time.Now(),
+	}
+	if idempotencyKey != "" {
+		e.results[idempotencyKey] = result
+	}
+	return result, nil
+}
+
+// checkFiscalDocument повторяет основные проверки ККТ: итог сходится с предметами расчета и оплатами
+func checkFiscalDocument(document FiscalDocument) error {
+	if document.CalculationType != CalculationSale && document.CalculationType != CalculationSaleReturn {
+		return fmt.Errorf("%w: unknown calculation type %d", FiscalDocumentRejectedError, document.CalculationType)
+	}
+	if document.UserInn == "" {
+		return fmt.Errorf("%w: user INN is required", FiscalDocumentRejectedError)
+	}
+	if len(document.Items) == 0 {
+		return fmt.Errorf("%w: receipt has no items", FiscalDocumentRejectedError)
+	}
+	var total int64
+	for _, item := range document.Items {
+		if item.Name == "" || item.Quantity <= 0 || item.Amount != item.Price*int64(item.Quantity) {
+			return fmt.Errorf("%w: invalid item %q", FiscalDocumentRejectedError, item.Name)
+		}
+		total += item.Amount
+	}
+	if total != document.Total {
+		return fmt.Errorf("%w: items do not sum up to total", FiscalDocumentRejectedError)
+	}
+	if document.Cash+document.Electronic+document.Prepaid != document.Total {
+		return fmt.Errorf("%w: payments do not sum up to total", FiscalDocumentRejectedError)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFiscalRegistrarEmulatorIdempotency(t *testing.T) {
+	ctx := context.Background()
+	emulator := NewFiscalRegistrarEmulator("9999078900000001", "key")
+	document := FiscalDocument{
+		CalculationType: CalculationSale,
+		UserInn:         "0000000000",
+		Items:           []FiscalItem{{Name: "Чайник", Price: 150000, Quantity: 2, Amount: 300000}},
+		Total:           300000,
+		Cash:            100000,
+		Electronic:      200000,
+	}
+
+	first, err := emulator.Register(ctx, document, "receipt-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay, err := emulator.Register(ctx, document, "receipt-1")
+	if err != nil || replay != first {
+		t.Errorf("повтор с тем же ключом = %+v, %v, want %+v", replay, err, first)
+	}
+	second, err := emulator.Register(ctx, document, "receipt-2")
+	if err != nil || second.FiscalDocumentNumber != first.FiscalDocumentNumber+1 {
+		t.Errorf("другой ключ: номер ФД %d, %v, want %d", second.FiscalDocumentNumber, err, first.FiscalDocumentNumber+1)
+	}
+
+	// Отклонённый документ не запоминается: исправленный чек можно отправить с тем же ключом
+	broken := document
+	broken.Cash = 0
+	if _, err := emulator.Register(ctx, broken, "receipt-3"); !errors.Is(err, FiscalDocumentRejectedError) {
+		t.Fatalf("err = %v, want FiscalDocumentRejectedError", err)
+	}
+	if _, err := emulator.Register(ctx, document, "receipt-3"); err != nil {
+		t.Errorf("исправленный чек: %v", err)
+	}
+}
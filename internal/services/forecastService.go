@@ -0,0 +1,244 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math"
+	"time"
+)
+
+const (
+	ForecastMethodNone          = "none"
+	ForecastMethodMovingAverage = "moving_average"
+	ForecastMethodHoltWinters   = "holt_winters"
+)
+
+const (
+	// Продажи за последний год
+	forecastHistoryDays = 364
+	// Окно скользящего среднего
+	forecastMovingWindow = 28
+	// Недельная сезонность продаж
+	forecastSeasonLength = 7
+	// Коэффициенты сглаживания уровня, тренда и сезонности
+	forecastAlpha = 0.3
+	forecastBeta  = 0.05
+	forecastGamma = 0.2
+	// Затухание тренда, чтобы прогноз на год не уходил в бесконечность
+	forecastDamping = 0.95
+	// Срок поставки, если у поставщика он не указан
+	defaultLeadTimeDays = 7
+	// На сколько дней продаж рассчитана одна партия дозаказа
+	reorderCoverDays = 30
+	// Страховой запас на 95% уровень обслуживания
+	serviceLevelZ = 1.65
+)
+
+type ForecastDayDto struct {
+	Date     string  `json:"date"`
+	Quantity float64 `json:"quantity"`
+}
+
+type ForecastDto struct {
+	GoodId  int32  `json:"good_id"`
+	StoreId *int32 `json:"store_id"`
+	// Метод прогноза: holt_winters при истории от двух недель, иначе moving_average, none — продаж не было
+	Method string `json:"method"`
+	// Дней истории продаж, по которым построен прогноз
+	HistoryDays int `json:"history_days"`
+	// Средние продажи в день за последние 28 дней
+	MovingAverage float64          `json:"moving_average"`
+	Days          []ForecastDayDto `json:"days"`
+	Total         float64          `json:"total"`
+	LeadTimeDays  int32            `json:"lead_time_days"`
+	// Рекомендуемая точка заказа: спрос за срок поставки со страховым запасом и партия на 30 дней продаж
+	SuggestedMinQuantity     int32 `json:"suggested_min_quantity"`
+	SuggestedReorderQuantity int32 `json:"suggested_reorder_quantity"`
+}
+
+type ForecastInterface interface {
+	GetForecast(ctx context.Context, goodId int32, storeId *int32, days int) (ForecastDto, error)
+	ApplyForecast(ctx context.Context, goodId int32, storeId *int32) (ReorderPointDto, error)
+}
+
+type ForecastService struct {
+	Queries gen.Queries
+}
+
+var InvalidForecastPeriodError = errors.New("forecast period must be from 1 to 365 days")
+
+// GetForecast прогнозирует спрос на товар в магазине или по сети на days дней вперёд
+func (s ForecastService) GetForecast(ctx context.Context, goodId int32, storeId *int32, days int) (ForecastDto, error) {
+	if days < 1 || days > 365 {
+		return ForecastDto{}, InvalidForecastPeriodError
+	}
+	if _, err := s.Queries.GetGood(ctx, goodId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ForecastDto{}, ProductNotFound
+		}
+		return ForecastDto{}, err
+	}
+	if storeId != nil {
+		if _, err := s.Queries.GetStore(ctx, *storeId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ForecastDto{}, StoreNotFound
+			}
+			return ForecastDto{}, err
+		}
+	}
+	leadTime := int32(defaultLeadTimeDays)
+	supplier, err := s.Queries.GetBestSupplier(ctx, goodId)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ForecastDto{}, err
+	}
+	if supplier.LeadTimeDays.Valid && supplier.LeadTimeDays.Int32 > 0 {
+		leadTime = supplier.LeadTimeDays.Int32
+	}
+
+	// История заканчивается вчерашним днём: неполный сегодняшний день занизил бы прогноз
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, -forecastHistoryDays)
+	rows, err := s.Queries.ListGoodDailySales(ctx, gen.ListGoodDailySalesParams{
+		GoodID:   goodId,
+		StoreID:  toInt4(storeId),
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: today, Valid: true},
+	})
+	if err != nil {
+		return ForecastDto{}, err
+	}
+	history := dailySeries(rows, from)
+
+	horizon := max(days, int(leadTime)+reorderCoverDays)
+	method, forecast, deviation := forecastDemand(history, horizon)
+	response := ForecastDto{
+		GoodId:        goodId,
+		StoreId:       storeId,
+		Method:        method,
+		HistoryDays:   len(history),
+		MovingAverage: round2(movingAverage(history, forecastMovingWindow)),
+		Days:          make([]ForecastDayDto, days),
+		LeadTimeDays:  leadTime,
+	}
+	for i := 0; i < days; i++ {
+		response.Days[i] = ForecastDayDto{
+			Date:     today.AddDate(0, 0, i).Format(DateLayout),
+			Quantity: round2(forecast[i]),
+		}
+		response.Total += forecast[i]
+	}
+	response.Total = round2(response.Total)
+
+	leadDemand := sum(forecast[:leadTime])
+	safetyStock := serviceLevelZ * deviation * math.Sqrt(float64(leadTime))
+	response.SuggestedMinQuantity = int32(math.Ceil(leadDemand + safetyStock))
+	response.SuggestedReorderQuantity = max(1, int32(math.Ceil(sum(forecast[leadTime:leadTime+reorderCoverDays]))))
+	return response, nil
+}
+
+// ApplyForecast записывает рекомендуемую прогнозом точку заказа вместо заданной вручную
+func (s ForecastService) ApplyForecast(ctx context.Context, goodId int32, storeId *int32) (ReorderPointDto, error) {
+	forecast, err := s.GetForecast(ctx, goodId, storeId, reorderCoverDays)
+	if err != nil {
+		return ReorderPointDto{}, err
+	}
+	return setReorderPoint(ctx, &s.Queries, SetReorderPointDto{
+		GoodId:          goodId,
+		StoreId:         storeId,
+		MinQuantity:     forecast.SuggestedMinQuantity,
+		ReorderQuantity: forecast.SuggestedReorderQuantity,
+	})
+}
+
+// dailySeries раскладывает продажи по дням, начиная с первого дня с продажами. Дни без продаж — нули
+func dailySeries(rows []gen.ListGoodDailySalesRow, from time.Time) []float64 {
+	if len(rows) == 0 {
+		return nil
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(rows[0].Day.Time.Sub(start).Hours() / 24)
+	series := make([]float64, forecastHistoryDays-offset)
+	for _, row := range rows {
+		series[int(row.Day.Time.Sub(start).Hours()/24)-offset] = float64(row.Quantity)
+	}
+	return series
+}
+
+// forecastDemand прогнозирует продажи на horizon дней и оценивает разброс дневных продаж для страхового запаса
+func forecastDemand(history []float64, horizon int) (string, []float64, float64) {
+	forecast := make([]float64, horizon)
+	switch {
+	case len(history) == 0:
+		return ForecastMethodNone, forecast, 0
+	case len(history) < 2*forecastSeasonLength:
+		average := movingAverage(history, forecastMovingWindow)
+		for i := range forecast {
+			forecast[i] = average
+		}
+		return ForecastMethodMovingAverage, forecast, deviationFrom(history, average)
+	}
+	return ForecastMethodHoltWinters, forecast, holtWinters(history, forecast)
+}
+
+func movingAverage(history []float64, window int) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	window = min(window, len(history))
+	return sum(history[len(history)-window:]) / float64(window)
+}
+
+// holtWinters заполняет forecast аддитивной моделью Хольта-Винтерса с недельной сезонностью и затухающим трендом.
+// Возвращает среднеквадратичную ошибку прогнозов на день вперёд по истории
+func holtWinters(history []float64, forecast []float64) float64 {
+	m := forecastSeasonLength
+	level := sum(history[:m]) / float64(m)
+	trend := (sum(history[m:2*m]) - sum(history[:m])) / float64(m*m)
+	season := make([]float64, m)
+	for i := 0; i < m; i++ {
+		season[i] = history[i] - level
+	}
+
+	squaredErrors, steps := 0.0, 0
+	for t := m; t < len(history); t++ {
+		predicted := level + forecastDamping*trend + season[t%m]
+		squaredErrors += (history[t] - predicted) * (history[t] - predicted)
+		steps++
+
+		previousLevel := level
+		level = forecastAlpha*(history[t]-season[t%m]) + (1-forecastAlpha)*(level+forecastDamping*trend)
+		trend = forecastBeta*(level-previousLevel) + (1-forecastBeta)*forecastDamping*trend
+		season[t%m] = forecastGamma*(history[t]-level) + (1-forecastGamma)*season[t%m]
+	}
+
+	damped := 0.0
+	for h := range forecast {
+		damped += math.Pow(forecastDamping, float64(h+1))
+		forecast[h] = max(0, level+damped*trend+season[(len(history)+h)%m])
+	}
+	return math.Sqrt(squaredErrors / float64(steps))
+}
+
+func deviationFrom(history []float64, average float64) float64 {
+	squared := 0.0
+	for _, value := range history {
+		squared += (value - average) * (value - average)
+	}
+	return math.Sqrt(squared / float64(len(history)))
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math"
+	"testing"
+	"time"
+)
+
+func salesDay(from time.Time, offset int, quantity int32) gen.ListGoodDailySalesRow {
+	return gen.ListGoodDailySalesRow{
+		Day:      pgtype.Date{Time: from.AddDate(0, 0, offset), Valid: true},
+		Quantity: quantity,
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	if series := dailySeries(nil, from); series != nil {
+		t.Fatalf("dailySeries(nil) = %v, want nil", series)
+	}
+
+	series := dailySeries([]gen.ListGoodDailySalesRow{salesDay(from, 2, 5), salesDay(from, 4, 3)}, from)
+	if len(series) != forecastHistoryDays-2 {
+		t.Fatalf("len = %d, want %d: series starts at the first day with sales", len(series), forecastHistoryDays-2)
+	}
+	for i, want := range []float64{5, 0, 3, 0} {
+		if series[i] != want {
+			t.Errorf("series[%d] = %v, want %v", i, series[i], want)
+		}
+	}
+}
+
+func TestForecastDemandMethod(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []float64
+		want    string
+	}{
+		{"без продаж", nil, ForecastMethodNone},
+		{"меньше двух сезонов", make([]float64, 2*forecastSeasonLength-1), ForecastMethodMovingAverage},
+		{"два сезона", make([]float64, 2*forecastSeasonLength), ForecastMethodHoltWinters},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method, forecast, _ := forecastDemand(test.history, 10)
+			if method != test.want {
+				t.Errorf("method = %s, want %s", method, test.want)
+			}
+			if len(forecast) != 10 {
+				t.Errorf("len(forecast) = %d, want 10", len(forecast))
+			}
+		})
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		history []float64
+		window  int
+		want    float64
+	}{
+		{nil, 28, 0},
+		{[]float64{2, 4}, 28, 3},
+		{[]float64{100, 1, 2, 3}, 3, 2},
+	}
+	for _, test := range tests {
+		if got := movingAverage(test.history, test.window); got != test.want {
+			t.Errorf("movingAverage(%v, %d) = %v, want %v", test.history, test.window, got, test.want)
+		}
+	}
+}
+
+func TestHoltWinters(t *testing.T) {
+	week := []float64{1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name    string
+		history []float64
+		want    []float64
+	}{
+		{"постоянный спрос", []float64{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5}, []float64{5, 5, 5, 5, 5, 5, 5, 5, 5}},
+		{"недельная сезонность", append(append(append([]float64{}, week...), week...), week...), []float64{1, 2, 3, 4, 5, 6, 7, 1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forecast := make([]float64, len(test.want))
+			rmse := holtWinters(test.history, forecast)
+			if rmse > 1e-9 {
+				t.Errorf("rmse = %v, want 0 for an exactly repeating series", rmse)
+			}
+			for i := range test.want {
+				if math.Abs(forecast[i]-test.want[i]) > 1e-9 {
+					t.Errorf("forecast[%d] = %v, want %v", i, forecast[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHoltWintersNeverNegative(t *testing.T) {
+	history := []float64{30, 28, 25, 22, 20, 17, 15, 12, 10, 8, 5, 3, 1, 0}
+	forecast := make([]float64, 60)
+	holtWinters(history, forecast)
+	for i, value := range forecast {
+		if value < 0 {
+			t.Fatalf("forecast[%d] = %v, want >= 0", i, value)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type GiftCardDto struct {
+	Id        int32     `json:"id"`
+	Code      string    `json:"code"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	IsAlive   bool      `json:"is_alive"`
+}
+
+type CreateGiftCardDto struct {
+	Code    string `json:"code"`
+	Balance int64  `json:"balance"`
+}
+
+type GiftCardInterface interface {
+	CreateGiftCard(ctx context.Context, dto CreateGiftCardDto) (GiftCardDto, error)
+	GetGiftCard(ctx context.Context, code string) (GiftCardDto, error)
+	GetGiftCards(ctx context.Context) ([]GiftCardDto, error)
+}
+
+type GiftCardService struct {
+	Queries gen.Queries
+}
+
+var GiftCardNotFound = errors.New("gift card not found")
+var InvalidGiftCardError = errors.New("gift card code and positive balance are required")
+var InsufficientGiftCardBalanceError = errors.New("insufficient gift card balance")
+
+func ToGiftCardDto(card gen.GiftCard) GiftCardDto {
+	return GiftCardDto{
+		Id:        card.ID,
+		Code:      card.Code,
+		Balance:   fromNumeric(card.Balance),
+		CreatedAt: card.CreatedAt.Time,
+		IsAlive:   card.IsAlive,
+	}
+}
+
+func (g GiftCardService) CreateGiftCard(ctx context.Context, dto CreateGiftCardDto) (GiftCardDto, error) {
+	if dto.Code == "" || dto.Balance <= 0 {
+		return GiftCardDto{}, InvalidGiftCardError
+	}
+	card, err := g.Queries.CreateGiftCard(ctx, gen.CreateGiftCardParams{
+		Code:      dto.Code,
+		Balance:   toNumeric(dto.Balance),
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:   true,
+	})
+	if err != nil {
+		return GiftCardDto{}, err
+	}
+	return ToGiftCardDto(card), nil
+}
+
+func (g GiftCardService) GetGiftCard(ctx context.Context, code string) (GiftCardDto, error) {
+	card, err := g.Queries.GetGiftCardByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return GiftCardDto{}, GiftCardNotFound
+		}
+		return GiftCardDto{}, err
+	}
+	return ToGiftCardDto(card), nil
+}
+
+func (g GiftCardService) GetGiftCards(ctx context.Context) ([]GiftCardDto, error) {
+	cards, err := g.Queries.ListGiftCards(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]GiftCardDto, len(cards))
+	for i, card := range cards {
+		response[i] = ToGiftCardDto(card)
+	}
+	return response, nil
+}
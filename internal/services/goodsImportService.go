@@ -0,0 +1,396 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	GoodsImportFormatCSV  = "csv"
+	GoodsImportFormatXLSX = "xlsx"
+)
+
+const (
+	// Строк в одной пачке COPY и пакетного обновления
+	goodsImportBatchSize = 1000
+	// Сколько ошибок строк вернуть в ответе, остальные только считаются
+	goodsImportMaxErrors = 1000
+)
+
+// GoodsImportFields — поля товара, которые можно загрузить из файла. Остатка и себестоимости среди них нет:
+// они меняются только документами, которые пишут журнал остатков и историю себестоимости
+var GoodsImportFields = []string{"article", "name", "price", "category_id", "tax_rate_id", "brand"}
+
+type GoodsImportOptionsDto struct {
+	// csv или xlsx
+	Format string
+	// Поле товара → заголовок колонки в файле. Поля без сопоставления ищутся по заголовку с тем же именем
+	Mapping map[string]string
+	// Разделитель колонок CSV
+	Delimiter rune
+	// Только проверить файл, ничего не записывая
+	DryRun bool
+}
+
+type GoodsImportErrorDto struct {
+	// Номер строки в файле, заголовок — строка 1
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type GoodsImportResultDto struct {
+	DryRun bool `json:"dry_run"`
+	Rows   int  `json:"rows"`
+	// Новые товары и товары, найденные по артикулу. При dry_run — сколько было бы создано и обновлено
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	// Строки с ошибками не загружаются
+	Skipped    int                   `json:"skipped"`
+	ErrorCount int                   `json:"error_count"`
+	Errors     []GoodsImportErrorDto `json:"errors"`
+}
+
+type GoodsImportInterface interface {
+	ImportGoods(ctx context.Context, body io.Reader, options GoodsImportOptionsDto) (GoodsImportResultDto, error)
+}
+
+type GoodsImportService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var InvalidImportFormatError = errors.New("format must be csv or xlsx")
+var InvalidImportFileError = errors.New("cannot read import file")
+var InvalidImportMappingError = errors.New("invalid column mapping")
+
+// importGood — проверенная строка файла
+type importGood struct {
+	article    string
+	name       string
+	price      int64
+	categoryId *int32
+	taxRateId  *int32
+	brand      string
+}
+
+// openImportRows возвращает функцию, читающую файл по строке; конец файла — io.EOF.
+// CSV читается потоком из тела запроса, XLSX — построчно из листа после распаковки книги
+func openImportRows(body io.Reader, options GoodsImportOptionsDto) (func() ([]string, error), func(), error) {
+	switch options.Format {
+	case GoodsImportFormatCSV:
+		reader := csv.NewReader(body)
+		reader.Comma = options.Delimiter
+		reader.FieldsPerRecord = -1
+		return reader.Read, func() {}, nil
+	case GoodsImportFormatXLSX:
+		book, err := excelize.OpenReader(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", InvalidImportFileError, err)
+		}
+		rows, err := book.Rows(book.GetSheetName(0))
+		if err != nil {
+			book.Close()
+			return nil, nil, fmt.Errorf("%w: %v", InvalidImportFileError, err)
+		}
+		next := func() ([]string, error) {
+			if !rows.Next() {
+				if err := rows.Error(); err != nil {
+					return nil, err
+				}
+				return nil, io.EOF
+			}
+			return rows.Columns()
+		}
+		return next, func() { rows.Close(); book.Close() }, nil
+	}
+	return nil, nil, InvalidImportFormatError
+}
+
+// importColumns сопоставляет поля товара с номерами колонок по заголовку файла
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	for field := range mapping {
+		if !slices.Contains(GoodsImportFields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q", InvalidImportMappingError, field)
+		}
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel сохраняет CSV в UTF-8 с BOM
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	columns := make(map[string]int)
+	for _, field := range GoodsImportFields {
+		name, mapped := mapping[field]
+		if !mapped {
+			name = field
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			if mapped {
+				return nil, fmt.Errorf("%w: column %q not found", InvalidImportMappingError, name)
+			}
+			continue
+		}
+		columns[field] = i
+	}
+	if _, ok := columns["article"]; !ok {
+		return nil, fmt.Errorf("%w: article column is required", InvalidImportMappingError)
+	}
+	return columns, nil
+}
+
+// parseImportRow проверяет строку файла. Пустая ячейка необязательного поля означает null
+func parseImportRow(record []string, columns map[string]int, categories map[int32]bool, taxRates map[int32]bool) (importGood, []GoodsImportErrorDto) {
+	cell := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	var good importGood
+	var rowErrors []GoodsImportErrorDto
+	fail := func(field string, message string) {
+		rowErrors = append(rowErrors, GoodsImportErrorDto{Column: field, Message: message})
+	}
+	parseId := func(field string, known map[int32]bool) *int32 {
+		value := cell(field)
+		if value == "" {
+			return nil
+		}
+		id, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			fail(field, "must be an integer")
+			return nil
+		}
+		if !known[int32(id)] {
+			fail(field, fmt.Sprintf("%d not found", id))
+			return nil
+		}
+		result := int32(id)
+		return &result
+	}
+
+	if good.article = cell("article"); good.article == "" {
+		fail("article", "is required")
+	}
+	// Название и цена обязательны у товара, поэтому пустая ячейка в их колонке — ошибка
+	if _, ok := columns["name"]; ok {
+		if good.name = cell("name"); good.name == "" {
+			fail("name", "is required")
+		}
+	}
+	good.brand = cell("brand")
+	if _, ok := columns["price"]; ok {
+		price, err := strconv.ParseInt(cell("price"), 10, 64)
+		if err != nil || price < 0 {
+			fail("price", "must be a non-negative integer")
+		}
+		good.price = price
+	}
+	good.categoryId = parseId("category_id", categories)
+	good.taxRateId = parseId("tax_rate_id", taxRates)
+	return good, rowErrors
+}
+
+// ImportGoods загружает товары из CSV или XLSX. Товар с уже известным артикулом обновляется,
+// причём меняются только колонки, которые есть в файле. Новые товары создаются с нулевым остатком
+// и без себестоимости — их задают приёмка и инвентаризация. Строки с ошибками пропускаются, остальные
+// записываются пачками через COPY в одной транзакции
+func (s GoodsImportService) ImportGoods(ctx context.Context, body io.Reader, options GoodsImportOptionsDto) (GoodsImportResultDto, error) {
+	next, closeRows, err := openImportRows(body, options)
+	if err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	defer closeRows()
+	header, err := next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return GoodsImportResultDto{}, fmt.Errorf("%w: file is empty", InvalidImportFileError)
+		}
+		return GoodsImportResultDto{}, fmt.Errorf("%w: %v", InvalidImportFileError, err)
+	}
+	columns, err := importColumns(header, options.Mapping)
+	if err != nil {
+		return GoodsImportResultDto{}, err
+	}
+
+	goods, err := s.Queries.ListGoodArticles(ctx)
+	if err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	articles := make(map[string]int32, len(goods))
+	for _, good := range goods {
+		if _, ok := articles[good.Article]; !ok {
+			articles[good.Article] = good.ID
+		}
+	}
+	categoryRows, err := s.Queries.ListCategories(ctx)
+	if err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	categories := make(map[int32]bool, len(categoryRows))
+	for _, category := range categoryRows {
+		categories[category.ID] = true
+	}
+	taxRateRows, err := s.Queries.ListTaxRates(ctx)
+	if err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	taxRates := make(map[int32]bool, len(taxRateRows))
+	for _, taxRate := range taxRateRows {
+		taxRates[taxRate.ID] = true
+	}
+
+	var tx pgx.Tx
+	q := &s.Queries
+	if !options.DryRun {
+		if tx, err = s.DB.Begin(ctx); err != nil {
+			return GoodsImportResultDto{}, err
+		}
+		defer tx.Rollback(ctx)
+		q = s.Queries.WithTx(tx)
+	}
+
+	_, setName := columns["name"]
+	_, setPrice := columns["price"]
+	_, setCategory := columns["category_id"]
+	_, setTaxRate := columns["tax_rate_id"]
+	_, setBrand := columns["brand"]
+	var created []gen.CreateManyGoodsParams
+	updated := gen.UpdateManyGoodsParams{
+		SetName:     setName,
+		SetPrice:    setPrice,
+		SetCategory: setCategory,
+		SetTaxRate:  setTaxRate,
+		SetBrand:    setBrand,
+	}
+	flush := func() error {
+		if len(created) > 0 {
+			if _, err := q.CreateManyGoods(ctx, created); err != nil {
+				return err
+			}
+			created = created[:0]
+		}
+		if len(updated.Ids) > 0 {
+			if err := q.UpdateManyGoods(ctx, updated); err != nil {
+				return err
+			}
+			updated.Ids, updated.Names, updated.Prices = updated.Ids[:0], updated.Names[:0], updated.Prices[:0]
+			updated.CategoryIds, updated.TaxRateIds = updated.CategoryIds[:0], updated.TaxRateIds[:0]
+			updated.Brands = updated.Brands[:0]
+		}
+		return nil
+	}
+
+	result := GoodsImportResultDto{DryRun: options.DryRun, Errors: []GoodsImportErrorDto{}}
+	// Строка файла, в которой артикул встретился первым
+	seen := make(map[string]int)
+	for row := 2; ; row++ {
+		record, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		// Битая запись CSV (например, незакрытая кавычка) — ошибка строки; читатель продолжает со следующей записи
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			result.Rows++
+			result.Skipped++
+			result.ErrorCount++
+			if len(result.Errors) < goodsImportMaxErrors {
+				result.Errors = append(result.Errors, GoodsImportErrorDto{Row: row, Message: parseError.Err.Error()})
+			}
+			continue
+		}
+		if err != nil {
+			return GoodsImportResultDto{}, fmt.Errorf("%w: row %d: %v", InvalidImportFileError, row, err)
+		}
+		if !slices.ContainsFunc(record, func(value string) bool { return strings.TrimSpace(value) != "" }) {
+			continue
+		}
+		result.Rows++
+
+		good, rowErrors := parseImportRow(record, columns, categories, taxRates)
+		id, exists := articles[good.article]
+		if first, ok := seen[good.article]; ok && good.article != "" {
+			rowErrors = append(rowErrors, GoodsImportErrorDto{Column: "article", Message: fmt.Sprintf("duplicates row %d", first)})
+		}
+		if !exists && !setName {
+			rowErrors = append(rowErrors, GoodsImportErrorDto{Column: "name", Message: "column is required for a new good"})
+		}
+		if !exists && !setPrice {
+			rowErrors = append(rowErrors, GoodsImportErrorDto{Column: "price", Message: "column is required for a new good"})
+		}
+		if len(rowErrors) > 0 {
+			result.Skipped++
+			result.ErrorCount += len(rowErrors)
+			for _, rowError := range rowErrors {
+				if len(result.Errors) < goodsImportMaxErrors {
+					rowError.Row = row
+					result.Errors = append(result.Errors, rowError)
+				}
+			}
+			continue
+		}
+		seen[good.article] = row
+
+		if exists {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+		if options.DryRun {
+			continue
+		}
+
+		if exists {
+			updated.Ids = append(updated.Ids, id)
+			updated.Names = append(updated.Names, good.name)
+			updated.Prices = append(updated.Prices, toNumeric(good.price))
+			updated.CategoryIds = append(updated.CategoryIds, toInt4(good.categoryId))
+			updated.TaxRateIds = append(updated.TaxRateIds, toInt4(good.taxRateId))
+			updated.Brands = append(updated.Brands, pgtype.Text{String: good.brand, Valid: good.brand != ""})
+		} else {
+			created = append(created, gen.CreateManyGoodsParams{
+				Article:    good.article,
+				Price:      toNumeric(good.price),
+				Name:       good.name,
+				IsAlive:    true,
+				CategoryID: toInt4(good.categoryId),
+				TaxRateID:  toInt4(good.taxRateId),
+				Brand:      pgtype.Text{String: good.brand, Valid: good.brand != ""},
+			})
+		}
+		if len(created)+len(updated.Ids) >= goodsImportBatchSize {
+			if err := flush(); err != nil {
+				return GoodsImportResultDto{}, err
+			}
+		}
+	}
+
+	if options.DryRun {
+		return result, nil
+	}
+	if err := flush(); err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return GoodsImportResultDto{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []string
+		mapping map[string]string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:   "по заголовкам",
+			header: []string{"\ufeffArticle", " name ", "price", "unknown"},
+			want:   map[string]int{"article": 0, "name": 1, "price": 2},
+		},
+		{
+			name:    "по сопоставлению",
+			header:  []string{"Артикул", "Наименование", "brand"},
+			mapping: map[string]string{"article": "артикул", "name": "Наименование"},
+			want:    map[string]int{"article": 0, "name": 1, "brand": 2},
+		},
+		{
+			name:   "повторный заголовок берётся первым",
+			header: []string{"article", "price", "price"},
+			want:   map[string]int{"article": 0, "price": 1},
+		},
+		{
+			name:    "нет колонки артикула",
+			header:  []string{"name", "price"},
+			wantErr: true,
+		},
+		{
+			name:    "сопоставленной колонки нет в файле",
+			header:  []string{"article"},
+			mapping: map[string]string{"name": "Наименование"},
+			wantErr: true,
+		},
+		{
+			name:    "остаток не загружается",
+			header:  []string{"article", "quantity"},
+			mapping: map[string]string{"quantity": "quantity"},
+			wantErr: true,
+		},
+		{
+			name:    "себестоимость не загружается",
+			header:  []string{"article", "cost"},
+			mapping: map[string]string{"cost": "cost"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := importColumns(test.header, test.mapping)
+			if test.wantErr {
+				if !errors.Is(err, InvalidImportMappingError) {
+					t.Fatalf("err = %v, want InvalidImportMappingError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(got, test.want) {
+				t.Errorf("importColumns = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	columns := map[string]int{"article": 0, "name": 1, "price": 2, "category_id": 3, "tax_rate_id": 4, "brand": 5}
+	categories := map[int32]bool{1: true}
+	taxRates := map[int32]bool{2: true}
+	category, taxRate := int32(1), int32(2)
+	tests := []struct {
+		name       string
+		record     []string
+		want       importGood
+		wantErrors []string
+	}{
+		{
+			name:   "все поля",
+			record: []string{" A-1 ", "Чайник", "1500", "1", "2", "Bosch"},
+			want:   importGood{article: "A-1", name: "Чайник", price: 1500, categoryId: &category, taxRateId: &taxRate, brand: "Bosch"},
+		},
+		{
+			name:   "пустые необязательные поля",
+			record: []string{"A-1", "Чайник", "0", "", ""},
+			want:   importGood{article: "A-1", name: "Чайник"},
+		},
+		{
+			name:       "без артикула и названия",
+			record:     []string{"", "", "10"},
+			want:       importGood{price: 10},
+			wantErrors: []string{"article", "name"},
+		},
+		{
+			name:       "неверная цена",
+			record:     []string{"A-1", "Чайник", "-5"},
+			want:       importGood{article: "A-1", name: "Чайник", price: -5},
+			wantErrors: []string{"price"},
+		},
+		{
+			name:       "неизвестные категория и ставка",
+			record:     []string{"A-1", "Чайник", "10", "7", "x"},
+			want:       importGood{article: "A-1", name: "Чайник", price: 10},
+			wantErrors: []string{"category_id", "tax_rate_id"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, rowErrors := parseImportRow(test.record, columns, categories, taxRates)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseImportRow = %+v, want %+v", got, test.want)
+			}
+			var fields []string
+			for _, rowError := range rowErrors {
+				fields = append(fields, rowError.Column)
+			}
+			if !reflect.DeepEqual(fields, test.wantErrors) {
+				t.Errorf("errors in %v, want %v", fields, test.wantErrors)
+			}
+		})
+	}
+}
+
+func TestOpenImportRowsContinuesAfterBrokenRecord(t *testing.T) {
+	body := strings.NewReader("article;name\nA-1;Чайник\nA-2;Утюг \"Люкс\"\nA-3;Фен\n")
+	next, closeRows, err := openImportRows(body, GoodsImportOptionsDto{Format: GoodsImportFormatCSV, Delimiter: ';'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeRows()
+	var articles []string
+	var broken int
+	for {
+		record, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			broken++
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		articles = append(articles, record[0])
+	}
+	if broken != 1 || !reflect.DeepEqual(articles, []string{"article", "A-1", "A-3"}) {
+		t.Errorf("broken = %d, articles = %v", broken, articles)
+	}
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const StockAdjustmentGoodsReceipt = "goods_receipt"
+
+type GoodsReceiptItemDto struct {
+	Id                  int32 `json:"id"`
+	PurchaseOrderItemId int32 `json:"purchase_order_item_id"`
+	GoodId              int32 `json:"good_id"`
+	// Сколько оставалось привезти по строке заказа до этой приёмки
+	ExpectedQuantity int32 `json:"expected_quantity"`
+	ReceivedQuantity int32 `json:"received_quantity"`
+	DamagedQuantity  int32 `json:"damaged_quantity"`
+	// Принято на склад: привезено за вычетом брака
+	AcceptedQuantity int32 `json:"accepted_quantity"`
+	// Недопоставлено. Считается только при завершающей приёмке
+	MissingQuantity int32 `json:"missing_quantity"`
+}
+
+type GoodsReceiptDto struct {
+	Id              int32                 `json:"id"`
+	PurchaseOrderId int32                 `json:"purchase_order_id"`
+	StoreId         int32                 `json:"store_id"`
+	EmployeeId      int32                 `json:"employee_id"`
+	IsFinal         bool                  `json:"is_final"`
+	Comment         string                `json:"comment,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	ClaimId         *int32                `json:"claim_id"`
+	Items           []GoodsReceiptItemDto `json:"items"`
+}
+
+type CreateGoodsReceiptItemDto struct {
+	PurchaseOrderItemId int32 `json:"purchase_order_item_id"`
+	// Всего привезено, включая брак
+	ReceivedQuantity int32 `json:"received_quantity"`
+	DamagedQuantity  int32 `json:"damaged_quantity"`
+}
+
+type CreateGoodsReceiptDto struct {
+	PurchaseOrderId int32 `json:"purchase_order_id"`
+	EmployeeId      int32 `json:"employee_id"`
+	// Завершающая приёмка: всё, что не привезено, считается недопоставкой, и заказ закрывается
+	IsFinal bool                        `json:"is_final"`
+	Comment string                      `json:"comment"`
+	Items   []CreateGoodsReceiptItemDto `json:"items"`
+}
+
+type GoodsReceiptInterface interface {
+	CreateGoodsReceipt(ctx context.Context, dto CreateGoodsReceiptDto) (GoodsReceiptDto, error)
+	GetGoodsReceipt(ctx context.Context, id int32) (GoodsReceiptDto, error)
+	GetGoodsReceipts(ctx context.Context, purchaseOrderId *int32) ([]GoodsReceiptDto, error)
+}
+
+type GoodsReceiptService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var GoodsReceiptNotFound = errors.New("goods receipt not found")
+var InvalidGoodsReceiptError = errors.New("receipt lines must reference order items once, with damaged not exceeding received quantity")
+var OverDeliveryError = errors.New("received quantity exceeds what remains on the purchase order")
+
+func ToGoodsReceiptDto(receipt gen.GoodsReceipt, items []gen.GoodsReceiptItem, claimId *int32) GoodsReceiptDto {
+	response := GoodsReceiptDto{
+		Id:              receipt.ID,
+		PurchaseOrderId: receipt.PurchaseOrderID,
+		StoreId:         receipt.StoreID,
+		EmployeeId:      receipt.EmployeeID,
+		IsFinal:         receipt.IsFinal,
+		Comment:         receipt.Comment.String,
+		CreatedAt:       receipt.CreatedAt.Time,
+		ClaimId:         claimId,
+		Items:           make([]GoodsReceiptItemDto, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = GoodsReceiptItemDto{
+			Id:                  item.ID,
+			PurchaseOrderItemId: item.PurchaseOrderItemID,
+			GoodId:              item.GoodID,
+			ExpectedQuantity:    item.ExpectedQuantity,
+			ReceivedQuantity:    item.ReceivedQuantity,
+			DamagedQuantity:     item.DamagedQuantity,
+			AcceptedQuantity:    item.AcceptedQuantity,
+			MissingQuantity:     item.MissingQuantity,
+		}
+	}
+	return response
+}
+
+// CreateGoodsReceipt фиксирует приёмку товара по отправленному заказу поставщику. Остаток увеличивается только
+// на принятое количество. Брак, а при завершающей приёмке и недопоставка, попадают в претензию поставщику.
+func (s GoodsReceiptService) CreateGoodsReceipt(ctx context.Context, dto CreateGoodsReceiptDto) (GoodsReceiptDto, error) {
+	if len(dto.Items) == 0 && !dto.IsFinal {
+		return GoodsReceiptDto{}, InvalidGoodsReceiptError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	order, err := q.GetPurchaseOrderForUpdate(ctx, dto.PurchaseOrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return GoodsReceiptDto{}, PurchaseOrderNotFound
+		}
+		return GoodsReceiptDto{}, err
+	}
+	if order.Status != PurchaseOrderStatusSent && order.Status != PurchaseOrderStatusPartiallyReceived {
+		return GoodsReceiptDto{}, PurchaseOrderStatusError
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, order.StoreID); err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	orderItems, err := q.ListPurchaseOrderItems(ctx, order.ID)
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	receivedRows, err := q.ListReceivedQuantities(ctx, order.ID)
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	received := make(map[int32]int32, len(receivedRows))
+	for _, row := range receivedRows {
+		received[row.PurchaseOrderItemID] = row.Received
+	}
+	lines, err := indexReceiptLines(dto.Items)
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	receipt, err := q.CreateGoodsReceipt(ctx, gen.CreateGoodsReceiptParams{
+		PurchaseOrderID: order.ID,
+		StoreID:         order.StoreID,
+		EmployeeID:      dto.EmployeeId,
+		IsFinal:         dto.IsFinal,
+		Comment:         pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		CreatedAt:       now,
+	})
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+
+	var items []gen.GoodsReceiptItem
+	var claimItems []gen.CreateSupplierClaimItemParams
+	var claimAmount int64
+	complete := true
+	for _, orderItem := range orderItems {
+		line, ok := lines[orderItem.ID]
+		delete(lines, orderItem.ID)
+		expected := orderItem.Quantity - received[orderItem.ID]
+		accepted, missing, err := receiveLine(expected, line, dto.IsFinal)
+		if err != nil {
+			return GoodsReceiptDto{}, err
+		}
+		if line.ReceivedQuantity < expected {
+			complete = false
+		}
+		// В промежуточной приёмке строки, которых не привезли, не отражаются: их ещё ждут
+		if !ok && (!dto.IsFinal || expected == 0) {
+			continue
+		}
+		item, err := q.CreateGoodsReceiptItem(ctx, gen.CreateGoodsReceiptItemParams{
+			GoodsReceiptID:      receipt.ID,
+			PurchaseOrderItemID: orderItem.ID,
+			GoodID:              orderItem.GoodID,
+			ExpectedQuantity:    expected,
+			ReceivedQuantity:    line.ReceivedQuantity,
+			DamagedQuantity:     line.DamagedQuantity,
+			AcceptedQuantity:    accepted,
+			MissingQuantity:     missing,
+		})
+		if err != nil {
+			return GoodsReceiptDto{}, err
+		}
+		items = append(items, item)
+
+		if accepted > 0 {
+			good, err := q.IncreaseGoodQuantity(ctx, gen.IncreaseGoodQuantityParams{ID: orderItem.GoodID, Quantity: accepted})
+			if err != nil {
+				return GoodsReceiptDto{}, err
+			}
+			if orderItem.UnitCost.Valid {
+				if err := updateAverageCost(ctx, q, good, accepted, fromNumeric(orderItem.UnitCost), receipt); err != nil {
+					return GoodsReceiptDto{}, err
+				}
+			}
+			_, err = q.CreateStockAdjustment(ctx, gen.CreateStockAdjustmentParams{
+				GoodID:         orderItem.GoodID,
+				StoreID:        order.StoreID,
+				Quantity:       accepted,
+				Reason:         StockAdjustmentGoodsReceipt,
+				GoodsReceiptID: pgtype.Int4{Int32: receipt.ID, Valid: true},
+				EmployeeID:     pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+				CreatedAt:      now,
+			})
+			if err != nil {
+				return GoodsReceiptDto{}, err
+			}
+		}
+		if line.DamagedQuantity > 0 || missing > 0 {
+			amount := fromNumeric(orderItem.UnitCost) * int64(line.DamagedQuantity+missing)
+			claimItems = append(claimItems, gen.CreateSupplierClaimItemParams{
+				GoodID:          orderItem.GoodID,
+				DamagedQuantity: line.DamagedQuantity,
+				MissingQuantity: missing,
+				UnitCost:        orderItem.UnitCost,
+				Amount:          toNumeric(amount),
+			})
+			claimAmount += amount
+		}
+	}
+	// Оставшиеся строки не относятся к этому заказу
+	if len(lines) > 0 {
+		return GoodsReceiptDto{}, InvalidGoodsReceiptError
+	}
+
+	status := PurchaseOrderStatusPartiallyReceived
+	if complete || dto.IsFinal {
+		status = PurchaseOrderStatusReceived
+	}
+	if _, err := q.UpdatePurchaseOrderStatus(ctx, gen.UpdatePurchaseOrderStatusParams{ID: order.ID, Status: status}); err != nil {
+		return GoodsReceiptDto{}, err
+	}
+
+	var claimId *int32
+	if len(claimItems) > 0 {
+		claim, err := q.CreateSupplierClaim(ctx, gen.CreateSupplierClaimParams{
+			SupplierID:      order.SupplierID,
+			PurchaseOrderID: order.ID,
+			GoodsReceiptID:  receipt.ID,
+			Status:          SupplierClaimStatusOpen,
+			Amount:          toNumeric(claimAmount),
+			CreatedAt:       now,
+		})
+		if err != nil {
+			return GoodsReceiptDto{}, err
+		}
+		for _, claimItem := range claimItems {
+			claimItem.SupplierClaimID = claim.ID
+			if _, err := q.CreateSupplierClaimItem(ctx, claimItem); err != nil {
+				return GoodsReceiptDto{}, err
+			}
+		}
+		claimId = &claim.ID
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	return ToGoodsReceiptDto(receipt, items, claimId), nil
+}
+
+// indexReceiptLines раскладывает строки приёмки по строкам заказа; строка заказа может встретиться только раз,
+// а брак не может превышать привезённое
+func indexReceiptLines(items []CreateGoodsReceiptItemDto) (map[int32]CreateGoodsReceiptItemDto, error) {
+	lines := make(map[int32]CreateGoodsReceiptItemDto, len(items))
+	for _, line := range items {
+		if _, ok := lines[line.PurchaseOrderItemId]; ok ||
+			line.ReceivedQuantity < 0 || line.DamagedQuantity < 0 || line.DamagedQuantity > line.ReceivedQuantity {
+			return nil, InvalidGoodsReceiptError
+		}
+		lines[line.PurchaseOrderItemId] = line
+	}
+	return lines, nil
+}
+
+// receiveLine считает принятое количество и недопоставку строки при ожидаемом остатке expected.
+// Недопоставка фиксируется только завершающей приёмкой.
+func receiveLine(expected int32, line CreateGoodsReceiptItemDto, isFinal bool) (accepted int32, missing int32, err error) {
+	if line.ReceivedQuantity > expected {
+		return 0, 0, OverDeliveryError
+	}
+	if isFinal {
+		missing = expected - line.ReceivedQuantity
+	}
+	return line.ReceivedQuantity - line.DamagedQuantity, missing, nil
+}
+
+// averageCost — средняя взвешенная себестоимость после приёмки accepted единиц по цене unitCost к остатку before
+// по себестоимости cost, с округлением до единицы. Если прежняя себестоимость неизвестна
+// или товара не было на складе, себестоимостью становится цена приёмки.
+func averageCost(before int32, cost pgtype.Numeric, accepted int32, unitCost int64) int64 {
+	if before <= 0 || !cost.Valid {
+		return unitCost
+	}
+	total := int64(before)*fromNumeric(cost) + int64(accepted)*unitCost
+	quantity := int64(before) + int64(accepted)
+	return (total + quantity/2) / quantity
+}
+
+// updateAverageCost пересчитывает среднюю взвешенную себестоимость товара после приёмки и пишет её в историю.
+// good — товар с уже увеличенным остатком.
+func updateAverageCost(ctx context.Context, q *gen.Queries, good gen.Good, accepted int32, unitCost int64, receipt gen.GoodsReceipt) error {
+	cost := averageCost(good.Quantity-accepted, good.Cost, accepted, unitCost)
+	if err := q.UpdateGoodCost(ctx, gen.UpdateGoodCostParams{ID: good.ID, Cost: toNumeric(cost)}); err != nil {
+		return err
+	}
+	_, err := q.CreateGoodCost(ctx, gen.CreateGoodCostParams{
+		GoodID:         good.ID,
+		Cost:           toNumeric(cost),
+		GoodsReceiptID: pgtype.Int4{Int32: receipt.ID, Valid: true},
+		CreatedAt:      receipt.CreatedAt,
+	})
+	return err
+}
+
+func loadGoodsReceipt(ctx context.Context, q *gen.Queries, receipt gen.GoodsReceipt) (GoodsReceiptDto, error) {
+	items, err := q.ListGoodsReceiptItems(ctx, receipt.ID)
+	if err != nil {
+		return GoodsReceiptDto{}, err
+	}
+	var claimId *int32
+	claim, err := q.GetGoodsReceiptClaim(ctx, receipt.ID)
+	switch {
+	case err == nil:
+		claimId = &claim.ID
+	case !errors.Is(err, pgx.ErrNoRows):
+		return GoodsReceiptDto{}, err
+	}
+	return ToGoodsReceiptDto(receipt, items, claimId), nil
+}
+
+func (s GoodsReceiptService) GetGoodsReceipt(ctx context.Context, id int32) (GoodsReceiptDto, error) {
+	receipt, err := s.Queries.GetGoodsReceipt(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return GoodsReceiptDto{}, GoodsReceiptNotFound
+		}
+		return GoodsReceiptDto{}, err
+	}
+	return loadGoodsReceipt(ctx, &s.Queries, receipt)
+}
+
+func (s GoodsReceiptService) GetGoodsReceipts(ctx context.Context, purchaseOrderId *int32) ([]GoodsReceiptDto, error) {
+	receipts, err := s.Queries.ListGoodsReceipts(ctx, toInt4(purchaseOrderId))
+	if err != nil {
+		return nil, err
+	}
+	response := make([]GoodsReceiptDto, len(receipts))
+	for i, receipt := range receipts {
+		if response[i], err = loadGoodsReceipt(ctx, &s.Queries, receipt); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestAverageCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   int32
+		cost     pgtype.Numeric
+		accepted int32
+		unitCost int64
+		want     int64
+	}{
+		{"первая приёмка", 0, pgtype.Numeric{}, 10, 500, 500},
+		{"себестоимость неизвестна", 5, pgtype.Numeric{}, 10, 500, 500},
+		{"склад пуст", 0, toNumeric(300), 10, 500, 500},
+		{"отрицательный остаток", -2, toNumeric(300), 10, 500, 500},
+		{"взвешенное среднее", 10, toNumeric(100), 30, 200, 175},
+		{"округление вверх", 1, toNumeric(100), 1, 101, 101},
+		{"округление вниз", 2, toNumeric(100), 1, 101, 100},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := averageCost(test.before, test.cost, test.accepted, test.unitCost)
+			if got != test.want {
+				t.Errorf("averageCost = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIndexReceiptLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []CreateGoodsReceiptItemDto
+		want  error
+	}{
+		{"пустая приёмка", nil, nil},
+		{"две строки", []CreateGoodsReceiptItemDto{
+			{PurchaseOrderItemId: 1, ReceivedQuantity: 5, DamagedQuantity: 1},
+			{PurchaseOrderItemId: 2, ReceivedQuantity: 0},
+		}, nil},
+		{"весь товар с браком", []CreateGoodsReceiptItemDto{{PurchaseOrderItemId: 1, ReceivedQuantity: 3, DamagedQuantity: 3}}, nil},
+		{"повтор строки заказа", []CreateGoodsReceiptItemDto{
+			{PurchaseOrderItemId: 1, ReceivedQuantity: 2},
+			{PurchaseOrderItemId: 1, ReceivedQuantity: 3},
+		}, InvalidGoodsReceiptError},
+		{"брака больше привезённого", []CreateGoodsReceiptItemDto{{PurchaseOrderItemId: 1, ReceivedQuantity: 2, DamagedQuantity: 3}}, InvalidGoodsReceiptError},
+		{"отрицательное количество", []CreateGoodsReceiptItemDto{{PurchaseOrderItemId: 1, ReceivedQuantity: -1}}, InvalidGoodsReceiptError},
+		{"отрицательный брак", []CreateGoodsReceiptItemDto{{PurchaseOrderItemId: 1, ReceivedQuantity: 1, DamagedQuantity: -1}}, InvalidGoodsReceiptError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines, err := indexReceiptLines(test.items)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("indexReceiptLines = %v, want %v", err, test.want)
+			}
+			if err == nil && len(lines) != len(test.items) {
+				t.Errorf("indexReceiptLines lines = %d, want %d", len(lines), len(test.items))
+			}
+		})
+	}
+}
+
+func TestReceiveLine(t *testing.T) {
+	tests := []struct {
+		name         string
+		expected     int32
+		line         CreateGoodsReceiptItemDto
+		isFinal      bool
+		wantAccepted int32
+		wantMissing  int32
+		wantErr      error
+	}{
+		{"привезли всё", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 10}, false, 10, 0, nil},
+		{"частичная приёмка не фиксирует недопоставку", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 4}, false, 4, 0, nil},
+		{"завершающая приёмка фиксирует недопоставку", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 4}, true, 4, 6, nil},
+		{"брак не принимается", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 10, DamagedQuantity: 2}, false, 8, 0, nil},
+		{"брак и недопоставка", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 7, DamagedQuantity: 2}, true, 5, 3, nil},
+		{"строку не привезли", 10, CreateGoodsReceiptItemDto{}, true, 0, 10, nil},
+		{"привезли больше заказанного", 10, CreateGoodsReceiptItemDto{ReceivedQuantity: 11}, false, 0, 0, OverDeliveryError},
+		{"строка уже принята полностью", 0, CreateGoodsReceiptItemDto{ReceivedQuantity: 1}, true, 0, 0, OverDeliveryError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			accepted, missing, err := receiveLine(test.expected, test.line, test.isFinal)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("receiveLine error = %v, want %v", err, test.wantErr)
+			}
+			if accepted != test.wantAccepted || missing != test.wantMissing {
+				t.Errorf("receiveLine = %d/%d, want %d/%d", accepted, missing, test.wantAccepted, test.wantMissing)
+			}
+		})
+	}
+}
@@ -10,28 +10,45 @@ import (
 )
 
 type GoodDto struct {
-	Id       int32  `json:"id"`
-	Article  string `json:"article"`
-	Price    int64  `json:"price"`
-	Name     string `json:"name"`
-	Quantity int32  `json:"quantity"`
-	IsAlive  bool   `json:"is_alive"`
+	Id         int32  `json:"id"`
+	Article    string `json:"article"`
+	Price      int64  `json:"price"`
+	Name       string `json:"name"`
+	Quantity   int32  `json:"quantity"`
+	Reserved   int32  `json:"reserved"`
+	Available  int32  `json:"available"`
+	CategoryId *int32 `json:"category_id"`
+	TaxRateId  *int32 `json:"tax_rate_id"`
+	Cost       *int64 `json:"cost"`
+	Brand      string `json:"brand"`
+	IsAlive    bool   `json:"is_alive"`
 }
 
 type CreateGoodDto struct {
-	Article  string `json:"article"`
-	Price    int32  `json:"price"`
-	Name     string `json:"name"`
-	Quantity int32  `json:"quantity"`
+	Article    string `json:"article"`
+	Price      int32  `json:"price"`
+	Name       string `json:"name"`
+	Quantity   int32  `json:"quantity"`
+	CategoryId *int32 `json:"category_id"`
+	// Своя ставка НДС товара, иначе действует ставка категории
+	TaxRateId *int32 `json:"tax_rate_id"`
+	// Закупочная себестоимость единицы, null — неизвестна
+	Cost *int64 `json:"cost"`
+	// Производитель
+	Brand string `json:"brand"`
 }
 
+// UpdateGoodDto не содержит остатка и себестоимости: остаток меняют приёмки, продажи, списания
+// и инвентаризации, себестоимость — средняя по приёмкам
 type UpdateGoodDto struct {
-	Id       int32  `json:"id"`
-	Article  string `json:"article"`
-	Price    int64  `json:"price"`
-	Name     string `json:"name"`
-	Quantity int32  `json:"quantity"`
-	IsAlive  bool   `json:"is_alive"`
+	Id         int32  `json:"id"`
+	Article    string `json:"article"`
+	Price      int64  `json:"price"`
+	Name       string `json:"name"`
+	CategoryId *int32 `json:"category_id"`
+	TaxRateId  *int32 `json:"tax_rate_id"`
+	Brand      string `json:"brand"`
+	IsAlive    bool   `json:"is_alive"`
 }
 
 type GoodsInterface interface {
@@ -50,11 +67,15 @@ var ProductNotFound = errors.New("Product not found")
 
 func (g GoodsService) CreateProduct(ctx context.Context, dto CreateGoodDto) (GoodDto, error) {
 	product, err := g.Queries.CreateGood(ctx, gen.CreateGoodParams{
-		Article:  dto.Article,
-		Price:    pgtype.Numeric{Int: big.NewInt(int64(dto.Price))},
-		Name:     dto.Name,
-		Quantity: dto.Quantity,
-		IsAlive:  true,
+		Article:    dto.Article,
+		Price:      pgtype.Numeric{Int: big.NewInt(int64(dto.Price))},
+		Name:       dto.Name,
+		Quantity:   dto.Quantity,
+		IsAlive:    true,
+		CategoryID: toInt4(dto.CategoryId),
+		TaxRateID:  toInt4(dto.TaxRateId),
+		Cost:       toOptionalNumeric(dto.Cost),
+		Brand:      pgtype.Text{String: dto.Brand, Valid: dto.Brand != ""},
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -68,16 +89,48 @@ func (g GoodsService) CreateProduct(ctx context.Context, dto CreateGoodDto) (Goo
 
 func ToProductDto(product gen.Good) GoodDto {
 	response := GoodDto{
-		Id:       product.ID,
-		Article:  product.Article,
-		Price:    product.Price.Int.Int64(),
-		Name:     product.Name,
-		Quantity: product.Quantity,
-		IsAlive:  product.IsAlive,
+		Id:         product.ID,
+		Article:    product.Article,
+		Price:      product.Price.Int.Int64(),
+		Name:       product.Name,
+		Quantity:   product.Quantity,
+		Available:  product.Quantity,
+		CategoryId: fromInt4(product.CategoryID),
+		TaxRateId:  fromInt4(product.TaxRateID),
+		Cost:       fromOptionalNumeric(product.Cost),
+		Brand:      product.Brand.String,
+		IsAlive:    product.IsAlive,
 	}
 	return response
 }
 
+// applyReservations вычитает активные резервы из остатков, чтобы отдавать доступное количество
+func applyReservations(ctx context.Context, queries gen.Queries, goods []GoodDto) error {
+	reserved, err := queries.ListReservedQuantities(ctx)
+	if err != nil {
+		return err
+	}
+	byGood := make(map[int32]int32, len(reserved))
+	for _, r := range reserved {
+		byGood[r.GoodID] = r.Reserved
+	}
+	for i := range goods {
+		goods[i].Reserved = byGood[goods[i].Id]
+		goods[i].Available = goods[i].Quantity - goods[i].Reserved
+	}
+	return nil
+}
+
+func applyReservation(ctx context.Context, queries gen.Queries, good *GoodDto) error {
+	reserved, err := queries.GetReservedQuantity(ctx, good.Id)
+	if err != nil {
+		return err
+	}
+	good.Reserved = reserved
+	good.Available = good.Quantity - reserved
+	return nil
+}
+
 func (g GoodsService) GetProduct(ctx context.Context, id int32) (GoodDto, error) {
 	product, err := g.Queries.GetGood(ctx, id)
 	if err != nil {
@@ -87,6 +140,9 @@ func (g GoodsService) GetProduct(ctx context.Context, id int32) (GoodDto, error)
 		return GoodDto{}, err
 	}
 	response := ToProductDto(product)
+	if err := applyReservation(ctx, g.Queries, &response); err != nil {
+		return GoodDto{}, err
+	}
 	return response, nil
 }
 
@@ -102,17 +158,22 @@ func (g GoodsService) GetGoods(ctx context.Context) ([]GoodDto, error) {
 	for i, product := range products {
 		response[i] = ToProductDto(product)
 	}
+	if err := applyReservations(ctx, g.Queries, response); err != nil {
+		return nil, err
+	}
 	return response, nil
 }
 
 func (g GoodsService) UpdateGoods(ctx context.Context, dto UpdateGoodDto) (GoodDto, error) {
 	product, err := g.Queries.UpdateGood(ctx, gen.UpdateGoodParams{
-		ID:       dto.Id,
-		Article:  dto.Article,
-		Price:    pgtype.Numeric{Int: big.NewInt(dto.Price)},
-		Name:     dto.Name,
-		Quantity: dto.Quantity,
-		IsAlive:  dto.IsAlive,
+		ID:         dto.Id,
+		Article:    dto.Article,
+		Price:      pgtype.Numeric{Int: big.NewInt(dto.Price)},
+		Name:       dto.Name,
+		IsAlive:    dto.IsAlive,
+		CategoryID: toInt4(dto.CategoryId),
+		TaxRateID:  toInt4(dto.TaxRateId),
+		Brand:      pgtype.Text{String: dto.Brand, Valid: dto.Brand != ""},
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -121,6 +182,9 @@ func (g GoodsService) UpdateGoods(ctx context.Context, dto UpdateGoodDto) (GoodD
 		return GoodDto{}, err
 	}
 	response := ToProductDto(product)
+	if err := applyReservation(ctx, g.Queries, &response); err != nil {
+		return GoodDto{}, err
+	}
 	return response, nil
 }
 
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"math/big"
+)
+
+func toNumeric(value int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(value), Valid: true}
+}
+
+// fromNumeric учитывает экспоненту: pgx может вернуть 1500 как Int=15, Exp=2
+func fromNumeric(n pgtype.Numeric) int64 {
+	if !n.Valid || n.Int == nil {
+		return 0
+	}
+	value := new(big.Int).Set(n.Int)
+	if n.Exp > 0 {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n.Exp)), nil))
+	}
+	if n.Exp < 0 {
+		value.Quo(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-n.Exp)), nil))
+	}
+	return value.Int64()
+}
+
+func toOptionalNumeric(value *int64) pgtype.Numeric {
+	if value == nil {
+		return pgtype.Numeric{}
+	}
+	return toNumeric(*value)
+}
+
+func fromOptionalNumeric(value pgtype.Numeric) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	result := fromNumeric(value)
+	return &result
+}
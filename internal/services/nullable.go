@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// toInt4 и fromInt4 переводят необязательные ссылки из DTO в колонки с NULL и обратно
+
+func toInt4(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
+
+func fromInt4(value pgtype.Int4) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Int32
+	return &result
+}
+
+// DateLayout — формат дат без времени в DTO и параметрах запросов
+const DateLayout = "2006-01-02"
+
+var InvalidDateError = errors.New("date must be in YYYY-MM-DD format")
+
+// fromDate переводит необязательную дату в строку YYYY-MM-DD
+func fromDate(value pgtype.Date) *string {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Time.Format(DateLayout)
+	return &result
+}
+
+// parseDate разбирает дату YYYY-MM-DD, пустая строка означает сегодняшний день
+func parseDate(value string) (pgtype.Date, error) {
+	if value == "" {
+		now := time.Now()
+		return pgtype.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Valid: true}, nil
+	}
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return pgtype.Date{}, InvalidDateError
+	}
+	return pgtype.Date{Time: date, Valid: true}, nil
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	OrderStatusNew       = "new"
+	OrderStatusPaid      = "paid"
+	OrderStatusCancelled = "cancelled"
+
+	OrderPaymentUnpaid            = "unpaid"
+	OrderPaymentAuthorized        = "authorized"
+	OrderPaymentPaid              = "paid"
+	OrderPaymentPartiallyRefunded = "partially_refunded"
+	OrderPaymentRefunded          = "refunded"
+	OrderPaymentFailed            = "failed"
+
+	OrderReservationTTL = 30 * time.Minute
+	// Сколько эквайер держит авторизацию карты. На этот срок продлеваются резервы заказа,
+	// чтобы авторизованную оплату можно было списать и позже OrderReservationTTL
+	PaymentAuthorizationTTL = 7 * 24 * time.Hour
+)
+
+type OrderItemDto struct {
+	Id       int32 `json:"id"`
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+	Price    int64 `json:"price"`
+	Amount   int64 `json:"amount"`
+	// Ставка НДС на момент продажи, null — без НДС
+	VatRate *int32 `json:"vat_rate"`
+	// НДС, включённый в сумму позиции, в копейках
+	VatAmount int64 `json:"vat_amount"`
+	// Средняя себестоимость единицы на момент продажи, null — заказ не оплачен или себестоимость неизвестна
+	UnitCost *int64 `json:"unit_cost"`
+}
+
+// OrderServiceLineDto — строка заказа с услугой (установка, ремонт), резервов по ней не создаётся
+type OrderServiceLineDto struct {
+	Id        int32  `json:"id"`
+	ServiceId int32  `json:"service_id"`
+	Quantity  int32  `json:"quantity"`
+	Price     int64  `json:"price"`
+	Amount    int64  `json:"amount"`
+	VatRate   *int32 `json:"vat_rate"`
+	VatAmount int64  `json:"vat_amount"`
+}
+
+type OrderDto struct {
+	Id            int32                 `json:"id"`
+	CustomerId    *int32                `json:"customer_id,omitempty"`
+	EmployeeId    *int32                `json:"employee_id,omitempty"`
+	RegisterId    *int32                `json:"register_id,omitempty"`
+	StoreId       int32                 `json:"store_id"`
+	Status        string                `json:"status"`
+	PaymentStatus string                `json:"payment_status"`
+	FiscalStatus  string                `json:"fiscal_status"`
+	FiscalSign    string                `json:"fiscal_sign,omitempty"`
+	Total         int64                 `json:"total"`
+	Items         []OrderItemDto        `json:"items"`
+	Services      []OrderServiceLineDto `json:"services"`
+	Taxes         []TaxBreakdownDto     `json:"taxes"`
+	VatTotal      int64                 `json:"vat_total"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+type CreateOrderItemDto struct {
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+type CreateOrderServiceDto struct {
+	ServiceId int32 `json:"service_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+type CreateOrderDto struct {
+	CustomerId *int32                  `json:"customer_id"`
+	EmployeeId *int32                  `json:"employee_id"`
+	RegisterId *int32                  `json:"register_id"`
+	StoreId    int32                   `json:"store_id"`
+	Items      []CreateOrderItemDto    `json:"items"`
+	Services   []CreateOrderServiceDto `json:"services"`
+}
+
+type OrderInterface interface {
+	CreateOrder(ctx context.Context, dto CreateOrderDto) (OrderDto, error)
+	GetOrder(ctx context.Context, id int32) (OrderDto, error)
+	GetOrders(ctx context.Context) ([]OrderDto, error)
+	CancelOrder(ctx context.Context, id int32) (OrderDto, error)
+}
+
+type OrderService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var OrderNotFound = errors.New("order not found")
+var EmptyOrderError = errors.New("order has no items or services")
+var OrderStatusError = errors.New("operation is not allowed in current order status")
+
+// OrderReservationOwner — владелец резервов, созданных под заказ
+func OrderReservationOwner(orderId int32) string {
+	return fmt.Sprintf("order:%d", orderId)
+}
+
+func ToOrderDto(order gen.Order, items []gen.OrderItem, services []gen.OrderService) OrderDto {
+	response := OrderDto{
+		Id:            order.ID,
+		StoreId:       order.StoreID,
+		Status:        order.Status,
+		PaymentStatus: order.PaymentStatus,
+		FiscalStatus:  order.FiscalStatus,
+		FiscalSign:    order.FiscalSign.String,
+		Total:         fromNumeric(order.Total),
+		Items:         make([]OrderItemDto, len(items)),
+		Services:      make([]OrderServiceLineDto, len(services)),
+		Taxes:         []TaxBreakdownDto{},
+		CreatedAt:     order.CreatedAt.Time,
+		UpdatedAt:     order.UpdatedAt.Time,
+	}
+	if order.CustomerID.Valid {
+		customerId := order.CustomerID.Int32
+		response.CustomerId = &customerId
+	}
+	response.EmployeeId = fromInt4(order.EmployeeID)
+	response.RegisterId = fromInt4(order.RegisterID)
+	for i, item := range items {
+		price := fromNumeric(item.Price)
+		amount := price * int64(item.Quantity)
+		var vat int64
+		response.Taxes, vat = addTaxBreakdown(response.Taxes, item.VatRate, amount)
+		response.VatTotal += vat
+		response.Items[i] = OrderItemDto{
+			Id:        item.ID,
+			GoodId:    item.GoodID,
+			Quantity:  item.Quantity,
+			Price:     price,
+			Amount:    amount,
+			VatRate:   fromInt4(item.VatRate),
+			VatAmount: vat,
+			UnitCost:  fromOptionalNumeric(item.UnitCost),
+		}
+	}
+	for i, line := range services {
+		price := fromNumeric(line.Price)
+		amount := price * int64(line.Quantity)
+		var vat int64
+		response.Taxes, vat = addTaxBreakdown(response.Taxes, line.VatRate, amount)
+		response.VatTotal += vat
+		response.Services[i] = OrderServiceLineDto{
+			Id:        line.ID,
+			ServiceId: line.ServiceID,
+			Quantity:  line.Quantity,
+			Price:     price,
+			Amount:    amount,
+			VatRate:   fromInt4(line.VatRate),
+			VatAmount: vat,
+		}
+	}
+	return response
+}
+
+func loadOrder(ctx context.Context, q *gen.Queries, order gen.Order) (OrderDto, error) {
+	items, err := q.ListOrderItems(ctx, order.ID)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	services, err := q.ListOrderServices(ctx, order.ID)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	return ToOrderDto(order, items, services), nil
+}
+
+// activeOrderReservations возвращает резервы заказа, если все они ещё действуют
+func activeOrderReservations(ctx context.Context, q *gen.Queries, orderId int32) ([]gen.Reservation, error) {
+	items, err := q.ListOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := q.ListActiveReservationsByOwner(ctx, OrderReservationOwner(orderId))
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) != len(items) {
+		return nil, ReservationNotActiveError
+	}
+	return reservations, nil
+}
+
+// extendOrderReservations продлевает все резервы заказа до until. Если хотя бы один уже истёк, заказ не продлевается
+func extendOrderReservations(ctx context.Context, q *gen.Queries, orderId int32, until time.Time) error {
+	items, err := q.ListOrderItems(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	extended, err := q.ExtendReservationsByOwner(ctx, gen.ExtendReservationsByOwnerParams{
+		Owner:     OrderReservationOwner(orderId),
+		ExpiresAt: pgtype.Timestamp{Time: until, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if extended != int64(len(items)) {
+		return ReservationNotActiveError
+	}
+	return nil
+}
+
+// commitOrderReservations списывает со склада все резервы заказа.
+// Если хотя бы один резерв истёк, заказ провести нельзя.
+func commitOrderReservations(ctx context.Context, q *gen.Queries, orderId int32) error {
+	reservations, err := activeOrderReservations(ctx, q, orderId)
+	if err != nil {
+		return err
+	}
+	// Себестоимость фиксируется в момент списания товара со склада
+	if err := q.SetOrderItemCosts(ctx, orderId); err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if _, err := commitReservation(ctx, q, reservation.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o OrderService) CreateOrder(ctx context.Context, dto CreateOrderDto) (OrderDto, error) {
+	if len(dto.Items) == 0 && len(dto.Services) == 0 {
+		return OrderDto{}, EmptyOrderError
+	}
+	tx, err := o.DB.Begin(ctx)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := o.Queries.WithTx(tx)
+
+	if dto.EmployeeId != nil {
+		employee, err := q.GetEmployee(ctx, *dto.EmployeeId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return OrderDto{}, EmployeeNotFound
+			}
+			return OrderDto{}, err
+		}
+		if !employee.IsAlive {
+			return OrderDto{}, EmployeeNotFound
+		}
+	}
+	// Продажа в магазине проходит через кассу, и касса должна быть открыта
+	if dto.RegisterId != nil {
+		if err := checkRegisterOpen(ctx, q, *dto.RegisterId, dto.StoreId); err != nil {
+			return OrderDto{}, err
+		}
+	}
+	customerId := pgtype.Int4{}
+	if dto.CustomerId != nil {
+		customerId = pgtype.Int4{Int32: *dto.CustomerId, Valid: true}
+	}
+	order, err := q.CreateOrder(ctx, gen.CreateOrderParams{
+		CustomerID:    customerId,
+		StoreID:       dto.StoreId,
+		Status:        OrderStatusNew,
+		PaymentStatus: OrderPaymentUnpaid,
+		FiscalStatus:  OrderFiscalNone,
+		Total:         toNumeric(0),
+		CreatedAt:     pgtype.Timestamp{Time: time.Now(), Valid: true},
+		EmployeeID:    toInt4(dto.EmployeeId),
+		RegisterID:    toInt4(dto.RegisterId),
+	})
+	if err != nil {
+		return OrderDto{}, err
+	}
+
+	var total int64
+	for _, item := range dto.Items {
+		_, err := reserveGood(ctx, q, CreateReservationDto{
+			GoodId:     item.GoodId,
+			StoreId:    dto.StoreId,
+			Quantity:   item.Quantity,
+			Owner:      OrderReservationOwner(order.ID),
+			TtlSeconds: int32(OrderReservationTTL / time.Second),
+		})
+		if err != nil {
+			return OrderDto{}, err
+		}
+		good, err := q.GetGood(ctx, item.GoodId)
+		if err != nil {
+			return OrderDto{}, err
+		}
+		// Цена и ставка НДС фиксируются в заказе, чтобы чек не зависел от последующих изменений товара
+		vatRate, err := resolveVatRate(ctx, q, item.GoodId)
+		if err != nil {
+			return OrderDto{}, err
+		}
+		price := fromNumeric(good.Price)
+		_, err = q.CreateOrderItem(ctx, gen.CreateOrderItemParams{
+			OrderID:  order.ID,
+			GoodID:   item.GoodId,
+			Quantity: item.Quantity,
+			Price:    toNumeric(price),
+			VatRate:  vatRate,
+		})
+		if err != nil {
+			return OrderDto{}, err
+		}
+		total += price * int64(item.Quantity)
+	}
+	for _, line := range dto.Services {
+		if line.Quantity <= 0 {
+			return OrderDto{}, InvalidQuantityError
+		}
+		service, err := q.GetService(ctx, line.ServiceId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return OrderDto{}, ServiceNotFound
+			}
+			return OrderDto{}, err
+		}
+		if !service.IsAlive {
+			return OrderDto{}, ServiceNotFound
+		}
+		vatRate, err := resolveServiceVatRate(ctx, q, service.ID)
+		if err != nil {
+			return OrderDto{}, err
+		}
+		price := fromNumeric(service.Price)
+		_, err = q.CreateOrderService(ctx, gen.CreateOrderServiceParams{
+			OrderID:   order.ID,
+			ServiceID: service.ID,
+			Quantity:  line.Quantity,
+			Price:     toNumeric(price),
+			VatRate:   vatRate,
+		})
+		if err != nil {
+			return OrderDto{}, err
+		}
+		total += price * int64(line.Quantity)
+	}
+
+	order, err = q.UpdateOrderTotal(ctx, gen.UpdateOrderTotalParams{ID: order.ID, Total: toNumeric(total)})
+	if err != nil {
+		return OrderDto{}, err
+	}
+	response, err := loadOrder(ctx, q, order)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return OrderDto{}, err
+	}
+	return response, nil
+}
+
+func (o OrderService) GetOrder(ctx context.Context, id int32) (OrderDto, error) {
+	order, err := o.Queries.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return OrderDto{}, OrderNotFound
+		}
+		return OrderDto{}, err
+	}
+	return loadOrder(ctx, &o.Queries, order)
+}
+
+func (o OrderService) GetOrders(ctx context.Context) ([]OrderDto, error) {
+	orders, err := o.Queries.ListOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]OrderDto, len(orders))
+	for i, order := range orders {
+		response[i], err = loadOrder(ctx, &o.Queries, order)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// CancelOrder отменяет неоплаченный заказ и снимает его резервы
+func (o OrderService) CancelOrder(ctx context.Context, id int32) (OrderDto, error) {
+	tx, err := o.DB.Begin(ctx)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := o.Queries.WithTx(tx)
+
+	order, err := q.GetOrderForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return OrderDto{}, OrderNotFound
+		}
+		return OrderDto{}, err
+	}
+	if order.Status != OrderStatusNew ||
+		(order.PaymentStatus != OrderPaymentUnpaid && order.PaymentStatus != OrderPaymentFailed) {
+		return OrderDto{}, OrderStatusError
+	}
+	reservations, err := q.ListActiveReservationsByOwner(ctx, OrderReservationOwner(order.ID))
+	if err != nil {
+		return OrderDto{}, err
+	}
+	for _, reservation := range reservations {
+		if _, err := q.ReleaseReservation(ctx, reservation.ID); err != nil {
+			return OrderDto{}, err
+		}
+	}
+	order, err = q.UpdateOrderStatus(ctx, gen.UpdateOrderStatusParams{ID: order.ID, Status: OrderStatusCancelled})
+	if err != nil {
+		return OrderDto{}, err
+	}
+	response, err := loadOrder(ctx, q, order)
+	if err != nil {
+		return OrderDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return OrderDto{}, err
+	}
+	return response, nil
+}
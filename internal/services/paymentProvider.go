@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PaymentProvider — эквайер, через которого проходят оплаты заказов.
+// Суммы передаются в тех же единицах, что и цены товаров. Списание и возврат с одним ключом идемпотентности
+// эквайер проводит один раз, поэтому после сбоя их можно безопасно повторить.
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, request PaymentRequest) (ProviderPayment, error)
+	Capture(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error)
+	Refund(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error)
+	VerifyWebhook(payload []byte, signature string) (PaymentWebhookEvent, error)
+}
+
+type PaymentRequest struct {
+	OrderId     int32  `json:"order_id"`
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+}
+
+type ProviderPayment struct {
+	ExternalId string `json:"external_id"`
+	Status     string `json:"status"`
+	Amount     int64  `json:"amount"`
+}
+
+type PaymentWebhookEvent struct {
+	ExternalId string `json:"external_id"`
+	Status     string `json:"status"`
+	Amount     int64  `json:"amount"`
+}
+
+const PaymentSignatureHeader = "X-Signature"
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+var PaymentDeclinedError = errors.New("payment declined by provider")
+var InvalidWebhookSignatureError = errors.New("invalid webhook signature")
+
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyWebhook(secret string, payload []byte, signature string) (PaymentWebhookEvent, error) {
+	expected := signPayload(secret, payload)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return PaymentWebhookEvent{}, InvalidWebhookSignatureError
+	}
+	var event PaymentWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return PaymentWebhookEvent{}, err
+	}
+	return event, nil
+}
+
+// HTTPPaymentProvider ходит в эквайер по HTTP. Протокол совпадает с локальной заглушкой /fake-acquirer.
+type HTTPPaymentProvider struct {
+	BaseURL string
+	Secret  string
+	Client  *http.Client
+}
+
+func (p HTTPPaymentProvider) Name() string {
+	return "acquirer"
+}
+
+func (p HTTPPaymentProvider) post(ctx context.Context, path string, body any, idempotencyKey string) (ProviderPayment, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return ProviderPayment{}, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return ProviderPayment{}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		request.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return ProviderPayment{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusPaymentRequired {
+		return ProviderPayment{}, PaymentDeclinedError
+	}
+	if response.StatusCode >= 300 {
+		message, _ := io.ReadAll(response.Body)
+		return ProviderPayment{}, fmt.Errorf("acquirer %s: %s: %s", path, response.Status, bytes.TrimSpace(message))
+	}
+	var result ProviderPayment
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return ProviderPayment{}, err
+	}
+	return result, nil
+}
+
+func (p HTTPPaymentProvider) Authorize(ctx context.Context, request PaymentRequest) (ProviderPayment, error) {
+	return p.post(ctx, "/authorize", request, "")
+}
+
+func (p HTTPPaymentProvider) Capture(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error) {
+	return p.post(ctx, "/payments/"+externalId+"/capture", map[string]int64{"amount": amount}, idempotencyKey)
+}
+
+func (p HTTPPaymentProvider) Refund(ctx context.Context, externalId string, amount int64, idempotencyKey string) (ProviderPayment, error) {
+	return p.post(ctx, "/payments/"+externalId+"/refund", map[string]int64{"amount": amount}, idempotencyKey)
+}
+
+func (p HTTPPaymentProvider) VerifyWebhook(payload []byte, signature string) (PaymentWebhookEvent, error) {
+	return verifyWebhook(p.Secret, payload, signature)
+}
@@ -0,0 +1,572 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	PaymentStatusAuthorized        = "authorized"
+	PaymentStatusCapturing         = "capturing" // списание отправлено эквайеру, ответ ещё не записан
+	PaymentStatusCaptured          = "captured"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusFailed            = "failed"
+
+	TenderCash     = "cash"
+	TenderCard     = "card"
+	TenderBalance  = "balance"
+	TenderGiftCard = "gift_card"
+
+	// Провайдер платежей, которые магазин проводит сам: наличные, баланс, подарочные карты
+	StorePaymentProvider = "store"
+)
+
+type PaymentDto struct {
+	Id             int32     `json:"id"`
+	OrderId        int32     `json:"order_id"`
+	Tender         string    `json:"tender"`
+	Provider       string    `json:"provider"`
+	ExternalId     string    `json:"external_id"`
+	GiftCardId     *int32    `json:"gift_card_id,omitempty"`
+	Amount         int64     `json:"amount"`
+	RefundedAmount int64     `json:"refunded_amount"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type TenderDto struct {
+	Tender       string `json:"tender"`
+	Amount       int64  `json:"amount"`
+	GiftCardCode string `json:"gift_card_code,omitempty"`
+}
+
+type CreatePaymentDto struct {
+	OrderId int32 `json:"order_id"`
+	// Способы оплаты, сумма должна совпадать с суммой заказа. Если не указаны, весь заказ оплачивается картой
+	Tenders []TenderDto `json:"tenders"`
+}
+
+type TenderReportDto struct {
+	Tender   string `json:"tender"`
+	Payments int64  `json:"payments"`
+	Amount   int64  `json:"amount"`
+	Refunded int64  `json:"refunded"`
+	Net      int64  `json:"net"`
+}
+
+type PaymentInterface interface {
+	CreatePayment(ctx context.Context, dto CreatePaymentDto) ([]PaymentDto, error)
+	GetPayment(ctx context.Context, id int32) (PaymentDto, error)
+	GetOrderPayments(ctx context.Context, orderId int32) ([]PaymentDto, error)
+	CapturePayment(ctx context.Context, id int32) (PaymentDto, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (PaymentDto, error)
+	GetTenderReport(ctx context.Context, from time.Time, to time.Time) ([]TenderReportDto, error)
+}
+
+type PaymentService struct {
+	Queries  gen.Queries
+	DB       *pgxpool.Pool
+	Provider PaymentProvider
+}
+
+var PaymentNotFound = errors.New("payment not found")
+var PaymentStatusError = errors.New("operation is not allowed in current payment status")
+var InvalidTenderError = errors.New("invalid tender")
+var TenderTotalMismatchError = errors.New("tenders do not sum up to order total")
+var InsufficientBalanceError = errors.New("insufficient customer balance")
+
+func ToPaymentDto(payment gen.Payment) PaymentDto {
+	response := PaymentDto{
+		Id:             payment.ID,
+		OrderId:        payment.OrderID,
+		Tender:         payment.Tender,
+		Provider:       payment.Provider,
+		ExternalId:     payment.ExternalID,
+		Amount:         fromNumeric(payment.Amount),
+		RefundedAmount: fromNumeric(payment.RefundedAmount),
+		Status:         payment.Status,
+		CreatedAt:      payment.CreatedAt.Time,
+		UpdatedAt:      payment.UpdatedAt.Time,
+	}
+	if payment.GiftCardID.Valid {
+		giftCardId := payment.GiftCardID.Int32
+		response.GiftCardId = &giftCardId
+	}
+	return response
+}
+
+// validateTenders проверяет способы оплаты: каждый указан один раз, суммы положительные и в итоге дают сумму заказа
+func validateTenders(tenders []TenderDto, total int64) error {
+	var sum int64
+	seen := map[string]bool{}
+	for _, tender := range tenders {
+		switch tender.Tender {
+		case TenderCash, TenderCard, TenderBalance:
+		case TenderGiftCard:
+			if tender.GiftCardCode == "" {
+				return fmt.Errorf("%w: gift card code is required", InvalidTenderError)
+			}
+		default:
+			return fmt.Errorf("%w: unknown tender %q", InvalidTenderError, tender.Tender)
+		}
+		key := tender.Tender + ":" + tender.GiftCardCode
+		if seen[key] {
+			return fmt.Errorf("%w: duplicate tender %q", InvalidTenderError, tender.Tender)
+		}
+		seen[key] = true
+		if tender.Amount <= 0 {
+			return fmt.Errorf("%w: amount must be positive", InvalidTenderError)
+		}
+		sum += tender.Amount
+	}
+	if sum != total {
+		return TenderTotalMismatchError
+	}
+	return nil
+}
+
+// lockTender блокирует внутренние средства покупателя до конца транзакции и проверяет, что их хватает.
+// Для подарочной карты возвращает её id.
+func lockTender(ctx context.Context, q *gen.Queries, order gen.Order, tender TenderDto) (pgtype.Int4, error) {
+	switch tender.Tender {
+	case TenderBalance:
+		if !order.CustomerID.Valid {
+			return pgtype.Int4{}, fmt.Errorf("%w: order has no customer", InvalidTenderError)
+		}
+		balance, err := q.GetCustomerBalanceForUpdate(ctx, order.CustomerID.Int32)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pgtype.Int4{}, fmt.Errorf("%w: customer not found", InvalidTenderError)
+			}
+			return pgtype.Int4{}, err
+		}
+		if fromNumeric(balance) < tender.Amount {
+			return pgtype.Int4{}, InsufficientBalanceError
+		}
+	case TenderGiftCard:
+		card, err := q.GetGiftCardByCodeForUpdate(ctx, tender.GiftCardCode)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return pgtype.Int4{}, GiftCardNotFound
+			}
+			return pgtype.Int4{}, err
+		}
+		if fromNumeric(card.Balance) < tender.Amount {
+			return pgtype.Int4{}, InsufficientGiftCardBalanceError
+		}
+		return pgtype.Int4{Int32: card.ID, Valid: true}, nil
+	}
+	return pgtype.Int4{}, nil
+}
+
+// captureIdempotencyKey — ключ списания по платежу, одинаковый для всех повторов
+func captureIdempotencyKey(paymentId int32) string {
+	return fmt.Sprintf("payment-%d-capture", paymentId)
+}
+
+// returnToTender возвращает внутренние средства туда, откуда они пришли. Наличные выдаёт кассир, здесь только учёт.
+// Возврат на карту проходит через эквайера уже после фиксации транзакции, см. RefundService.
+func returnToTender(ctx context.Context, q *gen.Queries, order gen.Order, payment gen.Payment, amount int64) error {
+	switch payment.Tender {
+	case TenderBalance:
+		return q.AddCustomerBalance(ctx, gen.AddCustomerBalanceParams{ID: order.CustomerID.Int32, Balance: toNumeric(amount)})
+	case TenderGiftCard:
+		_, err := q.AddGiftCardBalance(ctx, gen.AddGiftCardBalanceParams{ID: payment.GiftCardID.Int32, Balance: toNumeric(amount)})
+		return err
+	case TenderCash:
+		return recordRegisterCash(ctx, q, order, payment.ID, CashMovementRefund, amount)
+	}
+	return nil
+}
+
+// CreatePayment оплачивает заказ одним или несколькими способами.
+// Наличные, баланс и подарочные карты проводятся сразу, карта только авторизуется и ждёт списания.
+func (p PaymentService) CreatePayment(ctx context.Context, dto CreatePaymentDto) ([]PaymentDto, error) {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := p.Queries.WithTx(tx)
+
+	order, err := q.GetOrderForUpdate(ctx, dto.OrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, OrderNotFound
+		}
+		return nil, err
+	}
+	if order.Status != OrderStatusNew ||
+		(order.PaymentStatus != OrderPaymentUnpaid && order.PaymentStatus != OrderPaymentFailed) {
+		return nil, OrderStatusError
+	}
+	if order.RegisterID.Valid {
+		if err := checkRegisterOpen(ctx, q, order.RegisterID.Int32, order.StoreID); err != nil {
+			return nil, err
+		}
+	}
+
+	total := fromNumeric(order.Total)
+	tenders := dto.Tenders
+	if len(tenders) == 0 {
+		tenders = []TenderDto{{Tender: TenderCard, Amount: total}}
+	}
+	if err := validateTenders(tenders, total); err != nil {
+		return nil, err
+	}
+
+	// Внутренние средства только проверяем и блокируем, списываем после ответа эквайера:
+	// при отказе по карте нужно сохранить неудачную попытку, а баланс и подарочные карты не трогать
+	giftCards := make([]pgtype.Int4, len(tenders))
+	card := -1
+	for i, tender := range tenders {
+		if tender.Tender == TenderCard {
+			card = i
+			continue
+		}
+		giftCards[i], err = lockTender(ctx, q, order, tender)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	var authorization ProviderPayment
+	if card >= 0 {
+		// Истёкшие резервы уже не списать, поэтому карту по такому заказу не авторизуем
+		if _, err := activeOrderReservations(ctx, q, order.ID); err != nil {
+			return nil, err
+		}
+		authorization, err = p.Provider.Authorize(ctx, PaymentRequest{
+			OrderId:     order.ID,
+			Amount:      tenders[card].Amount,
+			Description: fmt.Sprintf("Заказ №%d", order.ID),
+		})
+		if errors.Is(err, PaymentDeclinedError) {
+			payment, err := q.CreatePayment(ctx, gen.CreatePaymentParams{
+				OrderID:        order.ID,
+				Tender:         TenderCard,
+				Provider:       p.Provider.Name(),
+				Amount:         toNumeric(tenders[card].Amount),
+				RefundedAmount: toNumeric(0),
+				Status:         PaymentStatusFailed,
+				CreatedAt:      now,
+			})
+			if err != nil {
+				return nil, err
+			}
+			_, err = q.UpdateOrderPaymentStatus(ctx, gen.UpdateOrderPaymentStatusParams{ID: order.ID, PaymentStatus: OrderPaymentFailed})
+			if err != nil {
+				return nil, err
+			}
+			if err := tx.Commit(ctx); err != nil {
+				return nil, err
+			}
+			return []PaymentDto{ToPaymentDto(payment)}, PaymentDeclinedError
+		} else if err != nil {
+			return nil, err
+		}
+		// Списание по карте может прийти позже срока резерва, поэтому резервы держатся, пока действует авторизация
+		if err := extendOrderReservations(ctx, q, order.ID, now.Time.Add(PaymentAuthorizationTTL)); err != nil {
+			return nil, err
+		}
+	}
+
+	response := make([]PaymentDto, len(tenders))
+	for i, tender := range tenders {
+		params := gen.CreatePaymentParams{
+			OrderID:        order.ID,
+			Tender:         tender.Tender,
+			Provider:       StorePaymentProvider,
+			GiftCardID:     giftCards[i],
+			Amount:         toNumeric(tender.Amount),
+			RefundedAmount: toNumeric(0),
+			Status:         PaymentStatusCaptured,
+			CreatedAt:      now,
+		}
+		switch tender.Tender {
+		case TenderCard:
+			params.Provider = p.Provider.Name()
+			params.ExternalID = authorization.ExternalId
+			params.Status = PaymentStatusAuthorized
+		case TenderBalance:
+			err = q.AddCustomerBalance(ctx, gen.AddCustomerBalanceParams{ID: order.CustomerID.Int32, Balance: toNumeric(-tender.Amount)})
+		case TenderGiftCard:
+			_, err = q.AddGiftCardBalance(ctx, gen.AddGiftCardBalanceParams{ID: giftCards[i].Int32, Balance: toNumeric(-tender.Amount)})
+		}
+		if err != nil {
+			return nil, err
+		}
+		payment, err := q.CreatePayment(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if tender.Tender == TenderCash {
+			if err := recordRegisterCash(ctx, q, order, payment.ID, CashMovementSale, tender.Amount); err != nil {
+				return nil, err
+			}
+		}
+		response[i] = ToPaymentDto(payment)
+	}
+	if err := settleOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p PaymentService) GetPayment(ctx context.Context, id int32) (PaymentDto, error) {
+	payment, err := p.Queries.GetPayment(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PaymentDto{}, PaymentNotFound
+		}
+		return PaymentDto{}, err
+	}
+	return ToPaymentDto(payment), nil
+}
+
+func (p PaymentService) GetOrderPayments(ctx context.Context, orderId int32) ([]PaymentDto, error) {
+	payments, err := p.Queries.ListPaymentsByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]PaymentDto, len(payments))
+	for i, payment := range payments {
+		response[i] = ToPaymentDto(payment)
+	}
+	return response, nil
+}
+
+// settleOrder пересчитывает статус оплаты заказа по его платежам. Когда проведены все способы оплаты,
+// резервы списываются со склада, заказ становится оплаченным и по нему ставится в очередь чек продажи.
+func settleOrder(ctx context.Context, q *gen.Queries, order gen.Order) error {
+	payments, err := q.ListPaymentsByOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	var captured int64
+	for _, payment := range payments {
+		switch payment.Status {
+		case PaymentStatusAuthorized, PaymentStatusCapturing:
+			_, err := q.UpdateOrderPaymentStatus(ctx, gen.UpdateOrderPaymentStatusParams{ID: order.ID, PaymentStatus: OrderPaymentAuthorized})
+			return err
+		case PaymentStatusCaptured:
+			captured += fromNumeric(payment.Amount)
+		}
+	}
+	if captured != fromNumeric(order.Total) {
+		return nil
+	}
+	if err := commitOrderReservations(ctx, q, order.ID); err != nil {
+		return err
+	}
+	if _, err := q.UpdateOrderStatus(ctx, gen.UpdateOrderStatusParams{ID: order.ID, Status: OrderStatusPaid}); err != nil {
+		return err
+	}
+	_, err = q.UpdateOrderPaymentStatus(ctx, gen.UpdateOrderPaymentStatusParams{ID: order.ID, PaymentStatus: OrderPaymentPaid})
+	if err != nil {
+		return err
+	}
+	return createReceipt(ctx, q, order.ID, pgtype.Int4{}, CalculationSale, captured)
+}
+
+// voidOrderTenders возвращает уже проведённые способы оплаты, когда карта по заказу не прошла
+func voidOrderTenders(ctx context.Context, q *gen.Queries, order gen.Order) error {
+	payments, err := q.ListPaymentsByOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		if payment.Status != PaymentStatusCaptured || payment.Tender == TenderCard {
+			continue
+		}
+		amount := fromNumeric(payment.Amount)
+		if err := returnToTender(ctx, q, order, payment, amount); err != nil {
+			return err
+		}
+		// Продажа не состоялась, поэтому платёж не попадает ни в чек, ни в отчёты
+		_, err := q.AddPaymentRefund(ctx, gen.AddPaymentRefundParams{
+			ID:             payment.ID,
+			RefundedAmount: toNumeric(amount),
+			Status:         PaymentStatusFailed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// Резервы держались на срок авторизации карты, теперь на оплату другим способом даётся обычный срок заказа
+	_, err = q.ExtendReservationsByOwner(ctx, gen.ExtendReservationsByOwnerParams{
+		Owner:     OrderReservationOwner(order.ID),
+		ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(OrderReservationTTL), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = q.UpdateOrderPaymentStatus(ctx, gen.UpdateOrderPaymentStatusParams{ID: order.ID, PaymentStatus: OrderPaymentFailed})
+	return err
+}
+
+// CapturePayment списывает авторизованную сумму по карте. Платёж сначала переводится в capturing и это
+// фиксируется в базе, потом идёт запрос к эквайеру, и только после его ответа заказ считается оплаченным.
+// Если ответ потерялся, повторный вызов отправит списание с тем же ключом идемпотентности.
+func (p PaymentService) CapturePayment(ctx context.Context, id int32) (PaymentDto, error) {
+	payment, err := p.beginCapture(ctx, id)
+	if err != nil {
+		return PaymentDto{}, err
+	}
+	_, err = p.Provider.Capture(ctx, payment.ExternalID, fromNumeric(payment.Amount), captureIdempotencyKey(payment.ID))
+	if errors.Is(err, PaymentDeclinedError) {
+		if _, err := p.finishCapture(ctx, payment.ID, PaymentStatusFailed); err != nil {
+			return PaymentDto{}, err
+		}
+		return PaymentDto{}, PaymentDeclinedError
+	} else if err != nil {
+		return PaymentDto{}, err
+	}
+	return p.finishCapture(ctx, payment.ID, PaymentStatusCaptured)
+}
+
+// beginCapture переводит платёж в capturing. Резервы проверяются заранее: если они истекли, деньги не списываем
+func (p PaymentService) beginCapture(ctx context.Context, id int32) (gen.Payment, error) {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return gen.Payment{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := p.Queries.WithTx(tx)
+
+	payment, err := q.GetPaymentForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Payment{}, PaymentNotFound
+		}
+		return gen.Payment{}, err
+	}
+	if payment.Status != PaymentStatusAuthorized && payment.Status != PaymentStatusCapturing {
+		return gen.Payment{}, PaymentStatusError
+	}
+	if _, err := q.GetOrderForUpdate(ctx, payment.OrderID); err != nil {
+		return gen.Payment{}, err
+	}
+	if _, err := activeOrderReservations(ctx, q, payment.OrderID); err != nil {
+		return gen.Payment{}, err
+	}
+	payment, err = q.UpdatePaymentStatus(ctx, gen.UpdatePaymentStatusParams{ID: payment.ID, Status: PaymentStatusCapturing})
+	if err != nil {
+		return gen.Payment{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return gen.Payment{}, err
+	}
+	return payment, nil
+}
+
+// finishCapture записывает ответ эквайера: списанный платёж проводит заказ, отклонённый возвращает остальные способы оплаты.
+// Если webhook успел раньше, платёж уже не в capturing и повторно не проводится.
+func (p PaymentService) finishCapture(ctx context.Context, id int32, status string) (PaymentDto, error) {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return PaymentDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := p.Queries.WithTx(tx)
+
+	payment, err := q.GetPaymentForUpdate(ctx, id)
+	if err != nil {
+		return PaymentDto{}, err
+	}
+	if payment.Status != PaymentStatusCapturing {
+		return ToPaymentDto(payment), nil
+	}
+	if err := p.applyPaymentStatus(ctx, q, &payment, status); err != nil {
+		return PaymentDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PaymentDto{}, err
+	}
+	return ToPaymentDto(payment), nil
+}
+
+// applyPaymentStatus записывает итог по карте и пересчитывает заказ
+func (p PaymentService) applyPaymentStatus(ctx context.Context, q *gen.Queries, payment *gen.Payment, status string) error {
+	order, err := q.GetOrderForUpdate(ctx, payment.OrderID)
+	if err != nil {
+		return err
+	}
+	*payment, err = q.UpdatePaymentStatus(ctx, gen.UpdatePaymentStatusParams{ID: payment.ID, Status: status})
+	if err != nil {
+		return err
+	}
+	if status == PaymentStatusCaptured {
+		return settleOrder(ctx, q, order)
+	}
+	return voidOrderTenders(ctx, q, order)
+}
+
+// HandleWebhook применяет асинхронное уведомление эквайера о статусе платежа
+func (p PaymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (PaymentDto, error) {
+	event, err := p.Provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return PaymentDto{}, err
+	}
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return PaymentDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := p.Queries.WithTx(tx)
+
+	payment, err := q.GetPaymentByExternalID(ctx, gen.GetPaymentByExternalIDParams{
+		Provider:   p.Provider.Name(),
+		ExternalID: event.ExternalId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PaymentDto{}, PaymentNotFound
+		}
+		return PaymentDto{}, err
+	}
+	if (payment.Status == PaymentStatusAuthorized || payment.Status == PaymentStatusCapturing) &&
+		(event.Status == PaymentStatusCaptured || event.Status == PaymentStatusFailed) {
+		if err := p.applyPaymentStatus(ctx, q, &payment, event.Status); err != nil {
+			return PaymentDto{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PaymentDto{}, err
+	}
+	return ToPaymentDto(payment), nil
+}
+
+// GetTenderReport возвращает суммы оплат и возвратов по способам оплаты за период [from, to)
+func (p PaymentService) GetTenderReport(ctx context.Context, from time.Time, to time.Time) ([]TenderReportDto, error) {
+	rows, err := p.Queries.PaymentTenderReport(ctx, gen.PaymentTenderReportParams{
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]TenderReportDto, len(rows))
+	for i, row := range rows {
+		response[i] = TenderReportDto{
+			Tender:   row.Tender,
+			Payments: row.Payments,
+			Amount:   row.Amount,
+			Refunded: row.Refunded,
+			Net:      row.Amount - row.Refunded,
+		}
+	}
+	return response, nil
+}
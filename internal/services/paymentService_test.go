@@ -0,0 +1,143 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateTenders(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenders []TenderDto
+		total   int64
+		want    error
+	}{
+		{"одна карта", []TenderDto{{Tender: TenderCard, Amount: 1000}}, 1000, nil},
+		{
+			name: "все способы вместе",
+			tenders: []TenderDto{
+				{Tender: TenderCash, Amount: 100},
+				{Tender: TenderCard, Amount: 500},
+				{Tender: TenderBalance, Amount: 200},
+				{Tender: TenderGiftCard, Amount: 150, GiftCardCode: "GC-1"},
+				{Tender: TenderGiftCard, Amount: 50, GiftCardCode: "GC-2"},
+			},
+			total: 1000,
+		},
+		{"сумма меньше заказа", []TenderDto{{Tender: TenderCash, Amount: 900}}, 1000, TenderTotalMismatchError},
+		{"сумма больше заказа", []TenderDto{{Tender: TenderCash, Amount: 600}, {Tender: TenderCard, Amount: 500}}, 1000, TenderTotalMismatchError},
+		{"неизвестный способ", []TenderDto{{Tender: "crypto", Amount: 1000}}, 1000, InvalidTenderError},
+		{"подарочная карта без кода", []TenderDto{{Tender: TenderGiftCard, Amount: 1000}}, 1000, InvalidTenderError},
+		{"повтор способа", []TenderDto{{Tender: TenderCash, Amount: 500}, {Tender: TenderCash, Amount: 500}}, 1000, InvalidTenderError},
+		{"повтор подарочной карты", []TenderDto{
+			{Tender: TenderGiftCard, Amount: 500, GiftCardCode: "GC-1"},
+			{Tender: TenderGiftCard, Amount: 500, GiftCardCode: "GC-1"},
+		}, 1000, InvalidTenderError},
+		{"нулевая сумма", []TenderDto{{Tender: TenderCash, Amount: 0}, {Tender: TenderCard, Amount: 1000}}, 1000, InvalidTenderError},
+		{"отрицательная сумма", []TenderDto{{Tender: TenderCash, Amount: -100}, {Tender: TenderCard, Amount: 1100}}, 1000, InvalidTenderError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateTenders(test.tenders, test.total); !errors.Is(err, test.want) {
+				t.Errorf("validateTenders = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+// tenderDB отвечает на блокировку баланса покупателя и подарочной карты, остальных запросов lockTender не делает
+type tenderDB struct {
+	gen.DBTX
+	balances  map[int32]int64
+	giftCards map[string]gen.GiftCard
+}
+
+type tenderRow struct {
+	values []any
+	err    error
+}
+
+func (r tenderRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, value := range r.values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (db tenderDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	switch {
+	case strings.Contains(sql, "name: GetCustomerBalanceForUpdate "):
+		if balance, ok := db.balances[args[0].(int32)]; ok {
+			return tenderRow{values: []any{toNumeric(balance)}}
+		}
+	case strings.Contains(sql, "name: GetGiftCardByCodeForUpdate "):
+		if card, ok := db.giftCards[args[0].(string)]; ok {
+			return tenderRow{values: []any{card.ID, card.Code, card.Balance, card.CreatedAt, card.IsAlive}}
+		}
+	}
+	return tenderRow{err: pgx.ErrNoRows}
+}
+
+func TestLockTender(t *testing.T) {
+	q := gen.New(tenderDB{
+		balances:  map[int32]int64{7: 500},
+		giftCards: map[string]gen.GiftCard{"GC-1": {ID: 3, Code: "GC-1", Balance: toNumeric(300), IsAlive: true}},
+	})
+	customer := gen.Order{ID: 1, CustomerID: pgtype.Int4{Int32: 7, Valid: true}}
+	tests := []struct {
+		name     string
+		order    gen.Order
+		tender   TenderDto
+		giftCard pgtype.Int4
+		wantErr  error
+	}{
+		{name: "наличные не блокируются", order: gen.Order{ID: 1}, tender: TenderDto{Tender: TenderCash, Amount: 1000}},
+		{name: "баланса хватает", order: customer, tender: TenderDto{Tender: TenderBalance, Amount: 500}},
+		{name: "баланса не хватает", order: customer, tender: TenderDto{Tender: TenderBalance, Amount: 501}, wantErr: InsufficientBalanceError},
+		{name: "баланс без покупателя", order: gen.Order{ID: 1}, tender: TenderDto{Tender: TenderBalance, Amount: 1}, wantErr: InvalidTenderError},
+		{
+			name:    "покупатель не найден",
+			order:   gen.Order{ID: 1, CustomerID: pgtype.Int4{Int32: 8, Valid: true}},
+			tender:  TenderDto{Tender: TenderBalance, Amount: 1},
+			wantErr: InvalidTenderError,
+		},
+		{
+			name:     "подарочной карты хватает",
+			order:    customer,
+			tender:   TenderDto{Tender: TenderGiftCard, Amount: 300, GiftCardCode: "GC-1"},
+			giftCard: pgtype.Int4{Int32: 3, Valid: true},
+		},
+		{
+			name:    "подарочной карты не хватает",
+			order:   customer,
+			tender:  TenderDto{Tender: TenderGiftCard, Amount: 301, GiftCardCode: "GC-1"},
+			wantErr: InsufficientGiftCardBalanceError,
+		},
+		{
+			name:    "подарочная карта не найдена",
+			order:   customer,
+			tender:  TenderDto{Tender: TenderGiftCard, Amount: 1, GiftCardCode: "GC-2"},
+			wantErr: GiftCardNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			giftCard, err := lockTender(context.Background(), q, test.order, test.tender)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("err = %v, want %v", err, test.wantErr)
+			}
+			if giftCard != test.giftCard {
+				t.Errorf("gift card = %+v, want %+v", giftCard, test.giftCard)
+			}
+		})
+	}
+}
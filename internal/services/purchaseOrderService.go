@@ -0,0 +1,294 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+type PurchaseOrderItemDto struct {
+	Id       int32 `json:"id"`
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+	// Закупочная цена единицы, null — цена не согласована
+	UnitCost *int64 `json:"unit_cost"`
+	Amount   int64  `json:"amount"`
+}
+
+type PurchaseOrderDto struct {
+	Id         int32                  `json:"id"`
+	SupplierId int32                  `json:"supplier_id"`
+	StoreId    int32                  `json:"store_id"`
+	Status     string                 `json:"status"`
+	Comment    string                 `json:"comment,omitempty"`
+	CreatedBy  *int32                 `json:"created_by"`
+	CreatedAt  time.Time              `json:"created_at"`
+	SentAt     *time.Time             `json:"sent_at"`
+	ExpectedAt *time.Time             `json:"expected_at"`
+	Total      int64                  `json:"total"`
+	Items      []PurchaseOrderItemDto `json:"items"`
+}
+
+type CreatePurchaseOrderItemDto struct {
+	GoodId   int32  `json:"good_id"`
+	Quantity int32  `json:"quantity"`
+	UnitCost *int64 `json:"unit_cost"`
+}
+
+type CreatePurchaseOrderDto struct {
+	SupplierId int32 `json:"supplier_id"`
+	// Магазин, в который поставщик привезёт товар
+	StoreId    int32                        `json:"store_id"`
+	EmployeeId *int32                       `json:"employee_id"`
+	Comment    string                       `json:"comment"`
+	Items      []CreatePurchaseOrderItemDto `json:"items"`
+}
+
+type SendPurchaseOrderDto struct {
+	// Ожидаемая дата поставки, YYYY-MM-DD
+	ExpectedAt string `json:"expected_at"`
+}
+
+type PurchaseOrderInterface interface {
+	CreatePurchaseOrder(ctx context.Context, dto CreatePurchaseOrderDto) (PurchaseOrderDto, error)
+	GetPurchaseOrder(ctx context.Context, id int32) (PurchaseOrderDto, error)
+	GetPurchaseOrders(ctx context.Context, supplierId *int32, status string) ([]PurchaseOrderDto, error)
+	SendPurchaseOrder(ctx context.Context, id int32, dto SendPurchaseOrderDto) (PurchaseOrderDto, error)
+	CancelPurchaseOrder(ctx context.Context, id int32) (PurchaseOrderDto, error)
+}
+
+type PurchaseOrderService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var PurchaseOrderNotFound = errors.New("purchase order not found")
+var InvalidPurchaseOrderError = errors.New("purchase order must contain goods with positive quantities")
+var PurchaseOrderStatusError = errors.New("purchase order status does not allow this operation")
+
+func ToPurchaseOrderDto(order gen.PurchaseOrder, items []gen.PurchaseOrderItem) PurchaseOrderDto {
+	response := PurchaseOrderDto{
+		Id:         order.ID,
+		SupplierId: order.SupplierID,
+		StoreId:    order.StoreID,
+		Status:     order.Status,
+		Comment:    order.Comment.String,
+		CreatedBy:  fromInt4(order.CreatedBy),
+		CreatedAt:  order.CreatedAt.Time,
+		SentAt:     timestampPtr(order.SentAt),
+		ExpectedAt: timestampPtr(order.ExpectedAt),
+		Items:      make([]PurchaseOrderItemDto, len(items)),
+	}
+	for i, item := range items {
+		amount := fromNumeric(item.UnitCost) * int64(item.Quantity)
+		response.Items[i] = PurchaseOrderItemDto{
+			Id:       item.ID,
+			GoodId:   item.GoodID,
+			Quantity: item.Quantity,
+			UnitCost: fromOptionalNumeric(item.UnitCost),
+			Amount:   amount,
+		}
+		response.Total += amount
+	}
+	return response
+}
+
+// createPurchaseOrder создаёт черновик заказа поставщику, проверив поставщика, магазин и товары
+func createPurchaseOrder(ctx context.Context, q *gen.Queries, dto CreatePurchaseOrderDto) (PurchaseOrderDto, error) {
+	if len(dto.Items) == 0 {
+		return PurchaseOrderDto{}, InvalidPurchaseOrderError
+	}
+	if _, err := q.GetSupplier(ctx, dto.SupplierId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PurchaseOrderDto{}, SupplierNotFoundError
+		}
+		return PurchaseOrderDto{}, err
+	}
+	if _, err := q.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PurchaseOrderDto{}, StoreNotFound
+		}
+		return PurchaseOrderDto{}, err
+	}
+	order, err := q.CreatePurchaseOrder(ctx, gen.CreatePurchaseOrderParams{
+		SupplierID: dto.SupplierId,
+		StoreID:    dto.StoreId,
+		Status:     PurchaseOrderStatusDraft,
+		Comment:    pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		CreatedBy:  toInt4(dto.EmployeeId),
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	items := make([]gen.PurchaseOrderItem, len(dto.Items))
+	for i, item := range dto.Items {
+		if item.Quantity <= 0 {
+			return PurchaseOrderDto{}, InvalidPurchaseOrderError
+		}
+		if _, err := q.GetGood(ctx, item.GoodId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return PurchaseOrderDto{}, ProductNotFound
+			}
+			return PurchaseOrderDto{}, err
+		}
+		items[i], err = q.CreatePurchaseOrderItem(ctx, gen.CreatePurchaseOrderItemParams{
+			PurchaseOrderID: order.ID,
+			GoodID:          item.GoodId,
+			Quantity:        item.Quantity,
+			UnitCost:        toOptionalNumeric(item.UnitCost),
+		})
+		if err != nil {
+			return PurchaseOrderDto{}, err
+		}
+	}
+	return ToPurchaseOrderDto(order, items), nil
+}
+
+func (s PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, dto CreatePurchaseOrderDto) (PurchaseOrderDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	response, err := createPurchaseOrder(ctx, s.Queries.WithTx(tx), dto)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	return response, nil
+}
+
+func (s PurchaseOrderService) GetPurchaseOrder(ctx context.Context, id int32) (PurchaseOrderDto, error) {
+	order, err := s.Queries.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PurchaseOrderDto{}, PurchaseOrderNotFound
+		}
+		return PurchaseOrderDto{}, err
+	}
+	items, err := s.Queries.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	return ToPurchaseOrderDto(order, items), nil
+}
+
+func (s PurchaseOrderService) GetPurchaseOrders(ctx context.Context, supplierId *int32, status string) ([]PurchaseOrderDto, error) {
+	orders, err := s.Queries.ListPurchaseOrders(ctx, gen.ListPurchaseOrdersParams{
+		SupplierID: toInt4(supplierId),
+		Status:     pgtype.Text{String: status, Valid: status != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]PurchaseOrderDto, len(orders))
+	for i, order := range orders {
+		items, err := s.Queries.ListPurchaseOrderItems(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		response[i] = ToPurchaseOrderDto(order, items)
+	}
+	return response, nil
+}
+
+func lockPurchaseOrder(ctx context.Context, q *gen.Queries, id int32, status string) (gen.PurchaseOrder, error) {
+	order, err := q.GetPurchaseOrderForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.PurchaseOrder{}, PurchaseOrderNotFound
+		}
+		return gen.PurchaseOrder{}, err
+	}
+	if order.Status != status {
+		return gen.PurchaseOrder{}, PurchaseOrderStatusError
+	}
+	return order, nil
+}
+
+// SendPurchaseOrder отправляет черновик поставщику, после этого заказ нельзя менять
+func (s PurchaseOrderService) SendPurchaseOrder(ctx context.Context, id int32, dto SendPurchaseOrderDto) (PurchaseOrderDto, error) {
+	expectedAt := pgtype.Timestamp{}
+	if dto.ExpectedAt != "" {
+		date, err := parseDate(dto.ExpectedAt)
+		if err != nil {
+			return PurchaseOrderDto{}, err
+		}
+		expectedAt = pgtype.Timestamp{Time: date.Time, Valid: true}
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := lockPurchaseOrder(ctx, q, id, PurchaseOrderStatusDraft); err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	order, err := q.SendPurchaseOrder(ctx, gen.SendPurchaseOrderParams{
+		ID:         id,
+		Status:     PurchaseOrderStatusSent,
+		SentAt:     pgtype.Timestamp{Time: time.Now(), Valid: true},
+		ExpectedAt: expectedAt,
+	})
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	items, err := q.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	return ToPurchaseOrderDto(order, items), nil
+}
+
+// CancelPurchaseOrder отменяет черновик или отправленный заказ, товар из него перестаёт считаться заказанным
+func (s PurchaseOrderService) CancelPurchaseOrder(ctx context.Context, id int32) (PurchaseOrderDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	order, err := q.GetPurchaseOrderForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return PurchaseOrderDto{}, PurchaseOrderNotFound
+		}
+		return PurchaseOrderDto{}, err
+	}
+	if order.Status != PurchaseOrderStatusDraft && order.Status != PurchaseOrderStatusSent {
+		return PurchaseOrderDto{}, PurchaseOrderStatusError
+	}
+	order, err = q.UpdatePurchaseOrderStatus(ctx, gen.UpdatePurchaseOrderStatusParams{ID: id, Status: PurchaseOrderStatusCancelled})
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	items, err := q.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	return ToPurchaseOrderDto(order, items), nil
+}
@@ -0,0 +1,511 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"time"
+)
+
+const (
+	ReceiptStatusPending     = "pending"
+	ReceiptStatusRegistering = "registering" // документ отправлен в кассу, ответ ещё не записан
+	ReceiptStatusRegistered  = "registered"
+	ReceiptStatusFailed      = "failed"
+
+	// Фискальный статус заказа, по которому чек продажи ещё не формировался
+	OrderFiscalNone = "none"
+)
+
+type ReceiptDto struct {
+	Id                   int32           `json:"id"`
+	OrderId              int32           `json:"order_id"`
+	RefundId             *int32          `json:"refund_id,omitempty"`
+	CalculationType      int32           `json:"calculation_type"`
+	Total                int64           `json:"total"`
+	Status               string          `json:"status"`
+	Document             json.RawMessage `json:"document,omitempty" swaggertype:"object"`
+	FiscalDocumentNumber *int32          `json:"fiscal_document_number,omitempty"`
+	FiscalSign           string          `json:"fiscal_sign,omitempty"`
+	FnNumber             string          `json:"fn_number,omitempty"`
+	Error                string          `json:"error,omitempty"`
+	CreatedAt            time.Time       `json:"created_at"`
+	RegisteredAt         *time.Time      `json:"registered_at,omitempty"`
+}
+
+// FiscalOrganization — реквизиты продавца, которые печатаются в каждом чеке
+type FiscalOrganization struct {
+	Name      string
+	Inn       string
+	TaxSystem int32
+}
+
+type ReceiptInterface interface {
+	GetReceipt(ctx context.Context, id int32) (ReceiptDto, error)
+	GetOrderReceipts(ctx context.Context, orderId int32) ([]ReceiptDto, error)
+	RegisterReceipt(ctx context.Context, id int32) (ReceiptDto, error)
+	RegisterPending(ctx context.Context) (int, error)
+}
+
+type ReceiptService struct {
+	Queries      gen.Queries
+	DB           *pgxpool.Pool
+	Registrar    FiscalRegistrar
+	Organization FiscalOrganization
+}
+
+var ReceiptNotFound = errors.New("receipt not found")
+var ReceiptStatusError = errors.New("receipt is already registered")
+
+func ToReceiptDto(receipt gen.Receipt) ReceiptDto {
+	response := ReceiptDto{
+		Id:              receipt.ID,
+		OrderId:         receipt.OrderID,
+		CalculationType: receipt.CalculationType,
+		Total:           fromNumeric(receipt.Total),
+		Status:          receipt.Status,
+		FiscalSign:      receipt.FiscalSign.String,
+		FnNumber:        receipt.FnNumber.String,
+		Error:           receipt.Error.String,
+		CreatedAt:       receipt.CreatedAt.Time,
+	}
+	if receipt.RefundID.Valid {
+		refundId := receipt.RefundID.Int32
+		response.RefundId = &refundId
+	}
+	if receipt.Document.Valid {
+		response.Document = json.RawMessage(receipt.Document.String)
+	}
+	if receipt.FiscalDocumentNumber.Valid {
+		number := receipt.FiscalDocumentNumber.Int32
+		response.FiscalDocumentNumber = &number
+	}
+	if receipt.RegisteredAt.Valid {
+		registeredAt := receipt.RegisteredAt.Time
+		response.RegisteredAt = &registeredAt
+	}
+	return response
+}
+
+// createReceipt ставит чек в очередь на регистрацию. Фискальный статус заказа определяет чек продажи,
+// чеки возврата видны только в списке чеков заказа.
+func createReceipt(ctx context.Context, q *gen.Queries, orderId int32, refundId pgtype.Int4, calculationType int32, total int64) error {
+	_, err := q.CreateReceipt(ctx, gen.CreateReceiptParams{
+		OrderID:         orderId,
+		RefundID:        refundId,
+		CalculationType: calculationType,
+		Total:           toNumeric(total),
+		Status:          ReceiptStatusPending,
+		CreatedAt:       pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if calculationType == CalculationSale {
+		_, err = q.UpdateOrderFiscal(ctx, gen.UpdateOrderFiscalParams{ID: orderId, FiscalStatus: ReceiptStatusPending})
+	}
+	return err
+}
+
+// kopecks переводит сумму из единиц API в копейки, в которых считает ФН
+func kopecks(amount int64) int64 {
+	return amount * 100
+}
+
+func addFiscalItem(document *FiscalDocument, name string, price int64, quantity int32, vatRate pgtype.Int4, itemType int32) {
+	addFiscalItemKopecks(document, name, kopecks(price), quantity, vatRate, itemType)
+}
+
+// addFiscalItemKopecks добавляет предмет расчета с ценой в копейках
+func addFiscalItemKopecks(document *FiscalDocument, name string, price int64, quantity int32, vatRate pgtype.Int4, itemType int32) {
+	amount := price * int64(quantity)
+	vat := vatAmount(amount, vatRate)
+	vatCode := fiscalVatCode(vatRate)
+	document.Items = append(document.Items, FiscalItem{
+		Name:          name,
+		Price:         price,
+		Quantity:      quantity,
+		MeasureUnit:   FiscalMeasurePiece,
+		Amount:        amount,
+		VatCode:       vatCode,
+		VatAmount:     vat,
+		ItemType:      itemType,
+		PaymentMethod: FiscalFullPayment,
+	})
+	document.Total += amount
+	switch vatCode {
+	case FiscalVat20:
+		document.Vat20 += vat
+	case FiscalVat10:
+		document.Vat10 += vat
+	case FiscalVat0:
+		document.AmountVat0 += amount
+	case FiscalVatNone:
+		document.AmountVatNone += amount
+	}
+}
+
+// addFiscalPayment раскладывает оплату по видам: баланс и подарочные карты — это зачёт ранее внесённых средств
+func addFiscalPayment(document *FiscalDocument, tender string, amount int64) {
+	switch tender {
+	case TenderCash:
+		document.Cash += kopecks(amount)
+	case TenderCard:
+		document.Electronic += kopecks(amount)
+	case TenderBalance, TenderGiftCard:
+		document.Prepaid += kopecks(amount)
+	}
+}
+
+// vatShare — сумма в копейках, приходящаяся на одну ставку НДС
+type vatShare struct {
+	VatRate pgtype.Int4
+	Amount  int64
+}
+
+// splitByVatRate делит сумму между ставками НДС пропорционально строкам заказа. Остаток от округления
+// достаётся последней ставке, поэтому части в сумме всегда дают amount. Ставки идут в порядке строк заказа.
+func splitByVatRate(amount int64, lines []vatShare) []vatShare {
+	var bases []vatShare
+	var total int64
+	for _, line := range lines {
+		total += line.Amount
+		found := false
+		for i := range bases {
+			if bases[i].VatRate == line.VatRate {
+				bases[i].Amount += line.Amount
+				found = true
+				break
+			}
+		}
+		if !found {
+			bases = append(bases, line)
+		}
+	}
+	if total <= 0 {
+		return []vatShare{{VatRate: pgtype.Int4{Int32: DefaultVatRate, Valid: true}, Amount: amount}}
+	}
+	shares := make([]vatShare, 0, len(bases))
+	remaining := amount
+	for i, base := range bases {
+		part := remaining
+		if i < len(bases)-1 {
+			part = amount * base.Amount / total
+		}
+		remaining -= part
+		if part > 0 {
+			shares = append(shares, vatShare{VatRate: base.VatRate, Amount: part})
+		}
+	}
+	return shares
+}
+
+// orderVatLines возвращает строки заказа — товары и услуги — как суммы в копейках по ставкам НДС
+func orderVatLines(ctx context.Context, q *gen.Queries, orderId int32) ([]vatShare, error) {
+	items, err := q.ListOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	orderServices, err := q.ListOrderServices(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]vatShare, 0, len(items)+len(orderServices))
+	for _, item := range items {
+		lines = append(lines, vatShare{VatRate: item.VatRate, Amount: kopecks(fromNumeric(item.Price)) * int64(item.Quantity)})
+	}
+	for _, service := range orderServices {
+		lines = append(lines, vatShare{VatRate: service.VatRate, Amount: kopecks(fromNumeric(service.Price)) * int64(service.Quantity)})
+	}
+	return lines, nil
+}
+
+func addFiscalGood(ctx context.Context, q *gen.Queries, document *FiscalDocument, goodId int32, price pgtype.Numeric, quantity int32, vatRate pgtype.Int4) error {
+	good, err := q.GetGood(ctx, goodId)
+	if err != nil {
+		return err
+	}
+	addFiscalItem(document, good.Name, fromNumeric(price), quantity, vatRate, FiscalItemGood)
+	return nil
+}
+
+// buildDocument собирает чек ФФД 1.2 по заказу или возврату
+func (r ReceiptService) buildDocument(ctx context.Context, q *gen.Queries, receipt gen.Receipt) (FiscalDocument, error) {
+	order, err := q.GetOrder(ctx, receipt.OrderID)
+	if err != nil {
+		return FiscalDocument{}, err
+	}
+	store, err := q.GetStore(ctx, order.StoreID)
+	if err != nil {
+		return FiscalDocument{}, err
+	}
+	document := FiscalDocument{
+		CalculationType: receipt.CalculationType,
+		TaxSystem:       r.Organization.TaxSystem,
+		UserName:        r.Organization.Name,
+		UserInn:         r.Organization.Inn,
+		Address:         store.Address,
+		Place:           store.Address,
+		Items:           []FiscalItem{},
+	}
+
+	if !receipt.RefundID.Valid {
+		items, err := q.ListOrderItems(ctx, order.ID)
+		if err != nil {
+			return FiscalDocument{}, err
+		}
+		for _, item := range items {
+			if err := addFiscalGood(ctx, q, &document, item.GoodID, item.Price, item.Quantity, item.VatRate); err != nil {
+				return FiscalDocument{}, err
+			}
+		}
+		lines, err := q.ListOrderServices(ctx, order.ID)
+		if err != nil {
+			return FiscalDocument{}, err
+		}
+		for _, line := range lines {
+			service, err := q.GetService(ctx, line.ServiceID)
+			if err != nil {
+				return FiscalDocument{}, err
+			}
+			addFiscalItem(&document, service.Name, fromNumeric(line.Price), line.Quantity, line.VatRate, FiscalItemService)
+		}
+		payments, err := q.ListPaymentsByOrder(ctx, order.ID)
+		if err != nil {
+			return FiscalDocument{}, err
+		}
+		for _, payment := range payments {
+			if payment.Status != PaymentStatusFailed {
+				addFiscalPayment(&document, payment.Tender, fromNumeric(payment.Amount))
+			}
+		}
+		return document, nil
+	}
+
+	items, err := q.ListRefundItems(ctx, receipt.RefundID.Int32)
+	if err != nil {
+		return FiscalDocument{}, err
+	}
+	for _, item := range items {
+		if err := addFiscalGood(ctx, q, &document, item.GoodID, item.Price, item.Quantity, item.VatRate); err != nil {
+			return FiscalDocument{}, err
+		}
+	}
+	if len(items) == 0 {
+		// Возврат суммы без товаров — компенсация: пробивается как платёж, по позиции на каждую ставку НДС заказа,
+		// чтобы возвращённый НДС делился между ставками так же, как в чеке продажи
+		lines, err := orderVatLines(ctx, q, order.ID)
+		if err != nil {
+			return FiscalDocument{}, err
+		}
+		name := fmt.Sprintf("Возврат по заказу №%d", order.ID)
+		for _, share := range splitByVatRate(kopecks(fromNumeric(receipt.Total)), lines) {
+			addFiscalItemKopecks(&document, name, share.Amount, 1, share.VatRate, FiscalItemPayment)
+		}
+	}
+	tenders, err := q.ListRefundTenders(ctx, receipt.RefundID.Int32)
+	if err != nil {
+		return FiscalDocument{}, err
+	}
+	for _, tender := range tenders {
+		addFiscalPayment(&document, tender.Tender, fromNumeric(tender.Amount))
+	}
+	return document, nil
+}
+
+// receiptIdempotencyKey — ключ регистрации чека в кассе, одинаковый для всех повторов
+func receiptIdempotencyKey(receiptId int32) string {
+	return fmt.Sprintf("receipt-%d", receiptId)
+}
+
+// RegisterReceipt отправляет чек в фискальный регистратор. Перед отправкой чек переводится в registering
+// вместе с документом и это фиксируется в базе, поэтому касса вызывается вне транзакции. Если касса недоступна
+// или ответ потерялся, чек остаётся в очереди и при повторе уходит тот же документ с тем же ключом идемпотентности.
+// Если касса отклонила документ — чек помечается ошибочным.
+func (r ReceiptService) RegisterReceipt(ctx context.Context, id int32) (ReceiptDto, error) {
+	receipt, document, err := r.beginRegistration(ctx, id)
+	if err != nil {
+		return ReceiptDto{}, err
+	}
+	result, registerErr := r.Registrar.Register(ctx, document, receiptIdempotencyKey(receipt.ID))
+	if registerErr != nil && !errors.Is(registerErr, FiscalDocumentRejectedError) {
+		return ReceiptDto{}, registerErr
+	}
+	return r.finishRegistration(ctx, receipt.ID, result, registerErr)
+}
+
+// beginRegistration переводит чек в registering. Документ собирается один раз, повторы берут сохранённый
+func (r ReceiptService) beginRegistration(ctx context.Context, id int32) (gen.Receipt, FiscalDocument, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.Queries.WithTx(tx)
+
+	receipt, err := q.GetReceiptForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Receipt{}, FiscalDocument{}, ReceiptNotFound
+		}
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	if receipt.Status == ReceiptStatusRegistered {
+		return gen.Receipt{}, FiscalDocument{}, ReceiptStatusError
+	}
+	var document FiscalDocument
+	if receipt.Status == ReceiptStatusRegistering && receipt.Document.Valid {
+		if err := json.Unmarshal([]byte(receipt.Document.String), &document); err != nil {
+			return gen.Receipt{}, FiscalDocument{}, err
+		}
+		return receipt, document, nil
+	}
+	document, err = r.buildDocument(ctx, q, receipt)
+	if err != nil {
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	payload, err := json.Marshal(document)
+	if err != nil {
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	receipt, err = q.MarkReceiptRegistering(ctx, gen.MarkReceiptRegisteringParams{
+		ID:       receipt.ID,
+		Document: pgtype.Text{String: string(payload), Valid: true},
+	})
+	if err != nil {
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return gen.Receipt{}, FiscalDocument{}, err
+	}
+	return receipt, document, nil
+}
+
+// finishRegistration записывает ответ кассы. Если чек уже не в registering, его успел записать другой вызов
+func (r ReceiptService) finishRegistration(ctx context.Context, id int32, result FiscalResult, registerErr error) (ReceiptDto, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return ReceiptDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.Queries.WithTx(tx)
+
+	receipt, err := q.GetReceiptForUpdate(ctx, id)
+	if err != nil {
+		return ReceiptDto{}, err
+	}
+	if receipt.Status != ReceiptStatusRegistering {
+		return ToReceiptDto(receipt), nil
+	}
+	if registerErr != nil {
+		receipt, err = q.MarkReceiptFailed(ctx, gen.MarkReceiptFailedParams{
+			ID:       receipt.ID,
+			Status:   ReceiptStatusFailed,
+			Document: receipt.Document,
+			Error:    pgtype.Text{String: registerErr.Error(), Valid: true},
+		})
+	} else {
+		receipt, err = q.MarkReceiptRegistered(ctx, gen.MarkReceiptRegisteredParams{
+			ID:                   receipt.ID,
+			Status:               ReceiptStatusRegistered,
+			Document:             receipt.Document,
+			FiscalDocumentNumber: pgtype.Int4{Int32: result.FiscalDocumentNumber, Valid: true},
+			FiscalSign:           pgtype.Text{String: result.FiscalSign, Valid: true},
+			FnNumber:             pgtype.Text{String: result.FnNumber, Valid: true},
+			RegisteredAt:         pgtype.Timestamp{Time: result.DateTime, Valid: true},
+		})
+	}
+	if err != nil {
+		return ReceiptDto{}, err
+	}
+	if receipt.CalculationType == CalculationSale {
+		_, err = q.UpdateOrderFiscal(ctx, gen.UpdateOrderFiscalParams{
+			ID:           receipt.OrderID,
+			FiscalStatus: receipt.Status,
+			FiscalSign:   receipt.FiscalSign,
+		})
+		if err != nil {
+			return ReceiptDto{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ReceiptDto{}, err
+	}
+	return ToReceiptDto(receipt), nil
+}
+
+// RegisterPending отправляет в регистратор все чеки из очереди и возвращает число зарегистрированных.
+// Чек, который не удалось отправить, остаётся в очереди до следующего прохода и не задерживает остальные
+func (r ReceiptService) RegisterPending(ctx context.Context) (int, error) {
+	receipts, err := r.Queries.ListPendingReceipts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	registered := 0
+	for _, receipt := range receipts {
+		if ctx.Err() != nil {
+			return registered, ctx.Err()
+		}
+		response, err := r.RegisterReceipt(ctx, receipt.ID)
+		if err != nil {
+			log.Printf("receipt worker: receipt %d: %v", receipt.ID, err)
+			continue
+		}
+		if response.Status == ReceiptStatusRegistered {
+			registered++
+		}
+	}
+	return registered, nil
+}
+
+// StartWorker в фоне регистрирует чеки из очереди, пока не отменён ctx
+func (r ReceiptService) StartWorker(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				registered, err := r.RegisterPending(ctx)
+				if err != nil {
+					log.Printf("receipt worker: %v", err)
+					continue
+				}
+				if registered > 0 {
+					log.Printf("receipt worker: registered %d receipts", registered)
+				}
+			}
+		}
+	}()
+}
+
+func (r ReceiptService) GetReceipt(ctx context.Context, id int32) (ReceiptDto, error) {
+	receipt, err := r.Queries.GetReceipt(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReceiptDto{}, ReceiptNotFound
+		}
+		return ReceiptDto{}, err
+	}
+	return ToReceiptDto(receipt), nil
+}
+
+func (r ReceiptService) GetOrderReceipts(ctx context.Context, orderId int32) ([]ReceiptDto, error) {
+	receipts, err := r.Queries.ListReceiptsByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ReceiptDto, len(receipts))
+	for i, receipt := range receipts {
+		response[i] = ToReceiptDto(receipt)
+	}
+	return response, nil
+}
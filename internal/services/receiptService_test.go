@@ -0,0 +1,63 @@
+package services
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"reflect"
+	"testing"
+)
+
+func TestSplitByVatRate(t *testing.T) {
+	vat20 := pgtype.Int4{Int32: 20, Valid: true}
+	vat10 := pgtype.Int4{Int32: 10, Valid: true}
+	noVat := pgtype.Int4{}
+	tests := []struct {
+		name   string
+		amount int64
+		lines  []vatShare
+		want   []vatShare
+	}{
+		{
+			name:   "одна ставка",
+			amount: 50000,
+			lines:  []vatShare{{vat20, 100000}, {vat20, 30000}},
+			want:   []vatShare{{vat20, 50000}},
+		},
+		{
+			name:   "пропорционально строкам",
+			amount: 40000,
+			lines:  []vatShare{{vat20, 300000}, {vat10, 100000}},
+			want:   []vatShare{{vat20, 30000}, {vat10, 10000}},
+		},
+		{
+			name:   "остаток округления на последней ставке",
+			amount: 100,
+			lines:  []vatShare{{vat20, 100}, {vat10, 100}, {noVat, 100}},
+			want:   []vatShare{{vat20, 33}, {vat10, 33}, {noVat, 34}},
+		},
+		{
+			name:   "строки одной ставки собираются вместе",
+			amount: 9000,
+			lines:  []vatShare{{vat10, 1000}, {vat20, 1000}, {vat10, 1000}},
+			want:   []vatShare{{vat10, 6000}, {vat20, 3000}},
+		},
+		{
+			name:   "нулевая доля пропускается",
+			amount: 1,
+			lines:  []vatShare{{vat20, 100}, {vat10, 100000}},
+			want:   []vatShare{{vat10, 1}},
+		},
+		{
+			name:   "без строк — ставка по умолчанию",
+			amount: 700,
+			want:   []vatShare{{vat20, 700}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := splitByVatRate(test.amount, test.lines)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("splitByVatRate = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
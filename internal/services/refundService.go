@@ -0,0 +1,427 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"time"
+)
+
+// Возвращённый товар снова попадает на склад
+const StockAdjustmentRefund = "refund"
+
+const (
+	// Возврат на карту записан, но эквайер его ещё не подтвердил
+	RefundTenderPending   = "pending"
+	RefundTenderCompleted = "completed"
+)
+
+type RefundItemDto struct {
+	GoodId    int32  `json:"good_id"`
+	Quantity  int32  `json:"quantity"`
+	Price     int64  `json:"price"`
+	Amount    int64  `json:"amount"`
+	VatRate   *int32 `json:"vat_rate"`
+	VatAmount int64  `json:"vat_amount"`
+}
+
+type RefundTenderDto struct {
+	PaymentId int32  `json:"payment_id"`
+	Tender    string `json:"tender"`
+	Amount    int64  `json:"amount"`
+	// pending — возврат на карту ещё не подтверждён эквайером и будет повторён, completed — деньги возвращены
+	Status string `json:"status"`
+}
+
+type RefundDto struct {
+	Id        int32             `json:"id"`
+	OrderId   int32             `json:"order_id"`
+	Amount    int64             `json:"amount"`
+	Items     []RefundItemDto   `json:"items"`
+	Tenders   []RefundTenderDto `json:"tenders"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type CreateRefundItemDto struct {
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+type CreateRefundDto struct {
+	OrderId int32 `json:"order_id"`
+	// Возвращаемые товары: сумма считается по ценам заказа, товары возвращаются на склад
+	Items []CreateRefundItemDto `json:"items"`
+	// Сумма возврата без товаров, например компенсация. Игнорируется, если указаны товары
+	Amount int64 `json:"amount"`
+}
+
+type RefundInterface interface {
+	CreateRefund(ctx context.Context, dto CreateRefundDto) (RefundDto, error)
+	GetRefund(ctx context.Context, id int32) (RefundDto, error)
+	GetOrderRefunds(ctx context.Context, orderId int32) ([]RefundDto, error)
+}
+
+type RefundService struct {
+	Queries  gen.Queries
+	DB       *pgxpool.Pool
+	Provider PaymentProvider
+}
+
+var RefundNotFound = errors.New("refund not found")
+var InvalidRefundAmountError = errors.New("invalid refund amount")
+var RefundQuantityError = errors.New("refund quantity exceeds purchased quantity")
+
+// Порядок, в котором возврат раскладывается по способам оплаты: наличные выдаются в последнюю очередь
+var refundTenderOrder = []string{TenderCard, TenderGiftCard, TenderBalance, TenderCash}
+
+func loadRefund(ctx context.Context, q *gen.Queries, refund gen.Refund) (RefundDto, error) {
+	items, err := q.ListRefundItems(ctx, refund.ID)
+	if err != nil {
+		return RefundDto{}, err
+	}
+	tenders, err := q.ListRefundTenders(ctx, refund.ID)
+	if err != nil {
+		return RefundDto{}, err
+	}
+	response := RefundDto{
+		Id:        refund.ID,
+		OrderId:   refund.OrderID,
+		Amount:    fromNumeric(refund.Amount),
+		Items:     make([]RefundItemDto, len(items)),
+		Tenders:   make([]RefundTenderDto, len(tenders)),
+		CreatedAt: refund.CreatedAt.Time,
+	}
+	for i, item := range items {
+		price := fromNumeric(item.Price)
+		amount := price * int64(item.Quantity)
+		response.Items[i] = RefundItemDto{
+			GoodId:    item.GoodID,
+			Quantity:  item.Quantity,
+			Price:     price,
+			Amount:    amount,
+			VatRate:   fromInt4(item.VatRate),
+			VatAmount: vatAmount(kopecks(amount), item.VatRate),
+		}
+	}
+	for i, tender := range tenders {
+		response.Tenders[i] = RefundTenderDto{
+			PaymentId: tender.PaymentID,
+			Tender:    tender.Tender,
+			Amount:    fromNumeric(tender.Amount),
+			Status:    tender.Status,
+		}
+	}
+	return response, nil
+}
+
+// refundLines сверяет возвращаемые товары с заказом и прошлыми возвратами и подставляет цены и ставки НДС из заказа
+func refundLines(ctx context.Context, q *gen.Queries, orderId int32, items []CreateRefundItemDto) ([]gen.CreateRefundItemParams, error) {
+	orderItems, err := q.ListOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	refunded, err := q.ListRefundedQuantities(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return checkRefundLines(items, orderItems, refunded)
+}
+
+// checkRefundLines не даёт вернуть больше, чем куплено, с учётом прошлых возвратов и повторов товара в запросе
+func checkRefundLines(items []CreateRefundItemDto, orderItems []gen.OrderItem, refunded []gen.ListRefundedQuantitiesRow) ([]gen.CreateRefundItemParams, error) {
+	purchased := map[int32]gen.OrderItem{}
+	for _, item := range orderItems {
+		purchased[item.GoodID] = item
+	}
+	returned := map[int32]int32{}
+	for _, row := range refunded {
+		returned[row.GoodID] = row.Quantity
+	}
+	lines := make([]gen.CreateRefundItemParams, len(items))
+	for i, item := range items {
+		if item.Quantity <= 0 {
+			return nil, InvalidQuantityError
+		}
+		orderItem, ok := purchased[item.GoodId]
+		if !ok || returned[item.GoodId]+item.Quantity > orderItem.Quantity {
+			return nil, RefundQuantityError
+		}
+		returned[item.GoodId] += item.Quantity
+		lines[i] = gen.CreateRefundItemParams{
+			GoodID:   item.GoodId,
+			Quantity: item.Quantity,
+			Price:    orderItem.Price,
+			VatRate:  orderItem.VatRate,
+		}
+	}
+	return lines, nil
+}
+
+// refundIdempotencyKey — ключ возврата на карту, одинаковый для всех повторов
+func refundIdempotencyKey(tenderId int32) string {
+	return fmt.Sprintf("refund-tender-%d", tenderId)
+}
+
+// refundAllocation — часть возврата, которая приходится на один платёж, и новый статус платежа
+type refundAllocation struct {
+	payment gen.Payment
+	amount  int64
+	status  string
+}
+
+// refundableAmount — сколько ещё можно вернуть по проведённым платежам заказа
+func refundableAmount(payments []gen.Payment) int64 {
+	var refundable int64
+	for _, payment := range payments {
+		if payment.Status == PaymentStatusCaptured || payment.Status == PaymentStatusPartiallyRefunded {
+			refundable += fromNumeric(payment.Amount) - fromNumeric(payment.RefundedAmount)
+		}
+	}
+	return refundable
+}
+
+// allocateRefund раскладывает сумму возврата по проведённым платежам заказа в порядке refundTenderOrder,
+// не больше невозвращённого остатка каждого платежа. Сумма частей меньше amount, если вернуть столько нельзя.
+func allocateRefund(payments []gen.Payment, amount int64) []refundAllocation {
+	var allocations []refundAllocation
+	remaining := amount
+	for _, tender := range refundTenderOrder {
+		for _, payment := range payments {
+			if remaining == 0 || payment.Tender != tender ||
+				(payment.Status != PaymentStatusCaptured && payment.Status != PaymentStatusPartiallyRefunded) {
+				continue
+			}
+			paid, refunded := fromNumeric(payment.Amount), fromNumeric(payment.RefundedAmount)
+			part := min(remaining, paid-refunded)
+			if part == 0 {
+				continue
+			}
+			status := PaymentStatusPartiallyRefunded
+			if refunded+part == paid {
+				status = PaymentStatusRefunded
+			}
+			allocations = append(allocations, refundAllocation{payment: payment, amount: part, status: status})
+			remaining -= part
+		}
+	}
+	return allocations
+}
+
+// CreateRefund оформляет возврат по оплаченному заказу, раскладывает сумму по исходным способам оплаты
+// и ставит в очередь чек возврата прихода. Внутренние средства возвращаются в той же транзакции,
+// а возврат на карту уходит эквайеру только после её фиксации: иначе откат транзакции оставил бы деньги
+// возвращёнными без записи об этом. Неподтверждённые возвраты на карту повторяет RetryPendingRefunds.
+func (r RefundService) CreateRefund(ctx context.Context, dto CreateRefundDto) (RefundDto, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return RefundDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.Queries.WithTx(tx)
+
+	order, err := q.GetOrderForUpdate(ctx, dto.OrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RefundDto{}, OrderNotFound
+		}
+		return RefundDto{}, err
+	}
+	if order.PaymentStatus != OrderPaymentPaid && order.PaymentStatus != OrderPaymentPartiallyRefunded {
+		return RefundDto{}, OrderStatusError
+	}
+
+	amount := dto.Amount
+	var lines []gen.CreateRefundItemParams
+	if len(dto.Items) > 0 {
+		lines, err = refundLines(ctx, q, order.ID, dto.Items)
+		if err != nil {
+			return RefundDto{}, err
+		}
+		amount = 0
+		for _, line := range lines {
+			amount += fromNumeric(line.Price) * int64(line.Quantity)
+		}
+	}
+
+	payments, err := q.ListPaymentsByOrder(ctx, order.ID)
+	if err != nil {
+		return RefundDto{}, err
+	}
+	refundable := refundableAmount(payments)
+	if amount <= 0 || amount > refundable {
+		return RefundDto{}, InvalidRefundAmountError
+	}
+
+	refund, err := q.CreateRefund(ctx, gen.CreateRefundParams{
+		OrderID:   order.ID,
+		Amount:    toNumeric(amount),
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return RefundDto{}, err
+	}
+	for _, line := range lines {
+		line.RefundID = refund.ID
+		if _, err := q.CreateRefundItem(ctx, line); err != nil {
+			return RefundDto{}, err
+		}
+		_, err := q.IncreaseGoodQuantity(ctx, gen.IncreaseGoodQuantityParams{ID: line.GoodID, Quantity: line.Quantity})
+		if err != nil {
+			return RefundDto{}, err
+		}
+		_, err = q.CreateStockAdjustment(ctx, gen.CreateStockAdjustmentParams{
+			GoodID:    line.GoodID,
+			StoreID:   order.StoreID,
+			Quantity:  line.Quantity,
+			Reason:    StockAdjustmentRefund,
+			CreatedAt: refund.CreatedAt,
+		})
+		if err != nil {
+			return RefundDto{}, err
+		}
+	}
+
+	var cardRefunds []gen.ListPendingRefundTendersRow
+	for _, allocation := range allocateRefund(payments, amount) {
+		tenderStatus := RefundTenderPending
+		if allocation.payment.Tender != TenderCard {
+			if err := returnToTender(ctx, q, order, allocation.payment, allocation.amount); err != nil {
+				return RefundDto{}, err
+			}
+			tenderStatus = RefundTenderCompleted
+		}
+		_, err := q.AddPaymentRefund(ctx, gen.AddPaymentRefundParams{
+			ID:             allocation.payment.ID,
+			RefundedAmount: toNumeric(allocation.amount),
+			Status:         allocation.status,
+		})
+		if err != nil {
+			return RefundDto{}, err
+		}
+		refundTender, err := q.CreateRefundTender(ctx, gen.CreateRefundTenderParams{
+			RefundID:  refund.ID,
+			PaymentID: allocation.payment.ID,
+			Tender:    allocation.payment.Tender,
+			Amount:    toNumeric(allocation.amount),
+			Status:    tenderStatus,
+		})
+		if err != nil {
+			return RefundDto{}, err
+		}
+		if tenderStatus == RefundTenderPending {
+			cardRefunds = append(cardRefunds, gen.ListPendingRefundTendersRow{
+				ID:         refundTender.ID,
+				Amount:     refundTender.Amount,
+				ExternalID: allocation.payment.ExternalID,
+			})
+		}
+	}
+
+	err = createReceipt(ctx, q, order.ID, pgtype.Int4{Int32: refund.ID, Valid: true}, CalculationSaleReturn, amount)
+	if err != nil {
+		return RefundDto{}, err
+	}
+
+	orderPaymentStatus := OrderPaymentPartiallyRefunded
+	if amount == refundable {
+		orderPaymentStatus = OrderPaymentRefunded
+	}
+	_, err = q.UpdateOrderPaymentStatus(ctx, gen.UpdateOrderPaymentStatusParams{ID: order.ID, PaymentStatus: orderPaymentStatus})
+	if err != nil {
+		return RefundDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return RefundDto{}, err
+	}
+
+	// Возврат уже записан, поэтому сбой эквайера не отменяет его: неподтверждённая часть останется pending
+	for _, cardRefund := range cardRefunds {
+		if err := r.refundToCard(ctx, cardRefund); err != nil {
+			log.Printf("refund %d: card refund %d: %v", refund.ID, cardRefund.ID, err)
+		}
+	}
+	return loadRefund(ctx, &r.Queries, refund)
+}
+
+// refundToCard возвращает деньги на карту через эквайера и отмечает часть возврата проведённой
+func (r RefundService) refundToCard(ctx context.Context, tender gen.ListPendingRefundTendersRow) error {
+	_, err := r.Provider.Refund(ctx, tender.ExternalID, fromNumeric(tender.Amount), refundIdempotencyKey(tender.ID))
+	if err != nil {
+		return err
+	}
+	return r.Queries.CompleteRefundTender(ctx, tender.ID)
+}
+
+// RetryPendingRefunds повторяет неподтверждённые возвраты на карту и возвращает число проведённых
+func (r RefundService) RetryPendingRefunds(ctx context.Context) (int, error) {
+	tenders, err := r.Queries.ListPendingRefundTenders(ctx)
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for _, tender := range tenders {
+		if ctx.Err() != nil {
+			return completed, ctx.Err()
+		}
+		if err := r.refundToCard(ctx, tender); err != nil {
+			log.Printf("refund worker: card refund %d: %v", tender.ID, err)
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// StartWorker в фоне повторяет неподтверждённые возвраты на карту, пока не отменён ctx
+func (r RefundService) StartWorker(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				completed, err := r.RetryPendingRefunds(ctx)
+				if err != nil {
+					log.Printf("refund worker: %v", err)
+					continue
+				}
+				if completed > 0 {
+					log.Printf("refund worker: completed %d card refunds", completed)
+				}
+			}
+		}
+	}()
+}
+
+func (r RefundService) GetRefund(ctx context.Context, id int32) (RefundDto, error) {
+	refund, err := r.Queries.GetRefund(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RefundDto{}, RefundNotFound
+		}
+		return RefundDto{}, err
+	}
+	return loadRefund(ctx, &r.Queries, refund)
+}
+
+func (r RefundService) GetOrderRefunds(ctx context.Context, orderId int32) ([]RefundDto, error) {
+	refunds, err := r.Queries.ListRefundsByOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]RefundDto, len(refunds))
+	for i, refund := range refunds {
+		response[i], err = loadRefund(ctx, &r.Queries, refund)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
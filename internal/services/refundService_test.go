@@ -0,0 +1,131 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"reflect"
+	"testing"
+)
+
+func TestCheckRefundLines(t *testing.T) {
+	orderItems := []gen.OrderItem{
+		{GoodID: 1, Quantity: 2, Price: toNumeric(1500), VatRate: pgtype.Int4{Int32: 20, Valid: true}},
+		{GoodID: 2, Quantity: 1, Price: toNumeric(700)},
+	}
+	tests := []struct {
+		name     string
+		items    []CreateRefundItemDto
+		refunded []gen.ListRefundedQuantitiesRow
+		want     []gen.CreateRefundItemParams
+		wantErr  error
+	}{
+		{
+			name:  "цена и ставка из заказа",
+			items: []CreateRefundItemDto{{GoodId: 1, Quantity: 2}, {GoodId: 2, Quantity: 1}},
+			want: []gen.CreateRefundItemParams{
+				{GoodID: 1, Quantity: 2, Price: toNumeric(1500), VatRate: pgtype.Int4{Int32: 20, Valid: true}},
+				{GoodID: 2, Quantity: 1, Price: toNumeric(700)},
+			},
+		},
+		{
+			name:     "остаток после прошлого возврата",
+			items:    []CreateRefundItemDto{{GoodId: 1, Quantity: 1}},
+			refunded: []gen.ListRefundedQuantitiesRow{{GoodID: 1, Quantity: 1}},
+			want:     []gen.CreateRefundItemParams{{GoodID: 1, Quantity: 1, Price: toNumeric(1500), VatRate: pgtype.Int4{Int32: 20, Valid: true}}},
+		},
+		{
+			name:     "больше, чем осталось после прошлого возврата",
+			items:    []CreateRefundItemDto{{GoodId: 1, Quantity: 2}},
+			refunded: []gen.ListRefundedQuantitiesRow{{GoodID: 1, Quantity: 1}},
+			wantErr:  RefundQuantityError,
+		},
+		{
+			name:    "товар повторяется в запросе",
+			items:   []CreateRefundItemDto{{GoodId: 2, Quantity: 1}, {GoodId: 2, Quantity: 1}},
+			wantErr: RefundQuantityError,
+		},
+		{
+			name:    "товара нет в заказе",
+			items:   []CreateRefundItemDto{{GoodId: 3, Quantity: 1}},
+			wantErr: RefundQuantityError,
+		},
+		{
+			name:    "нулевое количество",
+			items:   []CreateRefundItemDto{{GoodId: 1, Quantity: 0}},
+			wantErr: InvalidQuantityError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := checkRefundLines(test.items, orderItems, test.refunded)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("err = %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("checkRefundLines = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAllocateRefund(t *testing.T) {
+	payment := func(id int32, tender string, amount int64, refunded int64, status string) gen.Payment {
+		return gen.Payment{ID: id, Tender: tender, Amount: toNumeric(amount), RefundedAmount: toNumeric(refunded), Status: status}
+	}
+	// Порядок платежей в заказе не совпадает с порядком возврата: карта, подарочные карты, баланс, наличные
+	payments := []gen.Payment{
+		payment(1, TenderCash, 300, 0, PaymentStatusCaptured),
+		payment(2, TenderBalance, 200, 0, PaymentStatusCaptured),
+		payment(3, TenderGiftCard, 100, 40, PaymentStatusPartiallyRefunded),
+		payment(4, TenderCard, 400, 0, PaymentStatusCaptured),
+		payment(5, TenderCard, 900, 0, PaymentStatusFailed),
+	}
+	type part struct {
+		id     int32
+		amount int64
+		status string
+	}
+	tests := []struct {
+		name   string
+		amount int64
+		want   []part
+	}{
+		{"только с карты", 150, []part{{4, 150, PaymentStatusPartiallyRefunded}}},
+		{"карта целиком", 400, []part{{4, 400, PaymentStatusRefunded}}},
+		{"карта и остаток подарочной карты", 430, []part{{4, 400, PaymentStatusRefunded}, {3, 30, PaymentStatusPartiallyRefunded}}},
+		{"до наличных", 800, []part{
+			{4, 400, PaymentStatusRefunded},
+			{3, 60, PaymentStatusRefunded},
+			{2, 200, PaymentStatusRefunded},
+			{1, 140, PaymentStatusPartiallyRefunded},
+		}},
+		{"больше, чем можно вернуть", 5000, []part{
+			{4, 400, PaymentStatusRefunded},
+			{3, 60, PaymentStatusRefunded},
+			{2, 200, PaymentStatusRefunded},
+			{1, 300, PaymentStatusRefunded},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []part
+			for _, allocation := range allocateRefund(payments, test.amount) {
+				got = append(got, part{allocation.payment.ID, allocation.amount, allocation.status})
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("allocateRefund = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+	// Неудачная авторизация и уже возвращённое в остаток не входят
+	if got := refundableAmount(payments); got != 960 {
+		t.Errorf("refundableAmount = %d, want 960", got)
+	}
+}
@@ -0,0 +1,547 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	RegisterSessionOpen   = "open"
+	RegisterSessionClosed = "closed"
+
+	CashMovementSale    = "sale"
+	CashMovementRefund  = "refund"
+	CashMovementCashIn  = "cash_in"
+	CashMovementCashOut = "cash_out"
+)
+
+type RegisterDto struct {
+	Id        int32     `json:"id"`
+	StoreId   int32     `json:"store_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	IsAlive   bool      `json:"is_alive"`
+	// Открытая смена кассы, null — касса закрыта и продажи через неё запрещены
+	Session *RegisterSessionDto `json:"session"`
+}
+
+type CreateRegisterDto struct {
+	StoreId int32  `json:"store_id"`
+	Name    string `json:"name"`
+}
+
+type CashMovementDto struct {
+	Id         int32     `json:"id"`
+	Type       string    `json:"type"`
+	Amount     int64     `json:"amount"`
+	OrderId    *int32    `json:"order_id,omitempty"`
+	PaymentId  *int32    `json:"payment_id,omitempty"`
+	EmployeeId *int32    `json:"employee_id,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RegisterSessionDto struct {
+	Id           int32             `json:"id"`
+	RegisterId   int32             `json:"register_id"`
+	OpenedBy     int32             `json:"opened_by"`
+	OpenedAt     time.Time         `json:"opened_at"`
+	OpeningCash  int64             `json:"opening_cash"`
+	ClosedBy     *int32            `json:"closed_by"`
+	ClosedAt     *time.Time        `json:"closed_at"`
+	ExpectedCash *int64            `json:"expected_cash"`
+	CountedCash  *int64            `json:"counted_cash"`
+	Status       string            `json:"status"`
+	Movements    []CashMovementDto `json:"movements,omitempty"`
+}
+
+type OpenRegisterDto struct {
+	EmployeeId int32 `json:"employee_id"`
+	// Размен в кассе на начало смены
+	OpeningCash int64 `json:"opening_cash"`
+}
+
+type CloseRegisterDto struct {
+	EmployeeId int32 `json:"employee_id"`
+	// Пересчитанные наличные в кассе на конец смены
+	CountedCash int64 `json:"counted_cash"`
+}
+
+type CreateCashMovementDto struct {
+	// cash_in — внесение, cash_out — изъятие
+	Type       string `json:"type"`
+	Amount     int64  `json:"amount"`
+	EmployeeId int32  `json:"employee_id"`
+	Comment    string `json:"comment"`
+}
+
+type ZReportTenderDto struct {
+	Tender   string `json:"tender"`
+	Payments int64  `json:"payments"`
+	Sales    int64  `json:"sales"`
+	Refunds  int64  `json:"refunds"`
+	Net      int64  `json:"net"`
+}
+
+// ZReportDto — отчёт о закрытии смены кассы. Сохраняется при закрытии и больше не меняется.
+type ZReportDto struct {
+	SessionId    int32     `json:"session_id"`
+	RegisterId   int32     `json:"register_id"`
+	StoreId      int32     `json:"store_id"`
+	OpenedBy     int32     `json:"opened_by"`
+	ClosedBy     int32     `json:"closed_by"`
+	OpenedAt     time.Time `json:"opened_at"`
+	ClosedAt     time.Time `json:"closed_at"`
+	OpeningCash  int64     `json:"opening_cash"`
+	CashSales    int64     `json:"cash_sales"`
+	CashRefunds  int64     `json:"cash_refunds"`
+	CashIn       int64     `json:"cash_in"`
+	CashOut      int64     `json:"cash_out"`
+	ExpectedCash int64     `json:"expected_cash"`
+	CountedCash  int64     `json:"counted_cash"`
+	// Расхождение: пересчитано минус ожидалось, отрицательное — недостача
+	Discrepancy int64              `json:"discrepancy"`
+	Tenders     []ZReportTenderDto `json:"tenders"`
+}
+
+type RegisterInterface interface {
+	CreateRegister(ctx context.Context, dto CreateRegisterDto) (RegisterDto, error)
+	GetRegister(ctx context.Context, id int32) (RegisterDto, error)
+	GetRegisters(ctx context.Context, storeId *int32) ([]RegisterDto, error)
+	OpenRegister(ctx context.Context, id int32, dto OpenRegisterDto) (RegisterSessionDto, error)
+	CloseRegister(ctx context.Context, id int32, dto CloseRegisterDto) (ZReportDto, error)
+	AddCashMovement(ctx context.Context, id int32, dto CreateCashMovementDto) (CashMovementDto, error)
+	GetSessions(ctx context.Context, registerId int32) ([]RegisterSessionDto, error)
+	GetSession(ctx context.Context, id int32) (RegisterSessionDto, error)
+	GetZReport(ctx context.Context, sessionId int32) (ZReportDto, error)
+}
+
+type RegisterService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var RegisterNotFound = errors.New("register not found")
+var RegisterSessionNotFound = errors.New("register session not found")
+var InvalidRegisterError = errors.New("register does not belong to the order store")
+var RegisterClosedError = errors.New("register is closed")
+var RegisterAlreadyOpenError = errors.New("register is already open")
+var InvalidCashMovementError = errors.New("cash movement must be cash_in or cash_out with positive amount")
+
+func ToRegisterDto(register gen.Register) RegisterDto {
+	return RegisterDto{
+		Id:        register.ID,
+		StoreId:   register.StoreID,
+		Name:      register.Name,
+		CreatedAt: register.CreatedAt.Time,
+		IsAlive:   register.IsAlive,
+	}
+}
+
+func ToCashMovementDto(movement gen.CashMovement) CashMovementDto {
+	return CashMovementDto{
+		Id:         movement.ID,
+		Type:       movement.Type,
+		Amount:     fromNumeric(movement.Amount),
+		OrderId:    fromInt4(movement.OrderID),
+		PaymentId:  fromInt4(movement.PaymentID),
+		EmployeeId: fromInt4(movement.EmployeeID),
+		Comment:    movement.Comment.String,
+		CreatedAt:  movement.CreatedAt.Time,
+	}
+}
+
+func ToRegisterSessionDto(session gen.RegisterSession) RegisterSessionDto {
+	return RegisterSessionDto{
+		Id:           session.ID,
+		RegisterId:   session.RegisterID,
+		OpenedBy:     session.OpenedBy,
+		OpenedAt:     session.OpenedAt.Time,
+		OpeningCash:  fromNumeric(session.OpeningCash),
+		ClosedBy:     fromInt4(session.ClosedBy),
+		ClosedAt:     timestampPtr(session.ClosedAt),
+		ExpectedCash: fromOptionalNumeric(session.ExpectedCash),
+		CountedCash:  fromOptionalNumeric(session.CountedCash),
+		Status:       session.Status,
+	}
+}
+
+// checkRegisterOpen проверяет, что через кассу можно продавать: она существует, относится к магазину и открыта
+func checkRegisterOpen(ctx context.Context, q *gen.Queries, registerId int32, storeId int32) error {
+	register, err := q.GetRegister(ctx, registerId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterNotFound
+		}
+		return err
+	}
+	if !register.IsAlive {
+		return RegisterNotFound
+	}
+	if register.StoreID != storeId {
+		return InvalidRegisterError
+	}
+	if _, err := q.GetOpenRegisterSessionForUpdate(ctx, registerId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterClosedError
+		}
+		return err
+	}
+	return nil
+}
+
+// recordRegisterCash записывает наличные по заказу в открытую смену его кассы.
+// Заказы без кассы (интернет-магазин) в сменах не учитываются.
+func recordRegisterCash(ctx context.Context, q *gen.Queries, order gen.Order, paymentId int32, movementType string, amount int64) error {
+	if !order.RegisterID.Valid {
+		return nil
+	}
+	session, err := q.GetOpenRegisterSessionForUpdate(ctx, order.RegisterID.Int32)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterClosedError
+		}
+		return err
+	}
+	_, err = q.CreateCashMovement(ctx, gen.CreateCashMovementParams{
+		SessionID: session.ID,
+		Type:      movementType,
+		Amount:    toNumeric(amount),
+		OrderID:   pgtype.Int4{Int32: order.ID, Valid: true},
+		PaymentID: pgtype.Int4{Int32: paymentId, Valid: true},
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	return err
+}
+
+func (s RegisterService) CreateRegister(ctx context.Context, dto CreateRegisterDto) (RegisterDto, error) {
+	if _, err := s.Queries.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterDto{}, StoreNotFound
+		}
+		return RegisterDto{}, err
+	}
+	register, err := s.Queries.CreateRegister(ctx, gen.CreateRegisterParams{
+		StoreID:   dto.StoreId,
+		Name:      dto.Name,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:   true,
+	})
+	if err != nil {
+		return RegisterDto{}, err
+	}
+	return ToRegisterDto(register), nil
+}
+
+func (s RegisterService) loadRegister(ctx context.Context, register gen.Register) (RegisterDto, error) {
+	response := ToRegisterDto(register)
+	session, err := s.Queries.GetOpenRegisterSession(ctx, register.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return RegisterDto{}, err
+	}
+	if err == nil {
+		dto := ToRegisterSessionDto(session)
+		response.Session = &dto
+	}
+	return response, nil
+}
+
+func (s RegisterService) GetRegister(ctx context.Context, id int32) (RegisterDto, error) {
+	register, err := s.Queries.GetRegister(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterDto{}, RegisterNotFound
+		}
+		return RegisterDto{}, err
+	}
+	return s.loadRegister(ctx, register)
+}
+
+func (s RegisterService) GetRegisters(ctx context.Context, storeId *int32) ([]RegisterDto, error) {
+	var registers []gen.Register
+	var err error
+	if storeId != nil {
+		registers, err = s.Queries.ListStoreRegisters(ctx, *storeId)
+	} else {
+		registers, err = s.Queries.ListRegisters(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := make([]RegisterDto, len(registers))
+	for i, register := range registers {
+		response[i], err = s.loadRegister(ctx, register)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// OpenRegister открывает смену кассы с разменом. Открыть кассу может сотрудник её магазина.
+func (s RegisterService) OpenRegister(ctx context.Context, id int32, dto OpenRegisterDto) (RegisterSessionDto, error) {
+	if dto.OpeningCash < 0 {
+		return RegisterSessionDto{}, InvalidCashMovementError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return RegisterSessionDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	register, err := q.GetRegister(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterSessionDto{}, RegisterNotFound
+		}
+		return RegisterSessionDto{}, err
+	}
+	if !register.IsAlive {
+		return RegisterSessionDto{}, RegisterNotFound
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, register.StoreID); err != nil {
+		return RegisterSessionDto{}, err
+	}
+	_, err = q.GetOpenRegisterSessionForUpdate(ctx, id)
+	if err == nil {
+		return RegisterSessionDto{}, RegisterAlreadyOpenError
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return RegisterSessionDto{}, err
+	}
+	session, err := q.CreateRegisterSession(ctx, gen.CreateRegisterSessionParams{
+		RegisterID:  id,
+		OpenedBy:    dto.EmployeeId,
+		OpenedAt:    pgtype.Timestamp{Time: time.Now(), Valid: true},
+		OpeningCash: toNumeric(dto.OpeningCash),
+		Status:      RegisterSessionOpen,
+	})
+	if err != nil {
+		return RegisterSessionDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return RegisterSessionDto{}, err
+	}
+	return ToRegisterSessionDto(session), nil
+}
+
+// AddCashMovement вносит или изымает наличные в открытой смене кассы
+func (s RegisterService) AddCashMovement(ctx context.Context, id int32, dto CreateCashMovementDto) (CashMovementDto, error) {
+	if (dto.Type != CashMovementCashIn && dto.Type != CashMovementCashOut) || dto.Amount <= 0 {
+		return CashMovementDto{}, InvalidCashMovementError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return CashMovementDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	session, err := q.GetOpenRegisterSessionForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CashMovementDto{}, RegisterClosedError
+		}
+		return CashMovementDto{}, err
+	}
+	movement, err := q.CreateCashMovement(ctx, gen.CreateCashMovementParams{
+		SessionID:  session.ID,
+		Type:       dto.Type,
+		Amount:     toNumeric(dto.Amount),
+		EmployeeID: pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+		Comment:    pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return CashMovementDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return CashMovementDto{}, err
+	}
+	return ToCashMovementDto(movement), nil
+}
+
+// addCashMovements суммирует движения наличных смены по типам и сверяет ожидаемый остаток с пересчитанным
+func addCashMovements(report *ZReportDto, movements []gen.CashMovement) {
+	for _, movement := range movements {
+		amount := fromNumeric(movement.Amount)
+		switch movement.Type {
+		case CashMovementSale:
+			report.CashSales += amount
+		case CashMovementRefund:
+			report.CashRefunds += amount
+		case CashMovementCashIn:
+			report.CashIn += amount
+		case CashMovementCashOut:
+			report.CashOut += amount
+		}
+	}
+	report.ExpectedCash = report.OpeningCash + report.CashSales - report.CashRefunds + report.CashIn - report.CashOut
+	report.Discrepancy = report.CountedCash - report.ExpectedCash
+}
+
+// tenderTotals сводит оплаты и возвраты смены по способам оплаты в порядке их появления
+func tenderTotals(payments []gen.RegisterPaymentTotalsRow, refunds []gen.RegisterRefundTotalsRow) []ZReportTenderDto {
+	totals := []ZReportTenderDto{}
+	tenders := map[string]int{}
+	for _, row := range payments {
+		tenders[row.Tender] = len(totals)
+		totals = append(totals, ZReportTenderDto{Tender: row.Tender, Payments: row.Payments, Sales: row.Amount})
+	}
+	for _, row := range refunds {
+		i, ok := tenders[row.Tender]
+		if !ok {
+			i = len(totals)
+			tenders[row.Tender] = i
+			totals = append(totals, ZReportTenderDto{Tender: row.Tender})
+		}
+		totals[i].Refunds += row.Amount
+	}
+	for i := range totals {
+		totals[i].Net = totals[i].Sales - totals[i].Refunds
+	}
+	return totals
+}
+
+// CloseRegister закрывает смену: сверяет пересчитанные наличные с ожидаемыми и сохраняет Z-отчёт
+func (s RegisterService) CloseRegister(ctx context.Context, id int32, dto CloseRegisterDto) (ZReportDto, error) {
+	if dto.CountedCash < 0 {
+		return ZReportDto{}, InvalidCashMovementError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	register, err := q.GetRegister(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ZReportDto{}, RegisterNotFound
+		}
+		return ZReportDto{}, err
+	}
+	session, err := q.GetOpenRegisterSessionForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ZReportDto{}, RegisterClosedError
+		}
+		return ZReportDto{}, err
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, register.StoreID); err != nil {
+		return ZReportDto{}, err
+	}
+
+	closedAt := time.Now()
+	report := ZReportDto{
+		SessionId:   session.ID,
+		RegisterId:  register.ID,
+		StoreId:     register.StoreID,
+		OpenedBy:    session.OpenedBy,
+		ClosedBy:    dto.EmployeeId,
+		OpenedAt:    session.OpenedAt.Time,
+		ClosedAt:    closedAt,
+		OpeningCash: fromNumeric(session.OpeningCash),
+		CountedCash: dto.CountedCash,
+	}
+	movements, err := q.ListCashMovements(ctx, session.ID)
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	addCashMovements(&report, movements)
+
+	window := gen.RegisterPaymentTotalsParams{
+		RegisterID: pgtype.Int4{Int32: register.ID, Valid: true},
+		DateFrom:   session.OpenedAt,
+		DateTo:     pgtype.Timestamp{Time: closedAt, Valid: true},
+	}
+	payments, err := q.RegisterPaymentTotals(ctx, window)
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	refunds, err := q.RegisterRefundTotals(ctx, gen.RegisterRefundTotalsParams(window))
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	report.Tenders = tenderTotals(payments, refunds)
+
+	document, err := json.Marshal(report)
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	_, err = q.CloseRegisterSession(ctx, gen.CloseRegisterSessionParams{
+		ID:           session.ID,
+		ClosedBy:     pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+		ClosedAt:     pgtype.Timestamp{Time: closedAt, Valid: true},
+		ExpectedCash: toNumeric(report.ExpectedCash),
+		CountedCash:  toNumeric(report.CountedCash),
+		ZReport:      pgtype.Text{String: string(document), Valid: true},
+		Status:       RegisterSessionClosed,
+	})
+	if err != nil {
+		return ZReportDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ZReportDto{}, err
+	}
+	return report, nil
+}
+
+func (s RegisterService) GetSessions(ctx context.Context, registerId int32) ([]RegisterSessionDto, error) {
+	sessions, err := s.Queries.ListRegisterSessions(ctx, registerId)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]RegisterSessionDto, len(sessions))
+	for i, session := range sessions {
+		response[i] = ToRegisterSessionDto(session)
+	}
+	return response, nil
+}
+
+func (s RegisterService) GetSession(ctx context.Context, id int32) (RegisterSessionDto, error) {
+	session, err := s.Queries.GetRegisterSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RegisterSessionDto{}, RegisterSessionNotFound
+		}
+		return RegisterSessionDto{}, err
+	}
+	movements, err := s.Queries.ListCashMovements(ctx, session.ID)
+	if err != nil {
+		return RegisterSessionDto{}, err
+	}
+	response := ToRegisterSessionDto(session)
+	response.Movements = make([]CashMovementDto, len(movements))
+	for i, movement := range movements {
+		response.Movements[i] = ToCashMovementDto(movement)
+	}
+	return response, nil
+}
+
+// GetZReport возвращает Z-отчёт, сохранённый при закрытии смены
+func (s RegisterService) GetZReport(ctx context.Context, sessionId int32) (ZReportDto, error) {
+	session, err := s.Queries.GetRegisterSession(ctx, sessionId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ZReportDto{}, RegisterSessionNotFound
+		}
+		return ZReportDto{}, err
+	}
+	if session.Status != RegisterSessionClosed || !session.ZReport.Valid {
+		return ZReportDto{}, RegisterAlreadyOpenError
+	}
+	var report ZReportDto
+	if err := json.Unmarshal([]byte(session.ZReport.String), &report); err != nil {
+		return ZReportDto{}, err
+	}
+	return report, nil
+}
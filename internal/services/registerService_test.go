@@ -0,0 +1,87 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"reflect"
+	"testing"
+)
+
+func TestAddCashMovements(t *testing.T) {
+	movement := func(movementType string, amount int64) gen.CashMovement {
+		return gen.CashMovement{Type: movementType, Amount: toNumeric(amount)}
+	}
+	tests := []struct {
+		name            string
+		movements       []gen.CashMovement
+		countedCash     int64
+		wantExpected    int64
+		wantDiscrepancy int64
+	}{
+		{"без движений", nil, 1000, 1000, 0},
+		{
+			"продажи, возвраты, внесения и изъятия",
+			[]gen.CashMovement{
+				movement(CashMovementSale, 5000),
+				movement(CashMovementSale, 2500),
+				movement(CashMovementRefund, 1500),
+				movement(CashMovementCashIn, 300),
+				movement(CashMovementCashOut, 4000),
+			},
+			3300, 3300, 0,
+		},
+		{"недостача", []gen.CashMovement{movement(CashMovementSale, 5000)}, 5900, 6000, -100},
+		{"излишек", []gen.CashMovement{movement(CashMovementCashOut, 500)}, 600, 500, 100},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := ZReportDto{OpeningCash: 1000, CountedCash: test.countedCash}
+			addCashMovements(&report, test.movements)
+			if report.ExpectedCash != test.wantExpected || report.Discrepancy != test.wantDiscrepancy {
+				t.Errorf("addCashMovements = expected %d, discrepancy %d, want %d, %d",
+					report.ExpectedCash, report.Discrepancy, test.wantExpected, test.wantDiscrepancy)
+			}
+		})
+	}
+}
+
+func TestTenderTotals(t *testing.T) {
+	tests := []struct {
+		name     string
+		payments []gen.RegisterPaymentTotalsRow
+		refunds  []gen.RegisterRefundTotalsRow
+		want     []ZReportTenderDto
+	}{
+		{"пустая смена", nil, nil, []ZReportTenderDto{}},
+		{
+			"оплаты и возвраты по одному способу",
+			[]gen.RegisterPaymentTotalsRow{{Tender: TenderCash, Payments: 3, Amount: 9000}},
+			[]gen.RegisterRefundTotalsRow{{Tender: TenderCash, Refunds: 1, Amount: 2000}},
+			[]ZReportTenderDto{{Tender: TenderCash, Payments: 3, Sales: 9000, Refunds: 2000, Net: 7000}},
+		},
+		{
+			"возврат по способу без оплат в смене",
+			[]gen.RegisterPaymentTotalsRow{{Tender: TenderCash, Payments: 1, Amount: 1000}},
+			[]gen.RegisterRefundTotalsRow{{Tender: TenderCard, Refunds: 1, Amount: 400}},
+			[]ZReportTenderDto{
+				{Tender: TenderCash, Payments: 1, Sales: 1000, Net: 1000},
+				{Tender: TenderCard, Refunds: 400, Net: -400},
+			},
+		},
+		{
+			"смешанная оплата",
+			[]gen.RegisterPaymentTotalsRow{{Tender: TenderCard, Payments: 2, Amount: 7000}, {Tender: TenderGiftCard, Payments: 1, Amount: 3000}},
+			[]gen.RegisterRefundTotalsRow{{Tender: TenderGiftCard, Refunds: 1, Amount: 3000}, {Tender: TenderCard, Refunds: 1, Amount: 500}},
+			[]ZReportTenderDto{
+				{Tender: TenderCard, Payments: 2, Sales: 7000, Refunds: 500, Net: 6500},
+				{Tender: TenderGiftCard, Payments: 1, Sales: 3000, Refunds: 3000, Net: 0},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tenderTotals(test.payments, test.refunds); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("tenderTotals = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"time"
+)
+
+const (
+	SuggestionStatusOpen    = "open"
+	SuggestionStatusOrdered = "ordered"
+)
+
+type ReorderPointDto struct {
+	Id      int32  `json:"id"`
+	GoodId  int32  `json:"good_id"`
+	StoreId *int32 `json:"store_id"`
+	// Минимальный остаток, ниже которого товар нужно дозаказать
+	MinQuantity int32 `json:"min_quantity"`
+	// Сколько заказывать за раз
+	ReorderQuantity int32     `json:"reorder_quantity"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type SetReorderPointDto struct {
+	GoodId int32 `json:"good_id"`
+	// Магазин, null — порог по всей сети
+	StoreId         *int32 `json:"store_id"`
+	MinQuantity     int32  `json:"min_quantity"`
+	ReorderQuantity int32  `json:"reorder_quantity"`
+}
+
+type SuggestionDto struct {
+	Id          int32  `json:"id"`
+	GoodId      int32  `json:"good_id"`
+	Article     string `json:"article"`
+	Name        string `json:"name"`
+	Available   int32  `json:"available"`
+	OnOrder     int32  `json:"on_order"`
+	MinQuantity int32  `json:"min_quantity"`
+	Quantity    int32  `json:"quantity"`
+	UnitCost    *int64 `json:"unit_cost"`
+	Amount      int64  `json:"amount"`
+}
+
+// SupplierSuggestionsDto — предложения к заказу у одного поставщика. Товары без поставщика идут с supplier_id null.
+type SupplierSuggestionsDto struct {
+	SupplierId *int32          `json:"supplier_id"`
+	Items      []SuggestionDto `json:"items"`
+	Total      int64           `json:"total"`
+}
+
+type ConvertSuggestionsDto struct {
+	SupplierId int32  `json:"supplier_id"`
+	StoreId    int32  `json:"store_id"`
+	EmployeeId *int32 `json:"employee_id"`
+}
+
+type ReplenishmentInterface interface {
+	SetReorderPoint(ctx context.Context, dto SetReorderPointDto) (ReorderPointDto, error)
+	GetReorderPoints(ctx context.Context, goodId *int32) ([]ReorderPointDto, error)
+	DeleteReorderPoint(ctx context.Context, id int32) error
+	RefreshSuggestions(ctx context.Context) (int, error)
+	GetSuggestions(ctx context.Context) ([]SupplierSuggestionsDto, error)
+	ConvertSuggestions(ctx context.Context, dto ConvertSuggestionsDto) (PurchaseOrderDto, error)
+}
+
+type ReplenishmentService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var ReorderPointNotFound = errors.New("reorder point not found")
+var InvalidReorderPointError = errors.New("reorder point quantities must be non-negative and reorder quantity positive")
+var NoSuggestionsError = errors.New("no open suggestions for the supplier")
+
+func ToReorderPointDto(point gen.ReorderPoint) ReorderPointDto {
+	return ReorderPointDto{
+		Id:              point.ID,
+		GoodId:          point.GoodID,
+		StoreId:         fromInt4(point.StoreID),
+		MinQuantity:     point.MinQuantity,
+		ReorderQuantity: point.ReorderQuantity,
+		UpdatedAt:       point.UpdatedAt.Time,
+	}
+}
+
+// setReorderPoint задаёт порог товара по сети или в магазине, заменяя прежний
+func setReorderPoint(ctx context.Context, q *gen.Queries, dto SetReorderPointDto) (ReorderPointDto, error) {
+	if dto.MinQuantity < 0 || dto.ReorderQuantity <= 0 {
+		return ReorderPointDto{}, InvalidReorderPointError
+	}
+	if _, err := q.GetGood(ctx, dto.GoodId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReorderPointDto{}, ProductNotFound
+		}
+		return ReorderPointDto{}, err
+	}
+	if dto.StoreId != nil {
+		if _, err := q.GetStore(ctx, *dto.StoreId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ReorderPointDto{}, StoreNotFound
+			}
+			return ReorderPointDto{}, err
+		}
+	}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	existing, err := q.FindReorderPoint(ctx, gen.FindReorderPointParams{GoodID: dto.GoodId, StoreID: toInt4(dto.StoreId)})
+	var point gen.ReorderPoint
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		point, err = q.CreateReorderPoint(ctx, gen.CreateReorderPointParams{
+			GoodID:          dto.GoodId,
+			StoreID:         toInt4(dto.StoreId),
+			MinQuantity:     dto.MinQuantity,
+			ReorderQuantity: dto.ReorderQuantity,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	case err == nil:
+		point, err = q.UpdateReorderPoint(ctx, gen.UpdateReorderPointParams{
+			ID:              existing.ID,
+			MinQuantity:     dto.MinQuantity,
+			ReorderQuantity: dto.ReorderQuantity,
+			UpdatedAt:       now,
+		})
+	}
+	if err != nil {
+		return ReorderPointDto{}, err
+	}
+	return ToReorderPointDto(point), nil
+}
+
+func (s ReplenishmentService) SetReorderPoint(ctx context.Context, dto SetReorderPointDto) (ReorderPointDto, error) {
+	return setReorderPoint(ctx, &s.Queries, dto)
+}
+
+func (s ReplenishmentService) GetReorderPoints(ctx context.Context, goodId *int32) ([]ReorderPointDto, error) {
+	points, err := s.Queries.ListReorderPoints(ctx, toInt4(goodId))
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ReorderPointDto, len(points))
+	for i, point := range points {
+		response[i] = ToReorderPointDto(point)
+	}
+	return response, nil
+}
+
+func (s ReplenishmentService) DeleteReorderPoint(ctx context.Context, id int32) error {
+	if _, err := s.Queries.GetReorderPoint(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReorderPointNotFound
+		}
+		return err
+	}
+	return s.Queries.DeleteReorderPoint(ctx, id)
+}
+
+// reorderQuantity возвращает, сколько заказать, если доступное вместе с заказанным ниже порога, и 0 — если не нужно
+func reorderQuantity(available int32, onOrder int32, minQuantity int32, batch int32) int32 {
+	projected := available + onOrder
+	if projected >= minQuantity {
+		return 0
+	}
+	return max(batch, minQuantity-projected)
+}
+
+// RefreshSuggestions пересчитывает предложения к заказу. Остатки ведутся по сети, поэтому доступное
+// количество (остаток за вычетом резервов) вместе с уже заказанным у поставщиков сравнивается с порогом сети.
+// Ниже порога предлагается заказать партию, но не меньше, чем нужно для возврата к порогу.
+func (s ReplenishmentService) RefreshSuggestions(ctx context.Context) (int, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := q.DeleteOpenSuggestions(ctx); err != nil {
+		return 0, err
+	}
+	thresholds, err := q.ListReorderThresholds(ctx)
+	if err != nil {
+		return 0, err
+	}
+	reservedRows, err := q.ListReservedQuantities(ctx)
+	if err != nil {
+		return 0, err
+	}
+	reserved := make(map[int32]int32, len(reservedRows))
+	for _, row := range reservedRows {
+		reserved[row.GoodID] = row.Reserved
+	}
+	onOrderRows, err := q.ListOnOrderQuantities(ctx)
+	if err != nil {
+		return 0, err
+	}
+	onOrder := make(map[int32]int32, len(onOrderRows))
+	for _, row := range onOrderRows {
+		onOrder[row.GoodID] = row.OnOrder
+	}
+	supplierRows, err := q.ListBestSuppliers(ctx)
+	if err != nil {
+		return 0, err
+	}
+	suppliers := make(map[int32]gen.ListBestSuppliersRow, len(supplierRows))
+	for _, row := range supplierRows {
+		suppliers[row.GoodID] = row
+	}
+
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	created := 0
+	for _, threshold := range thresholds {
+		available := threshold.Quantity - reserved[threshold.GoodID]
+		quantity := reorderQuantity(available, onOrder[threshold.GoodID], threshold.MinQuantity, threshold.ReorderQuantity)
+		if quantity == 0 {
+			continue
+		}
+		params := gen.CreateSuggestionParams{
+			GoodID:      threshold.GoodID,
+			Available:   available,
+			OnOrder:     onOrder[threshold.GoodID],
+			MinQuantity: threshold.MinQuantity,
+			Quantity:    quantity,
+			Status:      SuggestionStatusOpen,
+			CreatedAt:   now,
+		}
+		if supplier, ok := suppliers[threshold.GoodID]; ok {
+			params.SupplierID = pgtype.Int4{Int32: supplier.SupplierID, Valid: true}
+			params.UnitCost = supplier.PurchasePrice
+		}
+		if _, err := q.CreateSuggestion(ctx, params); err != nil {
+			return 0, err
+		}
+		created++
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+// StartScanner в фоне пересчитывает предложения к заказу, пока не отменён ctx
+func (s ReplenishmentService) StartScanner(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				created, err := s.RefreshSuggestions(ctx)
+				if err != nil {
+					log.Printf("replenishment scanner: %v", err)
+					continue
+				}
+				if created > 0 {
+					log.Printf("replenishment scanner: %d goods below reorder point", created)
+				}
+			}
+		}
+	}()
+}
+
+// GetSuggestions возвращает открытые предложения, сгруппированные по лучшему поставщику товара
+func (s ReplenishmentService) GetSuggestions(ctx context.Context) ([]SupplierSuggestionsDto, error) {
+	rows, err := s.Queries.ListOpenSuggestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groupSuggestions(rows), nil
+}
+
+// groupSuggestions собирает предложения, упорядоченные по поставщику, в группы с итогом по каждому
+func groupSuggestions(rows []gen.ListOpenSuggestionsRow) []SupplierSuggestionsDto {
+	response := []SupplierSuggestionsDto{}
+	for _, row := range rows {
+		last := len(response) - 1
+		if last < 0 || !sameSupplier(response[last].SupplierId, row.SupplierID) {
+			response = append(response, SupplierSuggestionsDto{SupplierId: fromInt4(row.SupplierID)})
+			last++
+		}
+		amount := fromNumeric(row.UnitCost) * int64(row.Quantity)
+		response[last].Items = append(response[last].Items, SuggestionDto{
+			Id:          row.ID,
+			GoodId:      row.GoodID,
+			Article:     row.Article,
+			Name:        row.Name,
+			Available:   row.Available,
+			OnOrder:     row.OnOrder,
+			MinQuantity: row.MinQuantity,
+			Quantity:    row.Quantity,
+			UnitCost:    fromOptionalNumeric(row.UnitCost),
+			Amount:      amount,
+		})
+		response[last].Total += amount
+	}
+	return response
+}
+
+func sameSupplier(supplierId *int32, other pgtype.Int4) bool {
+	if supplierId == nil {
+		return !other.Valid
+	}
+	return other.Valid && *supplierId == other.Int32
+}
+
+// ConvertSuggestions превращает открытые предложения поставщика в черновик заказа поставщику
+func (s ReplenishmentService) ConvertSuggestions(ctx context.Context, dto ConvertSuggestionsDto) (PurchaseOrderDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	suggestions, err := q.ListSupplierSuggestionsForUpdate(ctx, pgtype.Int4{Int32: dto.SupplierId, Valid: true})
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	if len(suggestions) == 0 {
+		return PurchaseOrderDto{}, NoSuggestionsError
+	}
+	order := CreatePurchaseOrderDto{
+		SupplierId: dto.SupplierId,
+		StoreId:    dto.StoreId,
+		EmployeeId: dto.EmployeeId,
+		Comment:    "Пополнение по точкам заказа",
+		Items:      make([]CreatePurchaseOrderItemDto, len(suggestions)),
+	}
+	for i, suggestion := range suggestions {
+		order.Items[i] = CreatePurchaseOrderItemDto{
+			GoodId:   suggestion.GoodID,
+			Quantity: suggestion.Quantity,
+			UnitCost: fromOptionalNumeric(suggestion.UnitCost),
+		}
+	}
+	response, err := createPurchaseOrder(ctx, q, order)
+	if err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	for _, suggestion := range suggestions {
+		err := q.MarkSuggestionOrdered(ctx, gen.MarkSuggestionOrderedParams{
+			ID:              suggestion.ID,
+			PurchaseOrderID: pgtype.Int4{Int32: response.Id, Valid: true},
+		})
+		if err != nil {
+			return PurchaseOrderDto{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PurchaseOrderDto{}, err
+	}
+	return response, nil
+}
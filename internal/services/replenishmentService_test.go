@@ -0,0 +1,80 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestReorderQuantity(t *testing.T) {
+	tests := []struct {
+		name        string
+		available   int32
+		onOrder     int32
+		minQuantity int32
+		batch       int32
+		want        int32
+	}{
+		{"выше порога", 12, 0, 10, 20, 0},
+		{"ровно на пороге", 10, 0, 10, 20, 0},
+		{"заказанное поднимает до порога", 4, 6, 10, 20, 0},
+		{"ниже порога — партия", 9, 0, 10, 20, 20},
+		{"партии не хватает до порога", 2, 3, 50, 20, 45},
+		{"резервы больше остатка", -3, 0, 10, 5, 13},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := reorderQuantity(test.available, test.onOrder, test.minQuantity, test.batch); got != test.want {
+				t.Errorf("reorderQuantity = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSameSupplier(t *testing.T) {
+	one, two := int32(1), int32(2)
+	tests := []struct {
+		name       string
+		supplierId *int32
+		other      pgtype.Int4
+		want       bool
+	}{
+		{"оба без поставщика", nil, pgtype.Int4{}, true},
+		{"один поставщик", &one, pgtype.Int4{Int32: 1, Valid: true}, true},
+		{"разные поставщики", &two, pgtype.Int4{Int32: 1, Valid: true}, false},
+		{"без поставщика и с поставщиком", nil, pgtype.Int4{Int32: 1, Valid: true}, false},
+		{"с поставщиком и без", &one, pgtype.Int4{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sameSupplier(test.supplierId, test.other); got != test.want {
+				t.Errorf("sameSupplier = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGroupSuggestions(t *testing.T) {
+	supplier := pgtype.Int4{Int32: 3, Valid: true}
+	rows := []gen.ListOpenSuggestionsRow{
+		{ID: 1, GoodID: 10, SupplierID: supplier, Quantity: 2, UnitCost: toNumeric(1500)},
+		{ID: 2, GoodID: 11, SupplierID: supplier, Quantity: 5, UnitCost: toNumeric(200)},
+		{ID: 3, GoodID: 12, Quantity: 4},
+	}
+	groups := groupSuggestions(rows)
+	if len(groups) != 2 {
+		t.Fatalf("groupSuggestions groups = %d, want 2", len(groups))
+	}
+	if groups[0].SupplierId == nil || *groups[0].SupplierId != 3 || len(groups[0].Items) != 2 || groups[0].Total != 4000 {
+		t.Errorf("groupSuggestions[0] = %+v, want supplier 3 with 2 items and total 4000", groups[0])
+	}
+	if groups[1].SupplierId != nil || len(groups[1].Items) != 1 || groups[1].Total != 0 {
+		t.Errorf("groupSuggestions[1] = %+v, want no supplier with 1 item and total 0", groups[1])
+	}
+	if groups[1].Items[0].UnitCost != nil {
+		t.Errorf("groupSuggestions unit cost = %v, want nil without purchase price", *groups[1].Items[0].UnitCost)
+	}
+	if empty := groupSuggestions(nil); empty == nil || len(empty) != 0 {
+		t.Errorf("groupSuggestions(nil) = %v, want empty slice", empty)
+	}
+}
@@ -0,0 +1,631 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"cmp"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"slices"
+	"time"
+)
+
+// Измерения группировки отчёта о продажах. Из day, week и month можно выбрать только одно
+var SalesReportDimensions = []string{"day", "week", "month", "store", "category", "brand", "employee"}
+
+type InventoryValuationRowDto struct {
+	// Магазин. null — остаток, появившийся до ведения журнала движений и не распределённый по магазинам
+	StoreId    *int32 `json:"store_id"`
+	CategoryId *int32 `json:"category_id"`
+	Quantity   int32  `json:"quantity"`
+	// Стоимость остатка по средней себестоимости на дату
+	Value int64 `json:"value"`
+	// Количество товаров без известной себестоимости, в стоимость не вошло
+	UncostedQuantity int32 `json:"uncosted_quantity"`
+}
+
+type InventoryValuationDto struct {
+	Date          string                     `json:"date"`
+	Rows          []InventoryValuationRowDto `json:"rows"`
+	TotalQuantity int32                      `json:"total_quantity"`
+	TotalValue    int64                      `json:"total_value"`
+}
+
+type SalesReportFilterDto struct {
+	StoreId    *int32
+	CategoryId *int32
+	Brand      string
+	EmployeeId *int32
+}
+
+type SalesReportRowDto struct {
+	// Начало дня, недели или месяца, если выбрана группировка по времени
+	Period     *string `json:"period,omitempty"`
+	StoreId    *int32  `json:"store_id,omitempty"`
+	CategoryId *int32  `json:"category_id,omitempty"`
+	Brand      *string `json:"brand,omitempty"`
+	EmployeeId *int32  `json:"employee_id,omitempty"`
+	Revenue    int64   `json:"revenue"`
+	Units      int64   `json:"units"`
+	// Себестоимость проданного, зафиксированная при продаже
+	Cost int64 `json:"cost"`
+	// Выручка строк без себестоимости: услуги, товары без себестоимости и продажи до её учёта
+	UncostedRevenue int64 `json:"uncosted_revenue"`
+	// Выручка строк с себестоимостью за вычетом себестоимости
+	Margin        int64 `json:"margin"`
+	Orders        int64 `json:"orders"`
+	AverageTicket int64 `json:"average_ticket"`
+}
+
+type SalesReportDto struct {
+	From    string              `json:"from"`
+	To      string              `json:"to"`
+	GroupBy []string            `json:"group_by"`
+	Rows    []SalesReportRowDto `json:"rows"`
+	Total   SalesReportRowDto   `json:"total"`
+}
+
+type LowStockItemDto struct {
+	GoodId    int32  `json:"good_id"`
+	Article   string `json:"article"`
+	Name      string `json:"name"`
+	Quantity  int32  `json:"quantity"`
+	Reserved  int32  `json:"reserved"`
+	Available int32  `json:"available"`
+	// Порог: из запроса или минимальный остаток точки заказа
+	Threshold         int32   `json:"threshold"`
+	AverageDailySales float64 `json:"average_daily_sales"`
+	// На сколько дней хватит доступного остатка при текущих продажах, null — продаж не было
+	DaysOfCover *float64 `json:"days_of_cover"`
+}
+
+type DeadStockItemDto struct {
+	GoodId     int32      `json:"good_id"`
+	Article    string     `json:"article"`
+	Name       string     `json:"name"`
+	CategoryId *int32     `json:"category_id"`
+	Quantity   int32      `json:"quantity"`
+	UnitCost   *int64     `json:"unit_cost"`
+	LastSoldAt *time.Time `json:"last_sold_at"`
+	// Деньги, замороженные в остатке, по средней себестоимости
+	Value int64 `json:"value"`
+}
+
+type DeadStockDto struct {
+	Days       int                `json:"days"`
+	Items      []DeadStockItemDto `json:"items"`
+	TotalValue int64              `json:"total_value"`
+}
+
+type AbcXyzItemDto struct {
+	GoodId  int32  `json:"good_id"`
+	Article string `json:"article"`
+	Name    string `json:"name"`
+	Revenue int64  `json:"revenue"`
+	// Доля в выручке и накопленная доля с учётом более прибыльных товаров, в процентах
+	Share           float64 `json:"share"`
+	CumulativeShare float64 `json:"cumulative_share"`
+	Units           int64   `json:"units"`
+	// Коэффициент вариации продаж по интервалам в процентах, null — продаж не было
+	Variation *float64 `json:"variation"`
+	Abc       string   `json:"abc"`
+	Xyz       string   `json:"xyz"`
+	// Класс матрицы, например AX
+	Class string `json:"class"`
+}
+
+type AbcXyzCellDto struct {
+	Class   string `json:"class"`
+	Goods   int    `json:"goods"`
+	Revenue int64  `json:"revenue"`
+}
+
+type AbcXyzDto struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Interval string          `json:"interval"`
+	Matrix   []AbcXyzCellDto `json:"matrix"`
+	Items    []AbcXyzItemDto `json:"items"`
+}
+
+type ReportInterface interface {
+	GetInventoryValuation(ctx context.Context, date time.Time, storeId *int32) (InventoryValuationDto, error)
+	GetSalesReport(ctx context.Context, from time.Time, to time.Time, groupBy []string, filter SalesReportFilterDto) (SalesReportDto, error)
+	GetLowStock(ctx context.Context, threshold *int32, days int) ([]LowStockItemDto, error)
+	GetDeadStock(ctx context.Context, days int) (DeadStockDto, error)
+	GetAbcXyz(ctx context.Context, from time.Time, to time.Time, interval string) (AbcXyzDto, error)
+}
+
+type ReportService struct {
+	Queries gen.Queries
+}
+
+var InvalidReportGroupingError = errors.New("group_by must list day, week or month at most once and store, category, brand or employee")
+var InvalidReportDaysError = errors.New("days must be positive")
+var InvalidAnalysisIntervalError = errors.New("interval must be week or month")
+var InvalidAnalysisPeriodError = errors.New("analysis period is too long for the interval")
+
+const (
+	// Границы ABC по накопленной доле выручки
+	abcLimitA = 80.0
+	abcLimitB = 95.0
+	// Границы XYZ по коэффициенту вариации продаж
+	xyzLimitX = 10.0
+	xyzLimitY = 25.0
+	// Наибольшее число недель или месяцев в анализе: десять лет по неделям
+	maxAnalysisBuckets = 520
+)
+
+type valuationKey struct {
+	storeId     int32
+	hasStore    bool
+	categoryId  int32
+	hasCategory bool
+}
+
+// GetInventoryValuation оценивает остатки на конец дня date. Остатки ведутся по сети, поэтому остаток на дату
+// получается откатом текущего остатка по журналу движений, а разбивка по магазинам — сальдо движений магазина.
+// Магазин, продающий товар, принятый другим магазином, может уйти в минус. Себестоимость берётся последняя
+// рассчитанная до даты, а если её нет — текущая.
+func (s ReportService) GetInventoryValuation(ctx context.Context, date time.Time, storeId *int32) (InventoryValuationDto, error) {
+	end := pgtype.Timestamp{Time: date.AddDate(0, 0, 1), Valid: true}
+	goods, err := s.Queries.ListGoodsStock(ctx)
+	if err != nil {
+		return InventoryValuationDto{}, err
+	}
+	sinceRows, err := s.Queries.ListStockMovementsSince(ctx, end)
+	if err != nil {
+		return InventoryValuationDto{}, err
+	}
+	since := make(map[int32]int32, len(sinceRows))
+	for _, row := range sinceRows {
+		since[row.GoodID] = row.Quantity
+	}
+	balanceRows, err := s.Queries.ListStoreStockBalances(ctx, end)
+	if err != nil {
+		return InventoryValuationDto{}, err
+	}
+	balances := make(map[int32][]gen.ListStoreStockBalancesRow)
+	for _, row := range balanceRows {
+		balances[row.GoodID] = append(balances[row.GoodID], row)
+	}
+	costRows, err := s.Queries.ListGoodCostsAt(ctx, end)
+	if err != nil {
+		return InventoryValuationDto{}, err
+	}
+	costs := make(map[int32]pgtype.Numeric, len(costRows))
+	for _, row := range costRows {
+		costs[row.GoodID] = row.Cost
+	}
+
+	totals := make(map[valuationKey]*InventoryValuationRowDto)
+	add := func(key valuationKey, quantity int32, cost pgtype.Numeric) {
+		if quantity == 0 {
+			return
+		}
+		row, ok := totals[key]
+		if !ok {
+			row = &InventoryValuationRowDto{}
+			if key.hasStore {
+				row.StoreId = &key.storeId
+			}
+			if key.hasCategory {
+				row.CategoryId = &key.categoryId
+			}
+			totals[key] = row
+		}
+		row.Quantity += quantity
+		if cost.Valid {
+			row.Value += fromNumeric(cost) * int64(quantity)
+		} else {
+			row.UncostedQuantity += quantity
+		}
+	}
+	for _, good := range goods {
+		cost, ok := costs[good.ID]
+		if !ok {
+			cost = good.Cost
+		}
+		key := valuationKey{categoryId: good.CategoryID.Int32, hasCategory: good.CategoryID.Valid}
+		unallocated := good.Quantity - since[good.ID]
+		for _, balance := range balances[good.ID] {
+			unallocated -= balance.Quantity
+			if storeId == nil || *storeId == balance.StoreID {
+				key.storeId, key.hasStore = balance.StoreID, true
+				add(key, balance.Quantity, cost)
+			}
+		}
+		if storeId == nil {
+			key.storeId, key.hasStore = 0, false
+			add(key, unallocated, cost)
+		}
+	}
+
+	response := InventoryValuationDto{
+		Date: date.Format(DateLayout),
+		Rows: make([]InventoryValuationRowDto, 0, len(totals)),
+	}
+	for _, row := range totals {
+		response.Rows = append(response.Rows, *row)
+		response.TotalQuantity += row.Quantity
+		response.TotalValue += row.Value
+	}
+	slices.SortFunc(response.Rows, func(a, b InventoryValuationRowDto) int {
+		if c := cmp.Compare(idOrZero(a.StoreId), idOrZero(b.StoreId)); c != 0 {
+			return c
+		}
+		return cmp.Compare(idOrZero(a.CategoryId), idOrZero(b.CategoryId))
+	})
+	return response, nil
+}
+
+func idOrZero(id *int32) int32 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func toSalesReportRowDto(row gen.SalesReportRow) SalesReportRowDto {
+	response := SalesReportRowDto{
+		StoreId:         fromInt4(row.StoreID),
+		CategoryId:      fromInt4(row.CategoryID),
+		EmployeeId:      fromInt4(row.EmployeeID),
+		Revenue:         row.Revenue,
+		Units:           row.Units,
+		Cost:            row.Cost,
+		Margin:          row.Revenue - row.UncostedRevenue - row.Cost,
+		Orders:          row.Orders,
+		UncostedRevenue: row.UncostedRevenue,
+	}
+	if row.Period.Valid {
+		period := row.Period.Time.Format(DateLayout)
+		response.Period = &period
+	}
+	if row.Brand.Valid {
+		response.Brand = &row.Brand.String
+	}
+	if row.Orders > 0 {
+		response.AverageTicket = row.Revenue / row.Orders
+	}
+	return response
+}
+
+// GetSalesReport агрегирует продажи оплаченных заказов за период [from, to) по выбранным измерениям.
+// Возвраты не вычитаются. Итог считается отдельным запросом без группировки, чтобы заказ с товарами
+// нескольких категорий не учитывался в среднем чеке дважды.
+func (s ReportService) GetSalesReport(ctx context.Context, from time.Time, to time.Time, groupBy []string, filter SalesReportFilterDto) (SalesReportDto, error) {
+	params := gen.SalesReportParams{
+		DateFrom:    pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:      pgtype.Timestamp{Time: to, Valid: true},
+		StoreID:     toInt4(filter.StoreId),
+		CategoryID:  toInt4(filter.CategoryId),
+		BrandFilter: pgtype.Text{String: filter.Brand, Valid: filter.Brand != ""},
+		EmployeeID:  toInt4(filter.EmployeeId),
+	}
+	totalParams := params
+	for _, dimension := range groupBy {
+		if !slices.Contains(SalesReportDimensions, dimension) {
+			return SalesReportDto{}, InvalidReportGroupingError
+		}
+		switch dimension {
+		case "day", "week", "month":
+			if params.Period.Valid {
+				return SalesReportDto{}, InvalidReportGroupingError
+			}
+			params.Period = pgtype.Text{String: dimension, Valid: true}
+		case "store":
+			params.ByStore = true
+		case "category":
+			params.ByCategory = true
+		case "brand":
+			params.ByBrand = true
+		case "employee":
+			params.ByEmployee = true
+		}
+	}
+	rows, err := s.Queries.SalesReport(ctx, params)
+	if err != nil {
+		return SalesReportDto{}, err
+	}
+	total, err := s.Queries.SalesReport(ctx, totalParams)
+	if err != nil {
+		return SalesReportDto{}, err
+	}
+
+	response := SalesReportDto{
+		From:    from.Format(DateLayout),
+		To:      to.AddDate(0, 0, -1).Format(DateLayout),
+		GroupBy: groupBy,
+		Rows:    make([]SalesReportRowDto, len(rows)),
+	}
+	for i, row := range rows {
+		response.Rows[i] = toSalesReportRowDto(row)
+	}
+	if len(total) > 0 {
+		response.Total = toSalesReportRowDto(total[0])
+	}
+	return response, nil
+}
+
+// daysAgo возвращает начало дня, отстоящего от сегодняшнего на days дней
+func daysAgo(days int) pgtype.Timestamp {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return pgtype.Timestamp{Time: today.AddDate(0, 0, -days), Valid: true}
+}
+
+// GetLowStock возвращает товары, доступный остаток которых ниже порога. Без threshold порогом служит
+// минимальный остаток точки заказа, и в отчёт попадают только товары с точкой заказа.
+// Запас в днях считается по средним продажам за последние days дней, самые срочные первыми.
+func (s ReportService) GetLowStock(ctx context.Context, threshold *int32, days int) ([]LowStockItemDto, error) {
+	if days <= 0 {
+		return nil, InvalidReportDaysError
+	}
+	goods, err := s.Queries.ListGoods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thresholds := make(map[int32]int32)
+	if threshold == nil {
+		rows, err := s.Queries.ListReorderThresholds(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			thresholds[row.GoodID] = row.MinQuantity
+		}
+	}
+	reservedRows, err := s.Queries.ListReservedQuantities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reserved := make(map[int32]int32, len(reservedRows))
+	for _, row := range reservedRows {
+		reserved[row.GoodID] = row.Reserved
+	}
+	soldRows, err := s.Queries.ListGoodsSold(ctx, daysAgo(days))
+	if err != nil {
+		return nil, err
+	}
+	sold := make(map[int32]int32, len(soldRows))
+	for _, row := range soldRows {
+		sold[row.GoodID] = row.Units
+	}
+
+	response := []LowStockItemDto{}
+	for _, good := range goods {
+		limit, ok := thresholds[good.ID]
+		if threshold != nil {
+			limit, ok = *threshold, true
+		}
+		available := good.Quantity - reserved[good.ID]
+		if !ok || available >= limit {
+			continue
+		}
+		item := LowStockItemDto{
+			GoodId:            good.ID,
+			Article:           good.Article,
+			Name:              good.Name,
+			Quantity:          good.Quantity,
+			Reserved:          reserved[good.ID],
+			Available:         available,
+			Threshold:         limit,
+			AverageDailySales: round2(float64(sold[good.ID]) / float64(days)),
+		}
+		item.DaysOfCover = daysOfCover(available, sold[good.ID], days)
+		response = append(response, item)
+	}
+	sortByDaysOfCover(response)
+	return response, nil
+}
+
+// daysOfCover — на сколько дней хватит доступного остатка при продажах sold за days дней, nil — продаж не было
+func daysOfCover(available int32, sold int32, days int) *float64 {
+	if sold <= 0 {
+		return nil
+	}
+	cover := round2(float64(max(available, 0)) * float64(days) / float64(sold))
+	return &cover
+}
+
+// sortByDaysOfCover ставит самые срочные товары первыми, товары без продаж — в конец
+func sortByDaysOfCover(items []LowStockItemDto) {
+	slices.SortStableFunc(items, func(a, b LowStockItemDto) int {
+		switch {
+		case a.DaysOfCover == nil && b.DaysOfCover == nil:
+			return 0
+		case a.DaysOfCover == nil:
+			return 1
+		case b.DaysOfCover == nil:
+			return -1
+		}
+		return cmp.Compare(*a.DaysOfCover, *b.DaysOfCover)
+	})
+}
+
+// GetDeadStock возвращает товары на складе, которые не продавались последние days дней, с замороженной в них суммой
+func (s ReportService) GetDeadStock(ctx context.Context, days int) (DeadStockDto, error) {
+	if days <= 0 {
+		return DeadStockDto{}, InvalidReportDaysError
+	}
+	rows, err := s.Queries.ListDeadStock(ctx, daysAgo(days))
+	if err != nil {
+		return DeadStockDto{}, err
+	}
+	return toDeadStockDto(days, rows), nil
+}
+
+// toDeadStockDto считает замороженную сумму по себестоимости; товар без себестоимости в сумму не входит
+func toDeadStockDto(days int, rows []gen.ListDeadStockRow) DeadStockDto {
+	response := DeadStockDto{Days: days, Items: make([]DeadStockItemDto, len(rows))}
+	for i, row := range rows {
+		value := fromNumeric(row.Cost) * int64(row.Quantity)
+		response.Items[i] = DeadStockItemDto{
+			GoodId:     row.ID,
+			Article:    row.Article,
+			Name:       row.Name,
+			CategoryId: fromInt4(row.CategoryID),
+			Quantity:   row.Quantity,
+			UnitCost:   fromOptionalNumeric(row.Cost),
+			LastSoldAt: timestampPtr(row.LastSoldAt),
+			Value:      value,
+		}
+		response.TotalValue += value
+	}
+	return response
+}
+
+// bucketStart возвращает начало недели (понедельник) или месяца, в которые попадает день t, как date_trunc
+func bucketStart(t time.Time, interval string) time.Time {
+	if interval == "month" {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func nextBucket(t time.Time, interval string) time.Time {
+	if interval == "month" {
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 7)
+}
+
+// analysisPeriod расширяет период [from, to) до целых недель или месяцев: неполная первая или последняя
+// неделя выглядела бы как провал продаж и завышала коэффициент вариации
+func analysisPeriod(from time.Time, to time.Time, interval string) (time.Time, time.Time) {
+	return bucketStart(from, interval), nextBucket(bucketStart(to.AddDate(0, 0, -1), interval), interval)
+}
+
+// analysisBuckets возвращает начала недель или месяцев целого периода [from, to) из analysisPeriod.
+// Если их больше maxAnalysisBuckets, возвращает InvalidAnalysisPeriodError
+func analysisBuckets(from time.Time, to time.Time, interval string) ([]string, error) {
+	var buckets []string
+	for t := from; t.Before(to); t = nextBucket(t, interval) {
+		if len(buckets) == maxAnalysisBuckets {
+			return nil, InvalidAnalysisPeriodError
+		}
+		buckets = append(buckets, t.Format(DateLayout))
+	}
+	return buckets, nil
+}
+
+// xyzClass определяет класс XYZ по коэффициенту вариации продаж по периодам. Без продаж — Z без коэффициента
+func xyzClass(series []float64) (string, *float64) {
+	if len(series) == 0 {
+		return "Z", nil
+	}
+	mean := sum(series) / float64(len(series))
+	if mean <= 0 {
+		return "Z", nil
+	}
+	variation := round2(deviationFrom(series, mean) / mean * 100)
+	switch {
+	case variation <= xyzLimitX:
+		return "X", &variation
+	case variation <= xyzLimitY:
+		return "Y", &variation
+	}
+	return "Z", &variation
+}
+
+// assignAbcClasses проставляет классы ABC и доли выручки товарам, отсортированным по убыванию выручки
+func assignAbcClasses(items []AbcXyzItemDto, total int64) {
+	cumulative := 0.0
+	for i := range items {
+		item := &items[i]
+		item.Abc = "C"
+		if total <= 0 || item.Revenue <= 0 {
+			continue
+		}
+		// Класс определяется накопленной долей до товара, чтобы товар на границе попадал в старший класс
+		switch {
+		case cumulative < abcLimitA:
+			item.Abc = "A"
+		case cumulative < abcLimitB:
+			item.Abc = "B"
+		}
+		item.Share = float64(item.Revenue) / float64(total) * 100
+		cumulative += item.Share
+		item.Share, item.CumulativeShare = round2(item.Share), round2(cumulative)
+	}
+}
+
+// GetAbcXyz делит ассортимент на классы ABC по вкладу в выручку за период (80% и 95% накопленной доли)
+// и XYZ по коэффициенту вариации продаж в штуках по неделям или месяцам (до 10% и до 25%).
+// Период расширяется до целых недель или месяцев. Товары без продаж попадают в CZ.
+func (s ReportService) GetAbcXyz(ctx context.Context, from time.Time, to time.Time, interval string) (AbcXyzDto, error) {
+	if interval != "week" && interval != "month" {
+		return AbcXyzDto{}, InvalidAnalysisIntervalError
+	}
+	from, to = analysisPeriod(from, to, interval)
+	buckets, err := analysisBuckets(from, to, interval)
+	if err != nil {
+		return AbcXyzDto{}, err
+	}
+	goods, err := s.Queries.ListGoods(ctx)
+	if err != nil {
+		return AbcXyzDto{}, err
+	}
+	rows, err := s.Queries.ListGoodBucketSales(ctx, gen.ListGoodBucketSalesParams{
+		Bucket:   interval,
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: to, Valid: true},
+	})
+	if err != nil {
+		return AbcXyzDto{}, err
+	}
+	index := make(map[string]int, len(buckets))
+	for i, bucket := range buckets {
+		index[bucket] = i
+	}
+	sales := make(map[int32][]float64)
+	revenue := make(map[int32]int64)
+	var total int64
+	for _, row := range rows {
+		if sales[row.GoodID] == nil {
+			sales[row.GoodID] = make([]float64, len(buckets))
+		}
+		if i, ok := index[row.Bucket.Time.Format(DateLayout)]; ok {
+			sales[row.GoodID][i] += float64(row.Units)
+		}
+		revenue[row.GoodID] += row.Revenue
+		total += row.Revenue
+	}
+
+	items := make([]AbcXyzItemDto, len(goods))
+	for i, good := range goods {
+		items[i] = AbcXyzItemDto{GoodId: good.ID, Article: good.Article, Name: good.Name, Revenue: revenue[good.ID], Xyz: "Z"}
+		if series := sales[good.ID]; series != nil {
+			items[i].Units = int64(sum(series))
+			items[i].Xyz, items[i].Variation = xyzClass(series)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b AbcXyzItemDto) int { return cmp.Compare(b.Revenue, a.Revenue) })
+	assignAbcClasses(items, total)
+
+	cells := make(map[string]*AbcXyzCellDto)
+	response := AbcXyzDto{From: from.Format(DateLayout), To: to.AddDate(0, 0, -1).Format(DateLayout), Interval: interval, Items: items}
+	for i := range items {
+		item := &items[i]
+		item.Class = item.Abc + item.Xyz
+		cell, ok := cells[item.Class]
+		if !ok {
+			cell = &AbcXyzCellDto{Class: item.Class}
+			cells[item.Class] = cell
+		}
+		cell.Goods++
+		cell.Revenue += item.Revenue
+	}
+	for _, abc := range []string{"A", "B", "C"} {
+		for _, xyz := range []string{"X", "Y", "Z"} {
+			cell, ok := cells[abc+xyz]
+			if !ok {
+				cell = &AbcXyzCellDto{Class: abc + xyz}
+			}
+			response.Matrix = append(response.Matrix, *cell)
+		}
+	}
+	return response, nil
+}
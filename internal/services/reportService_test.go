@@ -0,0 +1,189 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"github.com/jackc/pgx/v5/pgtype"
+	"slices"
+	"testing"
+	"time"
+)
+
+func day(value string) time.Time {
+	t, err := time.ParseInLocation(DateLayout, value, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestAnalysisPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		interval string
+		wantFrom string
+		wantTo   string
+	}{
+		// 2025-03-05 — среда, 2025-03-19 — среда; to не включительно
+		{"недели расширяются до понедельников", "2025-03-05", "2025-03-20", "week", "2025-03-03", "2025-03-24"},
+		{"целые недели не меняются", "2025-03-03", "2025-03-17", "week", "2025-03-03", "2025-03-17"},
+		{"воскресенье остаётся в своей неделе", "2025-03-09", "2025-03-10", "week", "2025-03-03", "2025-03-10"},
+		{"месяцы расширяются до первых чисел", "2025-01-15", "2025-03-11", "month", "2025-01-01", "2025-04-01"},
+		{"целые месяцы не меняются", "2025-01-01", "2025-03-01", "month", "2025-01-01", "2025-03-01"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, to := analysisPeriod(day(test.from), day(test.to), test.interval)
+			if got := from.Format(DateLayout); got != test.wantFrom {
+				t.Errorf("from = %s, want %s", got, test.wantFrom)
+			}
+			if got := to.Format(DateLayout); got != test.wantTo {
+				t.Errorf("to = %s, want %s", got, test.wantTo)
+			}
+		})
+	}
+}
+
+func TestAnalysisBuckets(t *testing.T) {
+	buckets, err := analysisBuckets(day("2025-03-03"), day("2025-03-24"), "week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2025-03-03", "2025-03-10", "2025-03-17"}; !slices.Equal(buckets, want) {
+		t.Errorf("weeks = %v, want %v", buckets, want)
+	}
+
+	buckets, err = analysisBuckets(day("2024-12-01"), day("2025-03-01"), "month")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2024-12-01", "2025-01-01", "2025-02-01"}; !slices.Equal(buckets, want) {
+		t.Errorf("months = %v, want %v", buckets, want)
+	}
+
+	from := day("2000-01-03")
+	if _, err := analysisBuckets(from, from.AddDate(0, 0, 7*maxAnalysisBuckets), "week"); err != nil {
+		t.Errorf("%d weeks: %v, want no error", maxAnalysisBuckets, err)
+	}
+	if _, err := analysisBuckets(from, from.AddDate(0, 0, 7*(maxAnalysisBuckets+1)), "week"); err != InvalidAnalysisPeriodError {
+		t.Errorf("%d weeks: %v, want InvalidAnalysisPeriodError", maxAnalysisBuckets+1, err)
+	}
+}
+
+func TestXyzClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		series    []float64
+		want      string
+		variation float64
+	}{
+		{"стабильные продажи", []float64{10, 10, 10, 10}, "X", 0},
+		{"граница X", []float64{9, 11}, "X", 10},
+		{"колебания", []float64{8, 12}, "Y", 20},
+		{"граница Y", []float64{75, 125}, "Y", 25},
+		{"нерегулярные продажи", []float64{0, 0, 0, 12}, "Z", 173.21},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			xyz, variation := xyzClass(test.series)
+			if xyz != test.want {
+				t.Errorf("class = %s, want %s", xyz, test.want)
+			}
+			if variation == nil || *variation != test.variation {
+				t.Errorf("variation = %v, want %v", variation, test.variation)
+			}
+		})
+	}
+	for _, series := range [][]float64{nil, {0, 0, 0}} {
+		if xyz, variation := xyzClass(series); xyz != "Z" || variation != nil {
+			t.Errorf("xyzClass(%v) = %s, %v, want Z without variation", series, xyz, variation)
+		}
+	}
+}
+
+func TestAssignAbcClasses(t *testing.T) {
+	items := []AbcXyzItemDto{{Revenue: 70}, {Revenue: 15}, {Revenue: 6}, {Revenue: 5}, {Revenue: 4}, {Revenue: 0}}
+	assignAbcClasses(items, 100)
+	want := []struct {
+		abc        string
+		cumulative float64
+	}{
+		// Товар, на котором накопленная доля переходит 80%, остаётся в A
+		{"A", 70}, {"A", 85}, {"B", 91}, {"B", 96}, {"C", 100}, {"C", 0},
+	}
+	for i, item := range items {
+		if item.Abc != want[i].abc || item.CumulativeShare != want[i].cumulative {
+			t.Errorf("items[%d] = %s %v, want %s %v", i, item.Abc, item.CumulativeShare, want[i].abc, want[i].cumulative)
+		}
+	}
+
+	items = []AbcXyzItemDto{{Revenue: 0}}
+	assignAbcClasses(items, 0)
+	if items[0].Abc != "C" {
+		t.Errorf("no revenue: class = %s, want C", items[0].Abc)
+	}
+}
+
+func TestDaysOfCover(t *testing.T) {
+	cover := func(days float64) *float64 { return &days }
+	tests := []struct {
+		name      string
+		available int32
+		sold      int32
+		days      int
+		want      *float64
+	}{
+		{"продаж не было", 5, 0, 30, nil},
+		{"запас на 10 дней", 10, 30, 30, cover(10)},
+		{"дробный запас", 1, 3, 1, cover(0.33)},
+		{"резервы съели остаток", -2, 6, 30, cover(0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := daysOfCover(test.available, test.sold, test.days)
+			if (got == nil) != (test.want == nil) || got != nil && *got != *test.want {
+				t.Errorf("daysOfCover = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSortByDaysOfCover(t *testing.T) {
+	cover := func(days float64) *float64 { return &days }
+	items := []LowStockItemDto{
+		{GoodId: 1},
+		{GoodId: 2, DaysOfCover: cover(7)},
+		{GoodId: 3, DaysOfCover: cover(0.5)},
+		{GoodId: 4},
+		{GoodId: 5, DaysOfCover: cover(3)},
+	}
+	sortByDaysOfCover(items)
+	got := make([]int32, len(items))
+	for i, item := range items {
+		got[i] = item.GoodId
+	}
+	if want := []int32{3, 5, 2, 1, 4}; !slices.Equal(got, want) {
+		t.Errorf("sortByDaysOfCover = %v, want %v", got, want)
+	}
+}
+
+func TestToDeadStockDto(t *testing.T) {
+	rows := []gen.ListDeadStockRow{
+		{ID: 1, Quantity: 3, Cost: toNumeric(20000)},
+		{ID: 2, Quantity: 10},
+		{ID: 3, Quantity: 1, Cost: toNumeric(4500), LastSoldAt: pgtype.Timestamp{Time: day("2024-01-15"), Valid: true}},
+	}
+	report := toDeadStockDto(90, rows)
+	if report.Days != 90 || len(report.Items) != 3 {
+		t.Fatalf("toDeadStockDto = %+v, want 90 days and 3 items", report)
+	}
+	if report.TotalValue != 64500 {
+		t.Errorf("toDeadStockDto total = %d, want 64500", report.TotalValue)
+	}
+	if report.Items[1].UnitCost != nil || report.Items[1].Value != 0 {
+		t.Errorf("toDeadStockDto без себестоимости = %+v, want no cost and zero value", report.Items[1])
+	}
+	if report.Items[0].LastSoldAt != nil || report.Items[2].LastSoldAt == nil {
+		t.Errorf("toDeadStockDto last sold = %v, %v, want nil and set", report.Items[0].LastSoldAt, report.Items[2].LastSoldAt)
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log"
+	"time"
+)
+
+const (
+	ReservationStatusActive   = "active"
+	ReservationStatusConsumed = "consumed"
+	ReservationStatusReleased = "released"
+	ReservationStatusExpired  = "expired"
+
+	DefaultReservationTTL = 15 * time.Minute
+
+	// Проданный товар списывается со склада при проведении резерва
+	StockAdjustmentSale = "sale"
+)
+
+type ReservationDto struct {
+	Id        int32     `json:"id"`
+	GoodId    int32     `json:"good_id"`
+	StoreId   int32     `json:"store_id"`
+	Quantity  int32     `json:"quantity"`
+	Owner     string    `json:"owner"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateReservationDto struct {
+	GoodId   int32  `json:"good_id"`
+	StoreId  int32  `json:"store_id"`
+	Quantity int32  `json:"quantity"`
+	Owner    string `json:"owner"`
+	// Время жизни резерва в секундах, по умолчанию 15 минут
+	TtlSeconds int32 `json:"ttl_seconds"`
+}
+
+type ReservationInterface interface {
+	CreateReservation(ctx context.Context, dto CreateReservationDto) (ReservationDto, error)
+	GetReservation(ctx context.Context, id int32) (ReservationDto, error)
+	GetActiveReservations(ctx context.Context, owner string) ([]ReservationDto, error)
+	CommitReservation(ctx context.Context, id int32) (ReservationDto, error)
+	ReleaseReservation(ctx context.Context, id int32) (ReservationDto, error)
+	ReleaseExpired(ctx context.Context) (int64, error)
+}
+
+type ReservationService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var ReservationNotFound = errors.New("reservation not found")
+var ReservationNotActiveError = errors.New("reservation is not active")
+var InsufficientStockError = errors.New("insufficient stock")
+var InvalidQuantityError = errors.New("quantity must be positive")
+
+func ToReservationDto(reservation gen.Reservation) ReservationDto {
+	return ReservationDto{
+		Id:        reservation.ID,
+		GoodId:    reservation.GoodID,
+		StoreId:   reservation.StoreID,
+		Quantity:  reservation.Quantity,
+		Owner:     reservation.Owner,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt.Time,
+		CreatedAt: reservation.CreatedAt.Time,
+	}
+}
+
+// reservationTTL — время жизни резерва: заданное в запросе или по умолчанию
+func reservationTTL(ttlSeconds int32) time.Duration {
+	if ttlSeconds > 0 {
+		return time.Duration(ttlSeconds) * time.Second
+	}
+	return DefaultReservationTTL
+}
+
+// checkAvailable проверяет, что свободного остатка хватает: зарезервированное другими продать нельзя
+func checkAvailable(onHand int32, reserved int32, quantity int32) error {
+	if onHand-reserved < quantity {
+		return InsufficientStockError
+	}
+	return nil
+}
+
+// reserveGood создаёт резерв внутри уже открытой транзакции.
+// Строка товара блокируется, поэтому два кассира не смогут зарезервировать последнюю единицу одновременно.
+func reserveGood(ctx context.Context, q *gen.Queries, dto CreateReservationDto) (gen.Reservation, error) {
+	if dto.Quantity <= 0 {
+		return gen.Reservation{}, InvalidQuantityError
+	}
+	good, err := q.GetGoodForUpdate(ctx, dto.GoodId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Reservation{}, ProductNotFound
+		}
+		return gen.Reservation{}, err
+	}
+	// Удалённый товар нельзя ни зарезервировать, ни продать
+	if !good.IsAlive {
+		return gen.Reservation{}, ProductNotFound
+	}
+	if _, err := q.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Reservation{}, StoreNotFound
+		}
+		return gen.Reservation{}, err
+	}
+	reserved, err := q.GetReservedQuantity(ctx, dto.GoodId)
+	if err != nil {
+		return gen.Reservation{}, err
+	}
+	if err := checkAvailable(good.Quantity, reserved, dto.Quantity); err != nil {
+		return gen.Reservation{}, err
+	}
+	now := time.Now()
+	return q.CreateReservation(ctx, gen.CreateReservationParams{
+		GoodID:    dto.GoodId,
+		StoreID:   dto.StoreId,
+		Quantity:  dto.Quantity,
+		Owner:     dto.Owner,
+		Status:    ReservationStatusActive,
+		ExpiresAt: pgtype.Timestamp{Time: now.Add(reservationTTL(dto.TtlSeconds)), Valid: true},
+		CreatedAt: pgtype.Timestamp{Time: now, Valid: true},
+	})
+}
+
+// commitReservation списывает зарезервированное количество со склада
+func commitReservation(ctx context.Context, q *gen.Queries, id int32) (gen.Reservation, error) {
+	reservation, err := q.ConsumeReservation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Reservation{}, ReservationNotActiveError
+		}
+		return gen.Reservation{}, err
+	}
+	_, err = q.DecreaseGoodQuantity(ctx, gen.DecreaseGoodQuantityParams{
+		ID:       reservation.GoodID,
+		Quantity: reservation.Quantity,
+	})
+	if err != nil {
+		return gen.Reservation{}, err
+	}
+	_, err = q.CreateStockAdjustment(ctx, gen.CreateStockAdjustmentParams{
+		GoodID:    reservation.GoodID,
+		StoreID:   reservation.StoreID,
+		Quantity:  -reservation.Quantity,
+		Reason:    StockAdjustmentSale,
+		Comment:   pgtype.Text{String: reservation.Owner, Valid: true},
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return gen.Reservation{}, err
+	}
+	return reservation, nil
+}
+
+func (s ReservationService) CreateReservation(ctx context.Context, dto CreateReservationDto) (ReservationDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ReservationDto{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	reservation, err := reserveGood(ctx, s.Queries.WithTx(tx), dto)
+	if err != nil {
+		return ReservationDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ReservationDto{}, err
+	}
+	return ToReservationDto(reservation), nil
+}
+
+func (s ReservationService) GetReservation(ctx context.Context, id int32) (ReservationDto, error) {
+	reservation, err := s.Queries.GetReservation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReservationDto{}, ReservationNotFound
+		}
+		return ReservationDto{}, err
+	}
+	return ToReservationDto(reservation), nil
+}
+
+func (s ReservationService) GetActiveReservations(ctx context.Context, owner string) ([]ReservationDto, error) {
+	var reservations []gen.Reservation
+	var err error
+	if owner != "" {
+		reservations, err = s.Queries.ListActiveReservationsByOwner(ctx, owner)
+	} else {
+		reservations, err = s.Queries.ListActiveReservations(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ReservationDto, len(reservations))
+	for i, reservation := range reservations {
+		response[i] = ToReservationDto(reservation)
+	}
+	return response, nil
+}
+
+func (s ReservationService) CommitReservation(ctx context.Context, id int32) (ReservationDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ReservationDto{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	reservation, err := commitReservation(ctx, s.Queries.WithTx(tx), id)
+	if err != nil {
+		return ReservationDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ReservationDto{}, err
+	}
+	return ToReservationDto(reservation), nil
+}
+
+func (s ReservationService) ReleaseReservation(ctx context.Context, id int32) (ReservationDto, error) {
+	reservation, err := s.Queries.ReleaseReservation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReservationDto{}, ReservationNotActiveError
+		}
+		return ReservationDto{}, err
+	}
+	return ToReservationDto(reservation), nil
+}
+
+func (s ReservationService) ReleaseExpired(ctx context.Context) (int64, error) {
+	return s.Queries.ExpireReservations(ctx)
+}
+
+// StartSweeper в фоне снимает просроченные резервы, пока не отменён ctx
+func (s ReservationService) StartSweeper(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				released, err := s.ReleaseExpired(ctx)
+				if err != nil {
+					log.Printf("reservation sweeper: %v", err)
+					continue
+				}
+				if released > 0 {
+					log.Printf("reservation sweeper: released %d expired reservations", released)
+				}
+			}
+		}
+	}()
+}
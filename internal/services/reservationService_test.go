@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReservationTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttlSeconds int32
+		want       time.Duration
+	}{
+		{"по умолчанию", 0, DefaultReservationTTL},
+		{"отрицательный срок", -60, DefaultReservationTTL},
+		{"срок заказа", int32(OrderReservationTTL / time.Second), OrderReservationTTL},
+		{"свой срок", 90, 90 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := reservationTTL(test.ttlSeconds); got != test.want {
+				t.Errorf("reservationTTL = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckAvailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		onHand   int32
+		reserved int32
+		quantity int32
+		want     error
+	}{
+		{"хватает", 10, 3, 7, nil},
+		{"последняя свободная единица", 5, 4, 1, nil},
+		{"всё зарезервировано", 5, 5, 1, InsufficientStockError},
+		{"не хватает с учётом резервов", 10, 3, 8, InsufficientStockError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkAvailable(test.onHand, test.reserved, test.quantity); !errors.Is(err, test.want) {
+				t.Errorf("checkAvailable = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestOrderReservationOwner(t *testing.T) {
+	if got := OrderReservationOwner(42); got != "order:42" {
+		t.Errorf("OrderReservationOwner = %q, want %q", got, "order:42")
+	}
+}
@@ -3,6 +3,9 @@ package services
 import (
 	"HomeApplianceStore/pkg/gen"
 	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"slices"
 	"time"
 )
 
@@ -69,3 +72,70 @@ func (rs *RoleService) UpdateRole(ctx context.Context, id int32, name string) (R
 	response := ToRoleDto(role)
 	return response, nil
 }
+
+// Права, которые можно выдать роли
+const (
+	PermissionApproveWriteOffs = "write_offs.approve"
+	// Подключение 1С к обмену каталогом и заказами
+	PermissionExchange = "exchange.1c"
+)
+
+var Permissions = []string{
+	PermissionApproveWriteOffs,
+	PermissionExchange,
+}
+
+var UnknownPermissionError = errors.New("unknown permission")
+var PermissionDeniedError = errors.New("employee role does not have the required permission")
+
+func (rs *RoleService) GetPermissions(ctx context.Context, id int32) ([]string, error) {
+	if _, err := rs.Queries.GetRole(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, RoleNotFound
+		}
+		return nil, err
+	}
+	permissions, err := rs.Queries.ListRolePermissions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]string, len(permissions))
+	for i, p := range permissions {
+		response[i] = p.Permission
+	}
+	return response, nil
+}
+
+func (rs *RoleService) GrantPermission(ctx context.Context, id int32, permission string) ([]string, error) {
+	if !slices.Contains(Permissions, permission) {
+		return nil, UnknownPermissionError
+	}
+	if _, err := rs.Queries.GetRole(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, RoleNotFound
+		}
+		return nil, err
+	}
+	_, err := rs.Queries.GrantRolePermission(ctx, gen.GrantRolePermissionParams{RoleID: id, Permission: permission})
+	if err != nil {
+		return nil, err
+	}
+	return rs.GetPermissions(ctx, id)
+}
+
+func (rs *RoleService) RevokePermission(ctx context.Context, id int32, permission string) error {
+	_, err := rs.Queries.RevokeRolePermission(ctx, gen.RevokeRolePermissionParams{RoleID: id, Permission: permission})
+	return err
+}
+
+// checkPermission проверяет, что роль сотрудника имеет право
+func checkPermission(ctx context.Context, q *gen.Queries, employeeId int32, permission string) error {
+	granted, err := q.CountEmployeePermissions(ctx, gen.CountEmployeePermissionsParams{ID: employeeId, Permission: permission})
+	if err != nil {
+		return err
+	}
+	if granted == 0 {
+		return PermissionDeniedError
+	}
+	return nil
+}
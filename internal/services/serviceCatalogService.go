@@ -0,0 +1,156 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// ServiceDto — услуга из каталога (установка, подключение, ремонт). Остатков у услуг нет,
+// вместо этого указывается длительность работы техника.
+type ServiceDto struct {
+	Id              int32     `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description,omitempty"`
+	DurationMinutes int32     `json:"duration_minutes"`
+	Price           int64     `json:"price"`
+	TaxRateId       *int32    `json:"tax_rate_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	IsAlive         bool      `json:"is_alive"`
+}
+
+type CreateServiceDto struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DurationMinutes int32  `json:"duration_minutes"`
+	Price           int64  `json:"price"`
+	TaxRateId       *int32 `json:"tax_rate_id"`
+}
+
+type UpdateServiceDto struct {
+	Id              int32  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DurationMinutes int32  `json:"duration_minutes"`
+	Price           int64  `json:"price"`
+	TaxRateId       *int32 `json:"tax_rate_id"`
+	IsAlive         bool   `json:"is_alive"`
+}
+
+type ServiceCatalogInterface interface {
+	CreateService(ctx context.Context, dto CreateServiceDto) (ServiceDto, error)
+	GetService(ctx context.Context, id int32) (ServiceDto, error)
+	GetServices(ctx context.Context) ([]ServiceDto, error)
+	UpdateService(ctx context.Context, dto UpdateServiceDto) (ServiceDto, error)
+	DeleteService(ctx context.Context, id int32) error
+}
+
+type ServiceCatalogService struct {
+	Queries gen.Queries
+}
+
+var ServiceNotFound = errors.New("service not found")
+var InvalidServiceError = errors.New("service requires a name, positive duration and non-negative price")
+
+func ToServiceDto(service gen.Service) ServiceDto {
+	return ServiceDto{
+		Id:              service.ID,
+		Name:            service.Name,
+		Description:     service.Description.String,
+		DurationMinutes: service.DurationMinutes,
+		Price:           fromNumeric(service.Price),
+		TaxRateId:       fromInt4(service.TaxRateID),
+		CreatedAt:       service.CreatedAt.Time,
+		IsAlive:         service.IsAlive,
+	}
+}
+
+func validateService(name string, durationMinutes int32, price int64) error {
+	if name == "" || durationMinutes <= 0 || price < 0 {
+		return InvalidServiceError
+	}
+	return nil
+}
+
+// resolveServiceVatRate возвращает ставку НДС услуги, а если она не задана или удалена — общую ставку
+func resolveServiceVatRate(ctx context.Context, q *gen.Queries, serviceId int32) (pgtype.Int4, error) {
+	rate, err := q.GetServiceTaxRate(ctx, serviceId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.Int4{Int32: DefaultVatRate, Valid: true}, nil
+		}
+		return pgtype.Int4{}, err
+	}
+	return rate.Rate, nil
+}
+
+func (s ServiceCatalogService) CreateService(ctx context.Context, dto CreateServiceDto) (ServiceDto, error) {
+	if err := validateService(dto.Name, dto.DurationMinutes, dto.Price); err != nil {
+		return ServiceDto{}, err
+	}
+	service, err := s.Queries.CreateService(ctx, gen.CreateServiceParams{
+		Name:            dto.Name,
+		Description:     pgtype.Text{String: dto.Description, Valid: dto.Description != ""},
+		DurationMinutes: dto.DurationMinutes,
+		Price:           toNumeric(dto.Price),
+		TaxRateID:       toInt4(dto.TaxRateId),
+		CreatedAt:       pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:         true,
+	})
+	if err != nil {
+		return ServiceDto{}, err
+	}
+	return ToServiceDto(service), nil
+}
+
+func (s ServiceCatalogService) GetService(ctx context.Context, id int32) (ServiceDto, error) {
+	service, err := s.Queries.GetService(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ServiceDto{}, ServiceNotFound
+		}
+		return ServiceDto{}, err
+	}
+	return ToServiceDto(service), nil
+}
+
+func (s ServiceCatalogService) GetServices(ctx context.Context) ([]ServiceDto, error) {
+	services, err := s.Queries.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ServiceDto, len(services))
+	for i, service := range services {
+		response[i] = ToServiceDto(service)
+	}
+	return response, nil
+}
+
+func (s ServiceCatalogService) UpdateService(ctx context.Context, dto UpdateServiceDto) (ServiceDto, error) {
+	if err := validateService(dto.Name, dto.DurationMinutes, dto.Price); err != nil {
+		return ServiceDto{}, err
+	}
+	service, err := s.Queries.UpdateService(ctx, gen.UpdateServiceParams{
+		ID:              dto.Id,
+		Name:            dto.Name,
+		Description:     pgtype.Text{String: dto.Description, Valid: dto.Description != ""},
+		DurationMinutes: dto.DurationMinutes,
+		Price:           toNumeric(dto.Price),
+		TaxRateID:       toInt4(dto.TaxRateId),
+		IsAlive:         dto.IsAlive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ServiceDto{}, ServiceNotFound
+		}
+		return ServiceDto{}, err
+	}
+	return ToServiceDto(service), nil
+}
+
+func (s ServiceCatalogService) DeleteService(ctx context.Context, id int32) error {
+	return s.Queries.DeleteService(ctx, id)
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	// Максимальная длительность одной смены
+	MaxShiftDuration = 12 * time.Hour
+	// Максимум запланированных часов сотрудника за календарную неделю (пн–вс)
+	MaxWeeklyShiftHours = 40
+	// За сколько до начала смены можно отметить приход
+	ShiftClockInEarly = time.Hour
+)
+
+const monthLayout = "2006-01"
+
+type ShiftDto struct {
+	Id         int32      `json:"id"`
+	EmployeeId int32      `json:"employee_id"`
+	StoreId    int32      `json:"store_id"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	ClockInAt  *time.Time `json:"clock_in_at"`
+	ClockOutAt *time.Time `json:"clock_out_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateShiftDto struct {
+	EmployeeId int32     `json:"employee_id"`
+	StoreId    int32     `json:"store_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+type UpdateShiftDto struct {
+	StoreId  int32     `json:"store_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type TimesheetRowDto struct {
+	ShiftId        int32      `json:"shift_id"`
+	Date           string     `json:"date"`
+	StoreId        int32      `json:"store_id"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	ClockInAt      *time.Time `json:"clock_in_at"`
+	ClockOutAt     *time.Time `json:"clock_out_at"`
+	PlannedMinutes int32      `json:"planned_minutes"`
+	// Фактически отработано между отметками прихода и ухода, 0 — если отметок нет
+	WorkedMinutes int32 `json:"worked_minutes"`
+}
+
+type TimesheetDto struct {
+	EmployeeId     int32             `json:"employee_id"`
+	Month          string            `json:"month"`
+	Rows           []TimesheetRowDto `json:"rows"`
+	PlannedMinutes int32             `json:"planned_minutes"`
+	WorkedMinutes  int32             `json:"worked_minutes"`
+}
+
+type ShiftInterface interface {
+	CreateShift(ctx context.Context, dto CreateShiftDto) (ShiftDto, error)
+	GetShift(ctx context.Context, id int32) (ShiftDto, error)
+	GetStoreShifts(ctx context.Context, storeId int32, from time.Time, to time.Time) ([]ShiftDto, error)
+	GetEmployeeShifts(ctx context.Context, employeeId int32, from time.Time, to time.Time) ([]ShiftDto, error)
+	UpdateShift(ctx context.Context, id int32, dto UpdateShiftDto) (ShiftDto, error)
+	DeleteShift(ctx context.Context, id int32) error
+	ClockIn(ctx context.Context, id int32) (ShiftDto, error)
+	ClockOut(ctx context.Context, id int32) (ShiftDto, error)
+	GetTimesheet(ctx context.Context, employeeId int32, month string) (TimesheetDto, error)
+}
+
+type ShiftService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var ShiftNotFound = errors.New("shift not found")
+var InvalidShiftError = errors.New("shift must end after it starts and last no more than 12 hours")
+var ShiftOverlapError = errors.New("shift overlaps another shift of the employee")
+var ShiftWeeklyHoursError = errors.New("shift exceeds weekly hours limit of the employee")
+var EmployeeNotInStoreError = errors.New("employee is not assigned to the store on the shift date")
+var ShiftClockError = errors.New("clock-in or clock-out is not allowed for this shift now")
+var InvalidMonthError = errors.New("month must be in YYYY-MM format")
+
+func timestampPtr(value pgtype.Timestamp) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Time
+	return &result
+}
+
+func ToShiftDto(shift gen.Shift) ShiftDto {
+	return ShiftDto{
+		Id:         shift.ID,
+		EmployeeId: shift.EmployeeID,
+		StoreId:    shift.StoreID,
+		StartsAt:   shift.StartsAt.Time,
+		EndsAt:     shift.EndsAt.Time,
+		ClockInAt:  timestampPtr(shift.ClockInAt),
+		ClockOutAt: timestampPtr(shift.ClockOutAt),
+		CreatedAt:  shift.CreatedAt.Time,
+	}
+}
+
+// weekBounds возвращает календарную неделю [понедельник, следующий понедельник), в которую попадает момент
+func weekBounds(moment time.Time) (time.Time, time.Time) {
+	day := time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, moment.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	monday := day.AddDate(0, 0, -offset)
+	return monday, monday.AddDate(0, 0, 7)
+}
+
+// validateShift проверяет смену перед сохранением: длительность, назначение сотрудника в магазин,
+// пересечения с другими сменами и недельную норму. Вызывается под блокировкой строки сотрудника.
+func validateShift(ctx context.Context, q *gen.Queries, shiftId int32, employeeId int32, storeId int32, startsAt time.Time, endsAt time.Time) error {
+	duration := endsAt.Sub(startsAt)
+	if err := checkShiftDuration(duration); err != nil {
+		return err
+	}
+	assignments, err := q.CountEmployeeStoreAssignments(ctx, gen.CountEmployeeStoreAssignmentsParams{
+		EmployeeID: employeeId,
+		StoreID:    storeId,
+		OnDate:     pgtype.Date{Time: time.Date(startsAt.Year(), startsAt.Month(), startsAt.Day(), 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if assignments == 0 {
+		return EmployeeNotInStoreError
+	}
+	overlapping, err := q.CountOverlappingShifts(ctx, gen.CountOverlappingShiftsParams{
+		EmployeeID: employeeId,
+		ExcludeID:  shiftId,
+		StartsAt:   pgtype.Timestamp{Time: startsAt, Valid: true},
+		EndsAt:     pgtype.Timestamp{Time: endsAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return ShiftOverlapError
+	}
+	weekStart, weekEnd := weekBounds(startsAt)
+	planned, err := q.SumShiftMinutes(ctx, gen.SumShiftMinutesParams{
+		EmployeeID: employeeId,
+		ExcludeID:  shiftId,
+		DateFrom:   pgtype.Timestamp{Time: weekStart, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: weekEnd, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	return checkWeeklyHours(planned, duration)
+}
+
+func checkShiftDuration(duration time.Duration) error {
+	if duration <= 0 || duration > MaxShiftDuration {
+		return InvalidShiftError
+	}
+	return nil
+}
+
+// checkWeeklyHours проверяет, что смена вместе с уже запланированными минутами недели укладывается в норму
+func checkWeeklyHours(plannedMinutes int32, duration time.Duration) error {
+	if time.Duration(plannedMinutes)*time.Minute+duration > MaxWeeklyShiftHours*time.Hour {
+		return ShiftWeeklyHoursError
+	}
+	return nil
+}
+
+// checkClockIn разрешает отметить приход один раз, не раньше чем за час до начала и не позже конца смены
+func checkClockIn(shift gen.Shift, now time.Time) error {
+	if shift.ClockInAt.Valid || now.Before(shift.StartsAt.Time.Add(-ShiftClockInEarly)) || now.After(shift.EndsAt.Time) {
+		return ShiftClockError
+	}
+	return nil
+}
+
+// toTimesheetRow считает плановые и фактические минуты смены; без обеих отметок фактически отработано 0
+func toTimesheetRow(shift gen.Shift) TimesheetRowDto {
+	row := TimesheetRowDto{
+		ShiftId:        shift.ID,
+		Date:           shift.StartsAt.Time.Format(DateLayout),
+		StoreId:        shift.StoreID,
+		StartsAt:       shift.StartsAt.Time,
+		EndsAt:         shift.EndsAt.Time,
+		ClockInAt:      timestampPtr(shift.ClockInAt),
+		ClockOutAt:     timestampPtr(shift.ClockOutAt),
+		PlannedMinutes: int32(shift.EndsAt.Time.Sub(shift.StartsAt.Time) / time.Minute),
+	}
+	if shift.ClockInAt.Valid && shift.ClockOutAt.Valid {
+		row.WorkedMinutes = int32(shift.ClockOutAt.Time.Sub(shift.ClockInAt.Time) / time.Minute)
+	}
+	return row
+}
+
+func (s ShiftService) CreateShift(ctx context.Context, dto CreateShiftDto) (ShiftDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := q.LockEmployee(ctx, dto.EmployeeId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, EmployeeNotFound
+		}
+		return ShiftDto{}, err
+	}
+	if err := validateShift(ctx, q, 0, dto.EmployeeId, dto.StoreId, dto.StartsAt, dto.EndsAt); err != nil {
+		return ShiftDto{}, err
+	}
+	shift, err := q.CreateShift(ctx, gen.CreateShiftParams{
+		EmployeeID: dto.EmployeeId,
+		StoreID:    dto.StoreId,
+		StartsAt:   pgtype.Timestamp{Time: dto.StartsAt, Valid: true},
+		EndsAt:     pgtype.Timestamp{Time: dto.EndsAt, Valid: true},
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:    true,
+	})
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ShiftDto{}, err
+	}
+	return ToShiftDto(shift), nil
+}
+
+func (s ShiftService) GetShift(ctx context.Context, id int32) (ShiftDto, error) {
+	shift, err := s.Queries.GetShift(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, ShiftNotFound
+		}
+		return ShiftDto{}, err
+	}
+	return ToShiftDto(shift), nil
+}
+
+func (s ShiftService) GetStoreShifts(ctx context.Context, storeId int32, from time.Time, to time.Time) ([]ShiftDto, error) {
+	shifts, err := s.Queries.ListStoreShifts(ctx, gen.ListStoreShiftsParams{
+		StoreID:  storeId,
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ShiftDto, len(shifts))
+	for i, shift := range shifts {
+		response[i] = ToShiftDto(shift)
+	}
+	return response, nil
+}
+
+func (s ShiftService) GetEmployeeShifts(ctx context.Context, employeeId int32, from time.Time, to time.Time) ([]ShiftDto, error) {
+	shifts, err := s.Queries.ListEmployeeShifts(ctx, gen.ListEmployeeShiftsParams{
+		EmployeeID: employeeId,
+		DateFrom:   pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]ShiftDto, len(shifts))
+	for i, shift := range shifts {
+		response[i] = ToShiftDto(shift)
+	}
+	return response, nil
+}
+
+// UpdateShift переносит смену; смену, на которую сотрудник уже пришёл, менять нельзя
+func (s ShiftService) UpdateShift(ctx context.Context, id int32, dto UpdateShiftDto) (ShiftDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	shift, err := q.GetShift(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, ShiftNotFound
+		}
+		return ShiftDto{}, err
+	}
+	if _, err := q.LockEmployee(ctx, shift.EmployeeID); err != nil {
+		return ShiftDto{}, err
+	}
+	shift, err = q.GetShiftForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, ShiftNotFound
+		}
+		return ShiftDto{}, err
+	}
+	if shift.ClockInAt.Valid {
+		return ShiftDto{}, ShiftClockError
+	}
+	if err := validateShift(ctx, q, shift.ID, shift.EmployeeID, dto.StoreId, dto.StartsAt, dto.EndsAt); err != nil {
+		return ShiftDto{}, err
+	}
+	shift, err = q.UpdateShift(ctx, gen.UpdateShiftParams{
+		ID:       shift.ID,
+		StoreID:  dto.StoreId,
+		StartsAt: pgtype.Timestamp{Time: dto.StartsAt, Valid: true},
+		EndsAt:   pgtype.Timestamp{Time: dto.EndsAt, Valid: true},
+	})
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ShiftDto{}, err
+	}
+	return ToShiftDto(shift), nil
+}
+
+func (s ShiftService) DeleteShift(ctx context.Context, id int32) error {
+	shift, err := s.Queries.GetShift(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftNotFound
+		}
+		return err
+	}
+	if shift.ClockInAt.Valid {
+		return ShiftClockError
+	}
+	return s.Queries.DeleteShift(ctx, id)
+}
+
+// ClockIn отмечает приход: не раньше чем за час до начала и не позже конца смены
+func (s ShiftService) ClockIn(ctx context.Context, id int32) (ShiftDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	shift, err := q.GetShiftForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, ShiftNotFound
+		}
+		return ShiftDto{}, err
+	}
+	now := time.Now()
+	if err := checkClockIn(shift, now); err != nil {
+		return ShiftDto{}, err
+	}
+	shift, err = q.ClockInShift(ctx, gen.ClockInShiftParams{ID: id, ClockInAt: pgtype.Timestamp{Time: now, Valid: true}})
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ShiftDto{}, err
+	}
+	return ToShiftDto(shift), nil
+}
+
+func (s ShiftService) ClockOut(ctx context.Context, id int32) (ShiftDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	shift, err := q.GetShiftForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ShiftDto{}, ShiftNotFound
+		}
+		return ShiftDto{}, err
+	}
+	if !shift.ClockInAt.Valid || shift.ClockOutAt.Valid {
+		return ShiftDto{}, ShiftClockError
+	}
+	shift, err = q.ClockOutShift(ctx, gen.ClockOutShiftParams{ID: id, ClockOutAt: pgtype.Timestamp{Time: time.Now(), Valid: true}})
+	if err != nil {
+		return ShiftDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ShiftDto{}, err
+	}
+	return ToShiftDto(shift), nil
+}
+
+// GetTimesheet собирает табель сотрудника за месяц YYYY-MM (по умолчанию текущий)
+func (s ShiftService) GetTimesheet(ctx context.Context, employeeId int32, month string) (TimesheetDto, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	if month != "" {
+		parsed, err := time.ParseInLocation(monthLayout, month, time.Local)
+		if err != nil {
+			return TimesheetDto{}, InvalidMonthError
+		}
+		start = parsed
+	}
+	shifts, err := s.Queries.ListEmployeeShifts(ctx, gen.ListEmployeeShiftsParams{
+		EmployeeID: employeeId,
+		DateFrom:   pgtype.Timestamp{Time: start, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: start.AddDate(0, 1, 0), Valid: true},
+	})
+	if err != nil {
+		return TimesheetDto{}, err
+	}
+	response := TimesheetDto{
+		EmployeeId: employeeId,
+		Month:      start.Format(monthLayout),
+		Rows:       make([]TimesheetRowDto, len(shifts)),
+	}
+	for i, shift := range shifts {
+		row := toTimesheetRow(shift)
+		response.Rows[i] = row
+		response.PlannedMinutes += row.PlannedMinutes
+		response.WorkedMinutes += row.WorkedMinutes
+	}
+	return response, nil
+}
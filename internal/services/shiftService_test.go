@@ -0,0 +1,133 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+	"time"
+)
+
+func TestWeekBounds(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		moment time.Time
+	}{
+		{"понедельник утром", time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)},
+		{"среда", time.Date(2024, 5, 8, 15, 30, 0, 0, time.UTC)},
+		{"воскресенье вечером", time.Date(2024, 5, 12, 23, 59, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, to := weekBounds(test.moment)
+			if !from.Equal(monday) || !to.Equal(monday.AddDate(0, 0, 7)) {
+				t.Errorf("weekBounds = [%v, %v), want [%v, %v)", from, to, monday, monday.AddDate(0, 0, 7))
+			}
+		})
+	}
+}
+
+func TestCheckShiftDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     error
+	}{
+		{8 * time.Hour, nil},
+		{MaxShiftDuration, nil},
+		{MaxShiftDuration + time.Minute, InvalidShiftError},
+		{0, InvalidShiftError},
+		{-time.Hour, InvalidShiftError},
+	}
+	for _, test := range tests {
+		t.Run(test.duration.String(), func(t *testing.T) {
+			if err := checkShiftDuration(test.duration); !errors.Is(err, test.want) {
+				t.Errorf("checkShiftDuration = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckWeeklyHours(t *testing.T) {
+	tests := []struct {
+		name           string
+		plannedMinutes int32
+		duration       time.Duration
+		want           error
+	}{
+		{"пустая неделя", 0, 8 * time.Hour, nil},
+		{"ровно норма", 32 * 60, 8 * time.Hour, nil},
+		{"на минуту больше нормы", 32*60 + 1, 8 * time.Hour, ShiftWeeklyHoursError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkWeeklyHours(test.plannedMinutes, test.duration); !errors.Is(err, test.want) {
+				t.Errorf("checkWeeklyHours = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckClockIn(t *testing.T) {
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	shift := gen.Shift{
+		StartsAt: pgtype.Timestamp{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamp{Time: start.Add(8 * time.Hour), Valid: true},
+	}
+	clockedIn := shift
+	clockedIn.ClockInAt = pgtype.Timestamp{Time: start, Valid: true}
+	tests := []struct {
+		name  string
+		shift gen.Shift
+		now   time.Time
+		want  error
+	}{
+		{"вовремя", shift, start, nil},
+		{"за час до начала", shift, start.Add(-ShiftClockInEarly), nil},
+		{"слишком рано", shift, start.Add(-ShiftClockInEarly - time.Minute), ShiftClockError},
+		{"после конца смены", shift, start.Add(8*time.Hour + time.Minute), ShiftClockError},
+		{"повторная отметка", clockedIn, start.Add(time.Hour), ShiftClockError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkClockIn(test.shift, test.now); !errors.Is(err, test.want) {
+				t.Errorf("checkClockIn = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestToTimesheetRow(t *testing.T) {
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	shift := gen.Shift{
+		ID:       3,
+		StoreID:  1,
+		StartsAt: pgtype.Timestamp{Time: start, Valid: true},
+		EndsAt:   pgtype.Timestamp{Time: start.Add(8 * time.Hour), Valid: true},
+	}
+	clockedIn := shift
+	clockedIn.ClockInAt = pgtype.Timestamp{Time: start.Add(10 * time.Minute), Valid: true}
+	worked := clockedIn
+	worked.ClockOutAt = pgtype.Timestamp{Time: start.Add(7*time.Hour + 40*time.Minute), Valid: true}
+	tests := []struct {
+		name        string
+		shift       gen.Shift
+		wantPlanned int32
+		wantWorked  int32
+	}{
+		{"без отметок", shift, 480, 0},
+		{"только приход", clockedIn, 480, 0},
+		{"приход и уход", worked, 480, 450},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			row := toTimesheetRow(test.shift)
+			if row.PlannedMinutes != test.wantPlanned || row.WorkedMinutes != test.wantWorked {
+				t.Errorf("toTimesheetRow minutes = %d/%d, want %d/%d", row.PlannedMinutes, row.WorkedMinutes, test.wantPlanned, test.wantWorked)
+			}
+			if row.Date != "2024-05-06" {
+				t.Errorf("toTimesheetRow date = %s, want 2024-05-06", row.Date)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	StocktakeStatusCounting  = "counting"
+	StocktakeStatusApproved  = "approved"
+	StocktakeStatusCancelled = "cancelled"
+
+	StockAdjustmentStocktake = "stocktake"
+)
+
+type StocktakeLineDto struct {
+	GoodId           int32  `json:"good_id"`
+	Article          string `json:"article"`
+	Name             string `json:"name"`
+	ExpectedQuantity int32  `json:"expected_quantity"`
+	// Сумма подсчётов всех сотрудников, null — товар ещё не считали
+	CountedQuantity *int32 `json:"counted_quantity"`
+	Counters        int32  `json:"counters"`
+	// Расхождение: посчитано минус ожидалось, отрицательное — недостача
+	Variance       *int32 `json:"variance"`
+	VarianceAmount int64  `json:"variance_amount"`
+}
+
+type StocktakeDto struct {
+	Id         int32      `json:"id"`
+	StoreId    int32      `json:"store_id"`
+	CategoryId *int32     `json:"category_id"`
+	Status     string     `json:"status"`
+	Comment    string     `json:"comment,omitempty"`
+	CreatedBy  int32      `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ApprovedBy *int32     `json:"approved_by"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	Goods      int32      `json:"goods"`
+	Counted    int32      `json:"counted"`
+	// Недостача и излишки в рублях по текущим ценам
+	ShortageAmount int64              `json:"shortage_amount"`
+	SurplusAmount  int64              `json:"surplus_amount"`
+	Lines          []StocktakeLineDto `json:"lines,omitempty"`
+}
+
+type CreateStocktakeDto struct {
+	StoreId int32 `json:"store_id"`
+	// Категория товаров для выборочной инвентаризации, null — все товары
+	CategoryId *int32 `json:"category_id"`
+	EmployeeId int32  `json:"employee_id"`
+	Comment    string `json:"comment"`
+}
+
+type StocktakeCountItemDto struct {
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+// StocktakeCountDto — подсчёт одного сотрудника. Повторный подсчёт того же товара заменяет предыдущий.
+type StocktakeCountDto struct {
+	EmployeeId int32                   `json:"employee_id"`
+	Items      []StocktakeCountItemDto `json:"items"`
+}
+
+type ApproveStocktakeDto struct {
+	EmployeeId int32 `json:"employee_id"`
+}
+
+type StockAdjustmentDto struct {
+	Id             int32     `json:"id"`
+	GoodId         int32     `json:"good_id"`
+	StoreId        int32     `json:"store_id"`
+	Quantity       int32     `json:"quantity"`
+	Reason         string    `json:"reason"`
+	StocktakeId    *int32    `json:"stocktake_id,omitempty"`
+	WriteOffId     *int32    `json:"write_off_id,omitempty"`
+	GoodsReceiptId *int32    `json:"goods_receipt_id,omitempty"`
+	EmployeeId     *int32    `json:"employee_id,omitempty"`
+	Comment        string    `json:"comment,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type StocktakeInterface interface {
+	CreateStocktake(ctx context.Context, dto CreateStocktakeDto) (StocktakeDto, error)
+	GetStocktake(ctx context.Context, id int32) (StocktakeDto, error)
+	GetStocktakes(ctx context.Context, storeId *int32) ([]StocktakeDto, error)
+	AddCounts(ctx context.Context, id int32, dto StocktakeCountDto) (StocktakeDto, error)
+	GetVariances(ctx context.Context, id int32) ([]StocktakeLineDto, error)
+	ApproveStocktake(ctx context.Context, id int32, dto ApproveStocktakeDto) (StocktakeDto, error)
+	CancelStocktake(ctx context.Context, id int32) (StocktakeDto, error)
+	GetAdjustments(ctx context.Context, id int32) ([]StockAdjustmentDto, error)
+}
+
+type StocktakeService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var StocktakeNotFound = errors.New("stocktake not found")
+var StocktakeStatusError = errors.New("stocktake is not in counting status")
+var StocktakeInProgressError = errors.New("store already has a stocktake in progress")
+var GoodNotInStocktakeError = errors.New("good is not in the stocktake")
+var InvalidStocktakeCountError = errors.New("count must contain goods with non-negative quantities")
+
+func ToStocktakeDto(stocktake gen.Stocktake) StocktakeDto {
+	return StocktakeDto{
+		Id:         stocktake.ID,
+		StoreId:    stocktake.StoreID,
+		CategoryId: fromInt4(stocktake.CategoryID),
+		Status:     stocktake.Status,
+		Comment:    stocktake.Comment.String,
+		CreatedBy:  stocktake.CreatedBy,
+		CreatedAt:  stocktake.CreatedAt.Time,
+		ApprovedBy: fromInt4(stocktake.ApprovedBy),
+		ApprovedAt: timestampPtr(stocktake.ApprovedAt),
+	}
+}
+
+func ToStockAdjustmentDto(adjustment gen.StockAdjustment) StockAdjustmentDto {
+	return StockAdjustmentDto{
+		Id:             adjustment.ID,
+		GoodId:         adjustment.GoodID,
+		StoreId:        adjustment.StoreID,
+		Quantity:       adjustment.Quantity,
+		Reason:         adjustment.Reason,
+		StocktakeId:    fromInt4(adjustment.StocktakeID),
+		WriteOffId:     fromInt4(adjustment.WriteOffID),
+		GoodsReceiptId: fromInt4(adjustment.GoodsReceiptID),
+		EmployeeId:     fromInt4(adjustment.EmployeeID),
+		Comment:        adjustment.Comment.String,
+		CreatedAt:      adjustment.CreatedAt.Time,
+	}
+}
+
+func toStocktakeLineDto(line gen.ListStocktakeLinesRow) StocktakeLineDto {
+	response := StocktakeLineDto{
+		GoodId:           line.GoodID,
+		Article:          line.Article,
+		Name:             line.Name,
+		ExpectedQuantity: line.ExpectedQuantity,
+		CountedQuantity:  fromInt4(line.CountedQuantity),
+		Counters:         line.Counters,
+	}
+	if line.CountedQuantity.Valid {
+		variance := line.CountedQuantity.Int32 - line.ExpectedQuantity
+		response.Variance = &variance
+		response.VarianceAmount = fromNumeric(line.Price) * int64(variance)
+	}
+	return response
+}
+
+// loadStocktake дополняет документ строками и итогами по расхождениям
+func loadStocktake(ctx context.Context, q *gen.Queries, stocktake gen.Stocktake) (StocktakeDto, error) {
+	lines, err := q.ListStocktakeLines(ctx, stocktake.ID)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	response := ToStocktakeDto(stocktake)
+	response.Goods = int32(len(lines))
+	response.Lines = make([]StocktakeLineDto, len(lines))
+	for i, line := range lines {
+		response.Lines[i] = toStocktakeLineDto(line)
+		if line.CountedQuantity.Valid {
+			response.Counted++
+		}
+		if amount := response.Lines[i].VarianceAmount; amount < 0 {
+			response.ShortageAmount -= amount
+		} else {
+			response.SurplusAmount += amount
+		}
+	}
+	return response, nil
+}
+
+func lockCountingStocktake(ctx context.Context, q *gen.Queries, id int32) (gen.Stocktake, error) {
+	stocktake, err := q.GetStocktakeForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.Stocktake{}, StocktakeNotFound
+		}
+		return gen.Stocktake{}, err
+	}
+	if stocktake.Status != StocktakeStatusCounting {
+		return gen.Stocktake{}, StocktakeStatusError
+	}
+	return stocktake, nil
+}
+
+// CreateStocktake начинает инвентаризацию магазина и фиксирует ожидаемые остатки товаров
+func (s StocktakeService) CreateStocktake(ctx context.Context, dto CreateStocktakeDto) (StocktakeDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := q.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StocktakeDto{}, StoreNotFound
+		}
+		return StocktakeDto{}, err
+	}
+	if dto.CategoryId != nil {
+		if _, err := q.GetCategory(ctx, *dto.CategoryId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return StocktakeDto{}, CategoryNotFound
+			}
+			return StocktakeDto{}, err
+		}
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, dto.StoreId); err != nil {
+		return StocktakeDto{}, err
+	}
+	open, err := q.CountOpenStocktakes(ctx, dto.StoreId)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if open > 0 {
+		return StocktakeDto{}, StocktakeInProgressError
+	}
+
+	stocktake, err := q.CreateStocktake(ctx, gen.CreateStocktakeParams{
+		StoreID:    dto.StoreId,
+		CategoryID: toInt4(dto.CategoryId),
+		Status:     StocktakeStatusCounting,
+		Comment:    pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		CreatedBy:  dto.EmployeeId,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	_, err = q.SnapshotStocktakeLines(ctx, gen.SnapshotStocktakeLinesParams{
+		StocktakeID: stocktake.ID,
+		StoreID:     stocktake.StoreID,
+		CategoryID:  toInt4(dto.CategoryId),
+	})
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	response, err := loadStocktake(ctx, q, stocktake)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return StocktakeDto{}, err
+	}
+	return response, nil
+}
+
+func (s StocktakeService) GetStocktake(ctx context.Context, id int32) (StocktakeDto, error) {
+	stocktake, err := s.Queries.GetStocktake(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return StocktakeDto{}, StocktakeNotFound
+		}
+		return StocktakeDto{}, err
+	}
+	return loadStocktake(ctx, &s.Queries, stocktake)
+}
+
+func (s StocktakeService) GetStocktakes(ctx context.Context, storeId *int32) ([]StocktakeDto, error) {
+	var stocktakes []gen.Stocktake
+	var err error
+	if storeId != nil {
+		stocktakes, err = s.Queries.ListStoreStocktakes(ctx, *storeId)
+	} else {
+		stocktakes, err = s.Queries.ListStocktakes(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := make([]StocktakeDto, len(stocktakes))
+	for i, stocktake := range stocktakes {
+		response[i] = ToStocktakeDto(stocktake)
+	}
+	return response, nil
+}
+
+// AddCounts принимает подсчёт сотрудника. Несколько сотрудников могут считать разные зоны магазина,
+// их подсчёты одного товара складываются.
+func (s StocktakeService) AddCounts(ctx context.Context, id int32, dto StocktakeCountDto) (StocktakeDto, error) {
+	if len(dto.Items) == 0 {
+		return StocktakeDto{}, InvalidStocktakeCountError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	stocktake, err := lockCountingStocktake(ctx, q, id)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, stocktake.StoreID); err != nil {
+		return StocktakeDto{}, err
+	}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	for _, item := range dto.Items {
+		if item.Quantity < 0 {
+			return StocktakeDto{}, InvalidStocktakeCountError
+		}
+		_, err := q.GetStocktakeLine(ctx, gen.GetStocktakeLineParams{StocktakeID: id, GoodID: item.GoodId})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return StocktakeDto{}, GoodNotInStocktakeError
+			}
+			return StocktakeDto{}, err
+		}
+		_, err = q.UpsertStocktakeCount(ctx, gen.UpsertStocktakeCountParams{
+			StocktakeID: id,
+			GoodID:      item.GoodId,
+			EmployeeID:  dto.EmployeeId,
+			Quantity:    item.Quantity,
+			CountedAt:   now,
+		})
+		if err != nil {
+			return StocktakeDto{}, err
+		}
+	}
+	response, err := loadStocktake(ctx, q, stocktake)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return StocktakeDto{}, err
+	}
+	return response, nil
+}
+
+// GetVariances возвращает посчитанные товары, количество которых разошлось с ожидаемым
+func (s StocktakeService) GetVariances(ctx context.Context, id int32) ([]StocktakeLineDto, error) {
+	stocktake, err := s.GetStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := []StocktakeLineDto{}
+	for _, line := range stocktake.Lines {
+		if line.Variance != nil && *line.Variance != 0 {
+			response = append(response, line)
+		}
+	}
+	return response, nil
+}
+
+// networkVariance — изменение остатка сети при расхождении магазина. Излишек сначала покрывает остаток,
+// не числящийся ни за одним магазином: это товар, который уже учтён в сети, но ещё не попал в журнал
+func networkVariance(variance int32, unallocated int32) int32 {
+	if variance <= 0 || unallocated <= 0 {
+		return variance
+	}
+	return max(variance-unallocated, 0)
+}
+
+// ApproveStocktake проводит расхождения по остаткам. Проводится разница между посчитанным и ожидаемым
+// остатком магазина на момент начала инвентаризации, поэтому продажи во время подсчёта не теряются.
+// Недостача магазина уменьшает остаток сети, излишек увеличивает его сверх нераспределённого остатка.
+// Непосчитанные товары не корректируются.
+func (s StocktakeService) ApproveStocktake(ctx context.Context, id int32, dto ApproveStocktakeDto) (StocktakeDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	stocktake, err := lockCountingStocktake(ctx, q, id)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, stocktake.StoreID); err != nil {
+		return StocktakeDto{}, err
+	}
+	lines, err := q.ListStocktakeLines(ctx, id)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	for _, line := range lines {
+		if !line.CountedQuantity.Valid || line.CountedQuantity.Int32 == line.ExpectedQuantity {
+			continue
+		}
+		variance := line.CountedQuantity.Int32 - line.ExpectedQuantity
+		unallocated, err := q.GetUnallocatedQuantity(ctx, line.GoodID)
+		if err != nil {
+			return StocktakeDto{}, err
+		}
+		if change := networkVariance(variance, unallocated); change != 0 {
+			_, err = q.IncreaseGoodQuantity(ctx, gen.IncreaseGoodQuantityParams{ID: line.GoodID, Quantity: change})
+			if err != nil {
+				return StocktakeDto{}, err
+			}
+		}
+		_, err = q.CreateStockAdjustment(ctx, gen.CreateStockAdjustmentParams{
+			GoodID:      line.GoodID,
+			StoreID:     stocktake.StoreID,
+			Quantity:    variance,
+			Reason:      StockAdjustmentStocktake,
+			StocktakeID: pgtype.Int4{Int32: id, Valid: true},
+			EmployeeID:  pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+			CreatedAt:   now,
+		})
+		if err != nil {
+			return StocktakeDto{}, err
+		}
+	}
+	stocktake, err = q.UpdateStocktakeStatus(ctx, gen.UpdateStocktakeStatusParams{
+		ID:         id,
+		Status:     StocktakeStatusApproved,
+		ApprovedBy: pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+		ApprovedAt: now,
+	})
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	response, err := loadStocktake(ctx, q, stocktake)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return StocktakeDto{}, err
+	}
+	return response, nil
+}
+
+func (s StocktakeService) CancelStocktake(ctx context.Context, id int32) (StocktakeDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := lockCountingStocktake(ctx, q, id); err != nil {
+		return StocktakeDto{}, err
+	}
+	stocktake, err := q.UpdateStocktakeStatus(ctx, gen.UpdateStocktakeStatusParams{ID: id, Status: StocktakeStatusCancelled})
+	if err != nil {
+		return StocktakeDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return StocktakeDto{}, err
+	}
+	return ToStocktakeDto(stocktake), nil
+}
+
+// GetAdjustments возвращает корректировки остатков, проведённые по инвентаризации
+func (s StocktakeService) GetAdjustments(ctx context.Context, id int32) ([]StockAdjustmentDto, error) {
+	if _, err := s.Queries.GetStocktake(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, StocktakeNotFound
+		}
+		return nil, err
+	}
+	adjustments, err := s.Queries.ListStocktakeAdjustments(ctx, pgtype.Int4{Int32: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]StockAdjustmentDto, len(adjustments))
+	for i, adjustment := range adjustments {
+		response[i] = ToStockAdjustmentDto(adjustment)
+	}
+	return response, nil
+}
@@ -0,0 +1,26 @@
+package services
+
+import "testing"
+
+func TestNetworkVariance(t *testing.T) {
+	tests := []struct {
+		name        string
+		variance    int32
+		unallocated int32
+		want        int32
+	}{
+		{"недостача уменьшает сеть", -3, 10, -3},
+		{"излишек без нераспределённого остатка", 4, 0, 4},
+		{"излишек целиком из нераспределённого", 4, 10, 0},
+		{"излишек больше нераспределённого", 7, 5, 2},
+		{"отрицательный нераспределённый остаток не учитывается", 4, -2, 4},
+		{"без расхождения", 0, 5, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := networkVariance(test.variance, test.unallocated); got != test.want {
+				t.Errorf("networkVariance(%d, %d) = %d, want %d", test.variance, test.unallocated, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+const (
+	SupplierClaimStatusOpen     = "open"
+	SupplierClaimStatusResolved = "resolved"
+)
+
+type SupplierClaimItemDto struct {
+	Id              int32  `json:"id"`
+	GoodId          int32  `json:"good_id"`
+	DamagedQuantity int32  `json:"damaged_quantity"`
+	MissingQuantity int32  `json:"missing_quantity"`
+	UnitCost        *int64 `json:"unit_cost"`
+	Amount          int64  `json:"amount"`
+}
+
+// SupplierClaimDto — претензия поставщику по браку и недопоставке, выявленным при приёмке
+type SupplierClaimDto struct {
+	Id              int32                  `json:"id"`
+	SupplierId      int32                  `json:"supplier_id"`
+	PurchaseOrderId int32                  `json:"purchase_order_id"`
+	GoodsReceiptId  int32                  `json:"goods_receipt_id"`
+	Status          string                 `json:"status"`
+	Amount          int64                  `json:"amount"`
+	Resolution      string                 `json:"resolution,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	ResolvedAt      *time.Time             `json:"resolved_at"`
+	Items           []SupplierClaimItemDto `json:"items"`
+}
+
+type ResolveSupplierClaimDto struct {
+	// Чем закончилась претензия: возврат денег, допоставка и т.п.
+	Resolution string `json:"resolution"`
+}
+
+type SupplierClaimInterface interface {
+	GetSupplierClaim(ctx context.Context, id int32) (SupplierClaimDto, error)
+	GetSupplierClaims(ctx context.Context, supplierId *int32, status string) ([]SupplierClaimDto, error)
+	ResolveSupplierClaim(ctx context.Context, id int32, dto ResolveSupplierClaimDto) (SupplierClaimDto, error)
+}
+
+type SupplierClaimService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var SupplierClaimNotFound = errors.New("supplier claim not found")
+var SupplierClaimResolvedError = errors.New("supplier claim is already resolved")
+
+func ToSupplierClaimDto(claim gen.SupplierClaim, items []gen.SupplierClaimItem) SupplierClaimDto {
+	response := SupplierClaimDto{
+		Id:              claim.ID,
+		SupplierId:      claim.SupplierID,
+		PurchaseOrderId: claim.PurchaseOrderID,
+		GoodsReceiptId:  claim.GoodsReceiptID,
+		Status:          claim.Status,
+		Amount:          fromNumeric(claim.Amount),
+		Resolution:      claim.Resolution.String,
+		CreatedAt:       claim.CreatedAt.Time,
+		ResolvedAt:      timestampPtr(claim.ResolvedAt),
+		Items:           make([]SupplierClaimItemDto, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = SupplierClaimItemDto{
+			Id:              item.ID,
+			GoodId:          item.GoodID,
+			DamagedQuantity: item.DamagedQuantity,
+			MissingQuantity: item.MissingQuantity,
+			UnitCost:        fromOptionalNumeric(item.UnitCost),
+			Amount:          fromNumeric(item.Amount),
+		}
+	}
+	return response
+}
+
+func (s SupplierClaimService) GetSupplierClaim(ctx context.Context, id int32) (SupplierClaimDto, error) {
+	claim, err := s.Queries.GetSupplierClaim(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SupplierClaimDto{}, SupplierClaimNotFound
+		}
+		return SupplierClaimDto{}, err
+	}
+	items, err := s.Queries.ListSupplierClaimItems(ctx, id)
+	if err != nil {
+		return SupplierClaimDto{}, err
+	}
+	return ToSupplierClaimDto(claim, items), nil
+}
+
+func (s SupplierClaimService) GetSupplierClaims(ctx context.Context, supplierId *int32, status string) ([]SupplierClaimDto, error) {
+	claims, err := s.Queries.ListSupplierClaims(ctx, gen.ListSupplierClaimsParams{
+		SupplierID: toInt4(supplierId),
+		Status:     pgtype.Text{String: status, Valid: status != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]SupplierClaimDto, len(claims))
+	for i, claim := range claims {
+		items, err := s.Queries.ListSupplierClaimItems(ctx, claim.ID)
+		if err != nil {
+			return nil, err
+		}
+		response[i] = ToSupplierClaimDto(claim, items)
+	}
+	return response, nil
+}
+
+// ResolveSupplierClaim закрывает претензию, когда поставщик её урегулировал
+func (s SupplierClaimService) ResolveSupplierClaim(ctx context.Context, id int32, dto ResolveSupplierClaimDto) (SupplierClaimDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return SupplierClaimDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	claim, err := q.GetSupplierClaimForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SupplierClaimDto{}, SupplierClaimNotFound
+		}
+		return SupplierClaimDto{}, err
+	}
+	if claim.Status != SupplierClaimStatusOpen {
+		return SupplierClaimDto{}, SupplierClaimResolvedError
+	}
+	claim, err = q.ResolveSupplierClaim(ctx, gen.ResolveSupplierClaimParams{
+		ID:         id,
+		Status:     SupplierClaimStatusResolved,
+		Resolution: pgtype.Text{String: dto.Resolution, Valid: dto.Resolution != ""},
+		ResolvedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return SupplierClaimDto{}, err
+	}
+	items, err := q.ListSupplierClaimItems(ctx, id)
+	if err != nil {
+		return SupplierClaimDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return SupplierClaimDto{}, err
+	}
+	return ToSupplierClaimDto(claim, items), nil
+}
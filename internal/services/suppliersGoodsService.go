@@ -12,6 +12,9 @@ import (
 type CreateGoodsSupplierDto struct {
 	ProductId  int32 `json:"product_id"`
 	SupplierId int32 `json:"supplier_id"`
+	// Закупочная цена и срок поставки, по ним выбирается лучший поставщик для пополнения
+	PurchasePrice *int64 `json:"purchase_price"`
+	LeadTimeDays  *int32 `json:"lead_time_days"`
 }
 
 type GoodsSupplierInterface interface {
@@ -27,10 +30,12 @@ type GoodsSupplierService struct {
 
 func (g GoodsSupplierService) CreateGoodsSupplier(ctx context.Context, dto CreateGoodsSupplierDto) error {
 	_, err := g.Queries.CreateGoodsSupplier(ctx, gen.CreateGoodsSupplierParams{
-		GoodID:     dto.ProductId,
-		SupplierID: dto.SupplierId,
-		CreatedAt:  pgtype.Timestamp{Time: time.Now()},
-		IsAlive:    true,
+		GoodID:        dto.ProductId,
+		SupplierID:    dto.SupplierId,
+		CreatedAt:     pgtype.Timestamp{Time: time.Now()},
+		IsAlive:       true,
+		PurchasePrice: toOptionalNumeric(dto.PurchasePrice),
+		LeadTimeDays:  toInt4(dto.LeadTimeDays),
 	})
 	if err != nil {
 		return err
@@ -52,6 +57,9 @@ func (g GoodsSupplierService) GetGoodsBySupplier(ctx context.Context, supplierId
 	for i, good := range goods {
 		response[i] = ToProductDto(good)
 	}
+	if err := applyReservations(ctx, g.Queries, response); err != nil {
+		return nil, err
+	}
 	return response, nil
 }
 
@@ -25,12 +25,41 @@ type UpdateSupplierDto struct {
 	IsAlive bool  `json:"is_alive"`
 }
 
+// SupplierScorecardDto — показатели надёжности поставщика по заказам, отправленным за период.
+// Доли в процентах, null — за период не было заказов, по которым можно посчитать показатель
+type SupplierScorecardDto struct {
+	SupplierId int32  `json:"supplier_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Orders     int    `json:"orders"`
+	// Заказы, полностью принятые или закрытые финальной приёмкой (статус received)
+	CompletedOrders int `json:"completed_orders"`
+	// Доля заказов со сроком поставки, закрытых не позже ожидаемой даты. Просроченные незакрытые заказы считаются опоздавшими
+	OnTimeRate *float64 `json:"on_time_rate"`
+	// Доля принятого без брака от заказанного по закрытым заказам
+	FillRate *float64 `json:"fill_rate"`
+	// Доля брака от привезённого
+	DefectRate *float64 `json:"defect_rate"`
+	// Средний срок от отправки заказа до приёмки, закрывшей его, в днях
+	AverageLeadTimeDays *float64 `json:"average_lead_time_days"`
+	Ordered             int64    `json:"ordered"`
+	Received            int64    `json:"received"`
+	Damaged             int64    `json:"damaged"`
+	Claims              int32    `json:"claims"`
+	ClaimAmount         int64    `json:"claim_amount"`
+	// Возвраты покупателей за период по товарам, которые поставщик привозил по заказам периода. Товар нескольких поставщиков учитывается у каждого из них
+	Returned int64 `json:"returned"`
+	// Доля возвращённого покупателями от привезённого
+	ReturnRate *float64 `json:"return_rate"`
+}
+
 type SupplierInterface interface {
 	CreateSupplier(ctx context.Context, dto CreateSupplierDto) (SupplierDto, error)
 	GetSupplier(ctx context.Context, id int32) (SupplierDto, error)
 	GetSuppliers(ctx context.Context) ([]SupplierDto, error)
 	UpdateSupplier(ctx context.Context, dto UpdateSupplierDto) (SupplierDto, error)
 	DeleteSupplier(ctx context.Context, id int32) error
+	GetSupplierScorecard(ctx context.Context, id int32, from time.Time, to time.Time) (SupplierScorecardDto, error)
 }
 
 type SupplierService struct {
@@ -162,3 +191,99 @@ func (s SupplierService) DeleteSupplier(ctx context.Context, id int32) error {
 	}
 	return nil
 }
+
+// percent возвращает долю part от total в процентах, nil — если total нулевой
+func percent(part int64, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := round2(float64(part) / float64(total) * 100)
+	return &value
+}
+
+// deliveryTimeliness определяет, входит ли заказ в расчёт доли поставок в срок и закрыт ли он вовремя.
+// Заказ без ожидаемой даты не учитывается, незакрытый учитывается только после истечения срока.
+// Срок сравнивается по датам: приёмка в ожидаемый день вовремя в любое время суток
+func deliveryTimeliness(expectedAt pgtype.Timestamp, completedAt pgtype.Timestamp, now time.Time) (due bool, onTime bool) {
+	if !expectedAt.Valid {
+		return false, false
+	}
+	deadline := expectedAt.Time.Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if completedAt.Valid {
+		return true, completedAt.Time.Before(deadline)
+	}
+	return now.After(deadline), false
+}
+
+// GetSupplierScorecard считает показатели поставщика по заказам, отправленным в период [from, to)
+func (s SupplierService) GetSupplierScorecard(ctx context.Context, id int32, from time.Time, to time.Time) (SupplierScorecardDto, error) {
+	if _, err := s.Queries.GetSupplier(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return SupplierScorecardDto{}, SupplierNotFoundError
+		}
+		return SupplierScorecardDto{}, err
+	}
+	period := gen.ListSupplierOrderPerformanceParams{
+		SupplierID: id,
+		DateFrom:   pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: to, Valid: true},
+	}
+	orders, err := s.Queries.ListSupplierOrderPerformance(ctx, period)
+	if err != nil {
+		return SupplierScorecardDto{}, err
+	}
+	claims, err := s.Queries.GetSupplierClaimTotals(ctx, gen.GetSupplierClaimTotalsParams(period))
+	if err != nil {
+		return SupplierScorecardDto{}, err
+	}
+	returned, err := s.Queries.GetSupplierReturnedQuantity(ctx, gen.GetSupplierReturnedQuantityParams{
+		DateFrom:   period.DateFrom,
+		DateTo:     period.DateTo,
+		SupplierID: id,
+	})
+	if err != nil {
+		return SupplierScorecardDto{}, err
+	}
+
+	response := SupplierScorecardDto{
+		SupplierId:  id,
+		From:        from.Format(DateLayout),
+		To:          to.AddDate(0, 0, -1).Format(DateLayout),
+		Orders:      len(orders),
+		Claims:      claims.Claims,
+		ClaimAmount: fromNumeric(claims.Amount),
+		Returned:    returned,
+	}
+	// Даты без часового пояса приходят из базы как UTC, поэтому текущее время приводится к тому же виду
+	now := time.Now()
+	now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.UTC)
+	var due, onTime, completedOrdered, completedAccepted int64
+	leadTime := 0.0
+	for _, order := range orders {
+		response.Ordered += order.Ordered
+		response.Received += order.Received
+		response.Damaged += order.Damaged
+		if order.CompletedAt.Valid {
+			response.CompletedOrders++
+			completedOrdered += order.Ordered
+			completedAccepted += order.Accepted
+			leadTime += order.CompletedAt.Time.Sub(order.SentAt.Time).Hours() / 24
+		}
+		isDue, isOnTime := deliveryTimeliness(order.ExpectedAt, order.CompletedAt, now)
+		if isDue {
+			due++
+		}
+		if isOnTime {
+			onTime++
+		}
+	}
+	response.OnTimeRate = percent(onTime, due)
+	response.FillRate = percent(completedAccepted, completedOrdered)
+	response.DefectRate = percent(response.Damaged, response.Received)
+	response.ReturnRate = percent(response.Returned, response.Received)
+	if response.CompletedOrders > 0 {
+		average := round2(leadTime / float64(response.CompletedOrders))
+		response.AverageLeadTimeDays = &average
+	}
+	return response, nil
+}
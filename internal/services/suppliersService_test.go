@@ -0,0 +1,40 @@
+package services
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+	"time"
+)
+
+func TestDeliveryTimeliness(t *testing.T) {
+	at := func(value string) pgtype.Timestamp {
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pgtype.Timestamp{Time: parsed, Valid: true}
+	}
+	now := at("2024-03-10 12:00").Time
+	tests := []struct {
+		name        string
+		expectedAt  pgtype.Timestamp
+		completedAt pgtype.Timestamp
+		wantDue     bool
+		wantOnTime  bool
+	}{
+		{"срок не указан", pgtype.Timestamp{}, at("2024-03-01 10:00"), false, false},
+		{"закрыт раньше срока", at("2024-03-05 00:00"), at("2024-03-04 18:00"), true, true},
+		{"закрыт в ожидаемый день вечером", at("2024-03-05 00:00"), at("2024-03-05 23:59"), true, true},
+		{"закрыт на следующий день", at("2024-03-05 00:00"), at("2024-03-06 00:00"), true, false},
+		{"не закрыт, срок не истёк", at("2024-03-10 00:00"), pgtype.Timestamp{}, false, false},
+		{"не закрыт, срок истёк", at("2024-03-09 00:00"), pgtype.Timestamp{}, true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			due, onTime := deliveryTimeliness(test.expectedAt, test.completedAt, now)
+			if due != test.wantDue || onTime != test.wantOnTime {
+				t.Errorf("deliveryTimeliness = (%v, %v), want (%v, %v)", due, onTime, test.wantDue, test.wantOnTime)
+			}
+		})
+	}
+}
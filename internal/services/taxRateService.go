@@ -0,0 +1,160 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// Ставка НДС для товаров, у которых нет ни своей ставки, ни ставки категории
+const DefaultVatRate = 20
+
+// Ставки, которые умеет пробивать касса, и их коды в теге 1199. Отсутствие ставки означает «без НДС».
+var supportedVatRates = map[int32]int32{
+	20: FiscalVat20,
+	10: FiscalVat10,
+	0:  FiscalVat0,
+}
+
+type TaxRateDto struct {
+	Id   int32  `json:"id"`
+	Name string `json:"name"`
+	// Ставка в процентах, null — товар не облагается НДС
+	Rate      *int32    `json:"rate"`
+	CreatedAt time.Time `json:"created_at"`
+	IsAlive   bool      `json:"is_alive"`
+}
+
+type CreateTaxRateDto struct {
+	Name string `json:"name"`
+	// 20, 10 или 0; null — без НДС
+	Rate *int32 `json:"rate"`
+}
+
+// TaxBreakdownDto — сумма и выделенный НДС по одной ставке. Обе суммы в копейках, как в чеке:
+// цены целые, а налог, включённый в цену, почти всегда дробный.
+type TaxBreakdownDto struct {
+	VatRate *int32 `json:"vat_rate"`
+	// Сумма строк по ставке в копейках, включая НДС
+	Amount int64 `json:"amount"`
+	// НДС, включённый в Amount, в копейках
+	VatAmount int64 `json:"vat_amount"`
+}
+
+type TaxRateInterface interface {
+	CreateTaxRate(ctx context.Context, dto CreateTaxRateDto) (TaxRateDto, error)
+	GetTaxRate(ctx context.Context, id int32) (TaxRateDto, error)
+	GetTaxRates(ctx context.Context) ([]TaxRateDto, error)
+	DeleteTaxRate(ctx context.Context, id int32) error
+}
+
+type TaxRateService struct {
+	Queries gen.Queries
+}
+
+var TaxRateNotFound = errors.New("tax rate not found")
+var UnsupportedTaxRateError = errors.New("unsupported tax rate, expected 20, 10, 0 or null")
+
+func ToTaxRateDto(rate gen.TaxRate) TaxRateDto {
+	return TaxRateDto{
+		Id:        rate.ID,
+		Name:      rate.Name,
+		Rate:      fromInt4(rate.Rate),
+		CreatedAt: rate.CreatedAt.Time,
+		IsAlive:   rate.IsAlive,
+	}
+}
+
+// resolveVatRate возвращает действующую ставку товара: свою, ставку категории или ставку по умолчанию.
+// Удалённые ставки пропускаются
+func resolveVatRate(ctx context.Context, q *gen.Queries, goodId int32) (pgtype.Int4, error) {
+	rate, err := q.GetGoodTaxRate(ctx, goodId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.Int4{Int32: DefaultVatRate, Valid: true}, nil
+		}
+		return pgtype.Int4{}, err
+	}
+	return rate.Rate, nil
+}
+
+// fiscalVatCode переводит ставку в код тега 1199
+func fiscalVatCode(rate pgtype.Int4) int32 {
+	if !rate.Valid {
+		return FiscalVatNone
+	}
+	return supportedVatRates[rate.Int32]
+}
+
+// vatAmount выделяет НДС, включённый в сумму в копейках, с округлением до копейки
+func vatAmount(amount int64, rate pgtype.Int4) int64 {
+	if !rate.Valid || rate.Int32 == 0 {
+		return 0
+	}
+	base := int64(100 + rate.Int32)
+	return (amount*int64(rate.Int32) + base/2) / base
+}
+
+// addTaxBreakdown добавляет строку в разбивку по ставкам, amount — сумма строки в единицах цены.
+// Возвращает НДС строки в копейках.
+func addTaxBreakdown(taxes []TaxBreakdownDto, rate pgtype.Int4, amount int64) ([]TaxBreakdownDto, int64) {
+	amount = kopecks(amount)
+	vat := vatAmount(amount, rate)
+	for i := range taxes {
+		if (taxes[i].VatRate == nil && !rate.Valid) ||
+			(taxes[i].VatRate != nil && rate.Valid && *taxes[i].VatRate == rate.Int32) {
+			taxes[i].Amount += amount
+			taxes[i].VatAmount += vat
+			return taxes, vat
+		}
+	}
+	return append(taxes, TaxBreakdownDto{VatRate: fromInt4(rate), Amount: amount, VatAmount: vat}), vat
+}
+
+func (t TaxRateService) CreateTaxRate(ctx context.Context, dto CreateTaxRateDto) (TaxRateDto, error) {
+	if dto.Rate != nil {
+		if _, ok := supportedVatRates[*dto.Rate]; !ok {
+			return TaxRateDto{}, UnsupportedTaxRateError
+		}
+	}
+	rate, err := t.Queries.CreateTaxRate(ctx, gen.CreateTaxRateParams{
+		Name:      dto.Name,
+		Rate:      toInt4(dto.Rate),
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		IsAlive:   true,
+	})
+	if err != nil {
+		return TaxRateDto{}, err
+	}
+	return ToTaxRateDto(rate), nil
+}
+
+func (t TaxRateService) GetTaxRate(ctx context.Context, id int32) (TaxRateDto, error) {
+	rate, err := t.Queries.GetTaxRate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TaxRateDto{}, TaxRateNotFound
+		}
+		return TaxRateDto{}, err
+	}
+	return ToTaxRateDto(rate), nil
+}
+
+func (t TaxRateService) GetTaxRates(ctx context.Context) ([]TaxRateDto, error) {
+	rates, err := t.Queries.ListTaxRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]TaxRateDto, len(rates))
+	for i, rate := range rates {
+		response[i] = ToTaxRateDto(rate)
+	}
+	return response, nil
+}
+
+func (t TaxRateService) DeleteTaxRate(ctx context.Context, id int32) error {
+	return t.Queries.DeleteTaxRate(ctx, id)
+}
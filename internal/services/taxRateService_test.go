@@ -0,0 +1,78 @@
+package services
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"reflect"
+	"testing"
+)
+
+func TestVatAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		rate   pgtype.Int4
+		want   int64
+	}{
+		{"20% без остатка", 12000, pgtype.Int4{Int32: 20, Valid: true}, 2000},
+		{"20% с округлением вверх", 100, pgtype.Int4{Int32: 20, Valid: true}, 17},
+		{"20% с округлением вниз", 99900, pgtype.Int4{Int32: 20, Valid: true}, 16650},
+		{"10%", 11000, pgtype.Int4{Int32: 10, Valid: true}, 1000},
+		{"0%", 11000, pgtype.Int4{Int32: 0, Valid: true}, 0},
+		{"без НДС", 11000, pgtype.Int4{}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := vatAmount(test.amount, test.rate); got != test.want {
+				t.Errorf("vatAmount = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFiscalVatCode(t *testing.T) {
+	tests := []struct {
+		rate pgtype.Int4
+		want int32
+	}{
+		{pgtype.Int4{Int32: 20, Valid: true}, FiscalVat20},
+		{pgtype.Int4{Int32: 10, Valid: true}, FiscalVat10},
+		{pgtype.Int4{Int32: 0, Valid: true}, FiscalVat0},
+		{pgtype.Int4{}, FiscalVatNone},
+	}
+	for _, test := range tests {
+		if got := fiscalVatCode(test.rate); got != test.want {
+			t.Errorf("fiscalVatCode(%v) = %d, want %d", test.rate, got, test.want)
+		}
+	}
+}
+
+func TestAddTaxBreakdown(t *testing.T) {
+	vat20, vat10 := int32(20), int32(10)
+	lines := []struct {
+		rate    pgtype.Int4
+		amount  int64
+		wantVat int64
+	}{
+		{pgtype.Int4{Int32: 20, Valid: true}, 999, 16650},
+		{pgtype.Int4{}, 500, 0},
+		{pgtype.Int4{Int32: 10, Valid: true}, 110, 1000},
+		{pgtype.Int4{Int32: 20, Valid: true}, 1, 17},
+	}
+	var taxes []TaxBreakdownDto
+	for _, line := range lines {
+		var vat int64
+		taxes, vat = addTaxBreakdown(taxes, line.rate, line.amount)
+		if vat != line.wantVat {
+			t.Errorf("addTaxBreakdown(%v, %d): vat = %d, want %d", line.rate, line.amount, vat, line.wantVat)
+		}
+	}
+	// Суммы и НДС по ставке в копейках; НДС складывается построчно, как в чеке
+	want := []TaxBreakdownDto{
+		{VatRate: &vat20, Amount: 100000, VatAmount: 16667},
+		{VatRate: nil, Amount: 50000, VatAmount: 0},
+		{VatRate: &vat10, Amount: 11000, VatAmount: 1000},
+	}
+	if !reflect.DeepEqual(taxes, want) {
+		t.Errorf("taxes = %+v, want %+v", taxes, want)
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
+	"time"
+)
+
+const (
+	TechnicianBookingScheduled = "scheduled"
+	TechnicianBookingCompleted = "completed"
+	TechnicianBookingCancelled = "cancelled"
+)
+
+type TechnicianBookingDto struct {
+	Id         int32     `json:"id"`
+	EmployeeId int32     `json:"employee_id"`
+	OrderId    *int32    `json:"order_id"`
+	ServiceId  *int32    `json:"service_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Address    string    `json:"address,omitempty"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateTechnicianBookingDto struct {
+	EmployeeId int32     `json:"employee_id"`
+	OrderId    *int32    `json:"order_id"`
+	ServiceId  *int32    `json:"service_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	// Если не указано, окно рассчитывается по длительности услуги
+	EndsAt *time.Time `json:"ends_at"`
+	// Если не указан, берётся адрес по умолчанию покупателя заказа
+	Address string `json:"address"`
+}
+
+type UpdateTechnicianBookingStatusDto struct {
+	Status string `json:"status"`
+}
+
+type TechnicianScheduleDto struct {
+	EmployeeId int32                  `json:"employee_id"`
+	Date       string                 `json:"date"`
+	Bookings   []TechnicianBookingDto `json:"bookings"`
+}
+
+type TechnicianBookingInterface interface {
+	CreateBooking(ctx context.Context, dto CreateTechnicianBookingDto) (TechnicianBookingDto, error)
+	GetBooking(ctx context.Context, id int32) (TechnicianBookingDto, error)
+	GetOrderBookings(ctx context.Context, orderId int32) ([]TechnicianBookingDto, error)
+	GetSchedule(ctx context.Context, employeeId int32, date time.Time) (TechnicianScheduleDto, error)
+	UpdateStatus(ctx context.Context, id int32, dto UpdateTechnicianBookingStatusDto) (TechnicianBookingDto, error)
+}
+
+type TechnicianBookingService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var TechnicianBookingNotFound = errors.New("technician booking not found")
+var TechnicianNotFound = errors.New("technician not found")
+var InvalidBookingWindowError = errors.New("booking window must end after it starts")
+var TechnicianBusyError = errors.New("technician is already booked for this time")
+var ServiceNotInOrderError = errors.New("service is not part of the order")
+var TechnicianBookingStatusError = errors.New("booking status transition is not allowed")
+
+func ToTechnicianBookingDto(booking gen.TechnicianBooking) TechnicianBookingDto {
+	return TechnicianBookingDto{
+		Id:         booking.ID,
+		EmployeeId: booking.EmployeeID,
+		OrderId:    fromInt4(booking.OrderID),
+		ServiceId:  fromInt4(booking.ServiceID),
+		StartsAt:   booking.StartsAt.Time,
+		EndsAt:     booking.EndsAt.Time,
+		Address:    booking.Address.String,
+		Status:     booking.Status,
+		CreatedAt:  booking.CreatedAt.Time,
+	}
+}
+
+// bookingEnd вычисляет конец окна записи: явно заданный или по длительности услуги
+func bookingEnd(startsAt time.Time, endsAt *time.Time, durationMinutes int32) (time.Time, error) {
+	end := startsAt.Add(time.Duration(durationMinutes) * time.Minute)
+	if endsAt != nil {
+		end = *endsAt
+	}
+	if !end.After(startsAt) {
+		return time.Time{}, InvalidBookingWindowError
+	}
+	return end, nil
+}
+
+// checkBookingTransition разрешает завершить или отменить только запланированную запись
+func checkBookingTransition(from string, to string) error {
+	if to != TechnicianBookingCompleted && to != TechnicianBookingCancelled {
+		return TechnicianBookingStatusError
+	}
+	if from != TechnicianBookingScheduled {
+		return TechnicianBookingStatusError
+	}
+	return nil
+}
+
+// CreateBooking записывает техника на окно времени. Пересечение с другими активными записями
+// техника проверяется под блокировкой его строки, поэтому параллельные записи не создадут накладку.
+func (t TechnicianBookingService) CreateBooking(ctx context.Context, dto CreateTechnicianBookingDto) (TechnicianBookingDto, error) {
+	tx, err := t.DB.Begin(ctx)
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := t.Queries.WithTx(tx)
+
+	if _, err := q.LockEmployee(ctx, dto.EmployeeId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TechnicianBookingDto{}, TechnicianNotFound
+		}
+		return TechnicianBookingDto{}, err
+	}
+	employee, err := q.GetEmployee(ctx, dto.EmployeeId)
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	if !employee.IsAlive {
+		return TechnicianBookingDto{}, TechnicianNotFound
+	}
+
+	var durationMinutes int32
+	if dto.ServiceId != nil {
+		service, err := q.GetService(ctx, *dto.ServiceId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return TechnicianBookingDto{}, ServiceNotFound
+			}
+			return TechnicianBookingDto{}, err
+		}
+		durationMinutes = service.DurationMinutes
+	}
+	endsAt, err := bookingEnd(dto.StartsAt, dto.EndsAt, durationMinutes)
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+
+	address := dto.Address
+	if dto.OrderId != nil {
+		order, err := q.GetOrder(ctx, *dto.OrderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return TechnicianBookingDto{}, OrderNotFound
+			}
+			return TechnicianBookingDto{}, err
+		}
+		if order.Status == OrderStatusCancelled {
+			return TechnicianBookingDto{}, OrderStatusError
+		}
+		if dto.ServiceId != nil {
+			lines, err := q.ListOrderServices(ctx, order.ID)
+			if err != nil {
+				return TechnicianBookingDto{}, err
+			}
+			if !slices.ContainsFunc(lines, func(line gen.OrderService) bool { return line.ServiceID == *dto.ServiceId }) {
+				return TechnicianBookingDto{}, ServiceNotInOrderError
+			}
+		}
+		if address == "" {
+			address, err = customerDeliveryAddress(ctx, q, order, nil)
+			if err != nil && !errors.Is(err, InvalidAddressError) {
+				return TechnicianBookingDto{}, err
+			}
+		}
+	}
+
+	overlapping, err := q.CountOverlappingBookings(ctx, gen.CountOverlappingBookingsParams{
+		EmployeeID: dto.EmployeeId,
+		StartsAt:   pgtype.Timestamp{Time: dto.StartsAt, Valid: true},
+		EndsAt:     pgtype.Timestamp{Time: endsAt, Valid: true},
+	})
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	if overlapping > 0 {
+		return TechnicianBookingDto{}, TechnicianBusyError
+	}
+
+	booking, err := q.CreateTechnicianBooking(ctx, gen.CreateTechnicianBookingParams{
+		EmployeeID: dto.EmployeeId,
+		OrderID:    toInt4(dto.OrderId),
+		ServiceID:  toInt4(dto.ServiceId),
+		StartsAt:   pgtype.Timestamp{Time: dto.StartsAt, Valid: true},
+		EndsAt:     pgtype.Timestamp{Time: endsAt, Valid: true},
+		Address:    pgtype.Text{String: address, Valid: address != ""},
+		Status:     TechnicianBookingScheduled,
+		CreatedAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	return ToTechnicianBookingDto(booking), nil
+}
+
+func (t TechnicianBookingService) GetBooking(ctx context.Context, id int32) (TechnicianBookingDto, error) {
+	booking, err := t.Queries.GetTechnicianBooking(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TechnicianBookingDto{}, TechnicianBookingNotFound
+		}
+		return TechnicianBookingDto{}, err
+	}
+	return ToTechnicianBookingDto(booking), nil
+}
+
+func (t TechnicianBookingService) GetOrderBookings(ctx context.Context, orderId int32) ([]TechnicianBookingDto, error) {
+	bookings, err := t.Queries.ListTechnicianBookingsByOrder(ctx, pgtype.Int4{Int32: orderId, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]TechnicianBookingDto, len(bookings))
+	for i, booking := range bookings {
+		response[i] = ToTechnicianBookingDto(booking)
+	}
+	return response, nil
+}
+
+// GetSchedule возвращает записи техника, пересекающиеся с указанным днём
+func (t TechnicianBookingService) GetSchedule(ctx context.Context, employeeId int32, date time.Time) (TechnicianScheduleDto, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	bookings, err := t.Queries.ListTechnicianBookings(ctx, gen.ListTechnicianBookingsParams{
+		EmployeeID: employeeId,
+		DateFrom:   pgtype.Timestamp{Time: day, Valid: true},
+		DateTo:     pgtype.Timestamp{Time: day.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		return TechnicianScheduleDto{}, err
+	}
+	response := TechnicianScheduleDto{
+		EmployeeId: employeeId,
+		Date:       day.Format("2006-01-02"),
+		Bookings:   make([]TechnicianBookingDto, len(bookings)),
+	}
+	for i, booking := range bookings {
+		response.Bookings[i] = ToTechnicianBookingDto(booking)
+	}
+	return response, nil
+}
+
+// UpdateStatus завершает или отменяет запись; отменённая запись освобождает окно техника
+func (t TechnicianBookingService) UpdateStatus(ctx context.Context, id int32, dto UpdateTechnicianBookingStatusDto) (TechnicianBookingDto, error) {
+	tx, err := t.DB.Begin(ctx)
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := t.Queries.WithTx(tx)
+
+	booking, err := q.GetTechnicianBookingForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TechnicianBookingDto{}, TechnicianBookingNotFound
+		}
+		return TechnicianBookingDto{}, err
+	}
+	if err := checkBookingTransition(booking.Status, dto.Status); err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	booking, err = q.UpdateTechnicianBookingStatus(ctx, gen.UpdateTechnicianBookingStatusParams{ID: id, Status: dto.Status})
+	if err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TechnicianBookingDto{}, err
+	}
+	return ToTechnicianBookingDto(booking), nil
+}
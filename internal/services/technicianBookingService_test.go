@@ -0,0 +1,82 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBookingEnd(t *testing.T) {
+	start := time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC)
+	explicit := start.Add(30 * time.Minute)
+	before := start.Add(-time.Minute)
+	tests := []struct {
+		name            string
+		endsAt          *time.Time
+		durationMinutes int32
+		want            time.Time
+		wantErr         error
+	}{
+		{"по длительности услуги", nil, 90, start.Add(90 * time.Minute), nil},
+		{"явный конец важнее длительности", &explicit, 90, explicit, nil},
+		{"явный конец без услуги", &explicit, 0, explicit, nil},
+		{"ни конца, ни услуги", nil, 0, time.Time{}, InvalidBookingWindowError},
+		{"конец раньше начала", &before, 0, time.Time{}, InvalidBookingWindowError},
+		{"конец совпадает с началом", &start, 60, time.Time{}, InvalidBookingWindowError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := bookingEnd(start, test.endsAt, test.durationMinutes)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("bookingEnd error = %v, want %v", err, test.wantErr)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("bookingEnd = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckBookingTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want error
+	}{
+		{TechnicianBookingScheduled, TechnicianBookingCompleted, nil},
+		{TechnicianBookingScheduled, TechnicianBookingCancelled, nil},
+		{TechnicianBookingScheduled, TechnicianBookingScheduled, TechnicianBookingStatusError},
+		{TechnicianBookingCompleted, TechnicianBookingCancelled, TechnicianBookingStatusError},
+		{TechnicianBookingCancelled, TechnicianBookingCompleted, TechnicianBookingStatusError},
+	}
+	for _, test := range tests {
+		t.Run(test.from+"->"+test.to, func(t *testing.T) {
+			if err := checkBookingTransition(test.from, test.to); !errors.Is(err, test.want) {
+				t.Errorf("checkBookingTransition = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateService(t *testing.T) {
+	tests := []struct {
+		name            string
+		serviceName     string
+		durationMinutes int32
+		price           int64
+		want            error
+	}{
+		{"корректная услуга", "Установка", 60, 150000, nil},
+		{"бесплатная услуга", "Консультация", 15, 0, nil},
+		{"без названия", "", 60, 150000, InvalidServiceError},
+		{"нулевая длительность", "Установка", 0, 150000, InvalidServiceError},
+		{"отрицательная цена", "Установка", 60, -1, InvalidServiceError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateService(test.serviceName, test.durationMinutes, test.price); !errors.Is(err, test.want) {
+				t.Errorf("validateService = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"slices"
+	"time"
+)
+
+const (
+	WriteOffStatusDraft    = "draft"
+	WriteOffStatusApproved = "approved"
+	WriteOffStatusRejected = "rejected"
+
+	StockAdjustmentWriteOff = "write_off"
+)
+
+// Причины списания
+const (
+	WriteOffReasonDamaged      = "damaged"
+	WriteOffReasonDisplayModel = "display_model"
+	WriteOffReasonTransport    = "transport"
+	WriteOffReasonLost         = "lost"
+	WriteOffReasonDefective    = "defective"
+	WriteOffReasonOther        = "other"
+)
+
+var WriteOffReasons = []string{
+	WriteOffReasonDamaged,
+	WriteOffReasonDisplayModel,
+	WriteOffReasonTransport,
+	WriteOffReasonLost,
+	WriteOffReasonDefective,
+	WriteOffReasonOther,
+}
+
+type WriteOffItemDto struct {
+	Id       int32 `json:"id"`
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+	// Себестоимость единицы на момент утверждения, null — списание ещё не утверждено
+	// или себестоимость товара не была известна
+	UnitCost *int64 `json:"unit_cost"`
+}
+
+type WriteOffDto struct {
+	Id         int32             `json:"id"`
+	StoreId    int32             `json:"store_id"`
+	Reason     string            `json:"reason"`
+	Status     string            `json:"status"`
+	Comment    string            `json:"comment,omitempty"`
+	CreatedBy  int32             `json:"created_by"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ApprovedBy *int32            `json:"approved_by"`
+	ApprovedAt *time.Time        `json:"approved_at"`
+	TotalCost  *int64            `json:"total_cost"`
+	Items      []WriteOffItemDto `json:"items"`
+}
+
+type CreateWriteOffItemDto struct {
+	GoodId   int32 `json:"good_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+type CreateWriteOffDto struct {
+	StoreId int32 `json:"store_id"`
+	// damaged, display_model, transport, lost, defective или other
+	Reason     string                  `json:"reason"`
+	EmployeeId int32                   `json:"employee_id"`
+	Comment    string                  `json:"comment"`
+	Items      []CreateWriteOffItemDto `json:"items"`
+}
+
+type ReviewWriteOffDto struct {
+	EmployeeId int32 `json:"employee_id"`
+}
+
+type WriteOffReportRowDto struct {
+	StoreId   int32  `json:"store_id"`
+	Reason    string `json:"reason"`
+	Documents int32  `json:"documents"`
+	Quantity  int32  `json:"quantity"`
+	Cost      int64  `json:"cost"`
+	// Списано товара без известной себестоимости, в cost не входит
+	UncostedQuantity int32 `json:"uncosted_quantity"`
+}
+
+type WriteOffReportDto struct {
+	From  string                 `json:"from"`
+	To    string                 `json:"to"`
+	Rows  []WriteOffReportRowDto `json:"rows"`
+	Total int64                  `json:"total"`
+	// Всего списано товара без известной себестоимости
+	UncostedQuantity int32 `json:"uncosted_quantity"`
+}
+
+type WriteOffInterface interface {
+	CreateWriteOff(ctx context.Context, dto CreateWriteOffDto) (WriteOffDto, error)
+	GetWriteOff(ctx context.Context, id int32) (WriteOffDto, error)
+	GetWriteOffs(ctx context.Context, storeId *int32, status string) ([]WriteOffDto, error)
+	ApproveWriteOff(ctx context.Context, id int32, dto ReviewWriteOffDto) (WriteOffDto, error)
+	RejectWriteOff(ctx context.Context, id int32, dto ReviewWriteOffDto) (WriteOffDto, error)
+	GetReport(ctx context.Context, storeId *int32, from time.Time, to time.Time) (WriteOffReportDto, error)
+}
+
+type WriteOffService struct {
+	Queries gen.Queries
+	DB      *pgxpool.Pool
+}
+
+var WriteOffNotFound = errors.New("write-off not found")
+var InvalidWriteOffError = errors.New("write-off must have a known reason and goods with positive quantities")
+var WriteOffStatusError = errors.New("write-off is already reviewed")
+var WriteOffSelfApprovalError = errors.New("write-off cannot be approved by its author")
+
+func ToWriteOffDto(writeOff gen.WriteOff, items []gen.WriteOffItem) WriteOffDto {
+	response := WriteOffDto{
+		Id:         writeOff.ID,
+		StoreId:    writeOff.StoreID,
+		Reason:     writeOff.Reason,
+		Status:     writeOff.Status,
+		Comment:    writeOff.Comment.String,
+		CreatedBy:  writeOff.CreatedBy,
+		CreatedAt:  writeOff.CreatedAt.Time,
+		ApprovedBy: fromInt4(writeOff.ApprovedBy),
+		ApprovedAt: timestampPtr(writeOff.ApprovedAt),
+		TotalCost:  fromOptionalNumeric(writeOff.TotalCost),
+		Items:      make([]WriteOffItemDto, len(items)),
+	}
+	for i, item := range items {
+		response.Items[i] = WriteOffItemDto{
+			Id:       item.ID,
+			GoodId:   item.GoodID,
+			Quantity: item.Quantity,
+			UnitCost: fromOptionalNumeric(item.UnitCost),
+		}
+	}
+	return response
+}
+
+// CreateWriteOff создаёт черновик списания. Остатки не меняются до утверждения.
+func (s WriteOffService) CreateWriteOff(ctx context.Context, dto CreateWriteOffDto) (WriteOffDto, error) {
+	if !slices.Contains(WriteOffReasons, dto.Reason) || len(dto.Items) == 0 {
+		return WriteOffDto{}, InvalidWriteOffError
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := q.GetStore(ctx, dto.StoreId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return WriteOffDto{}, StoreNotFound
+		}
+		return WriteOffDto{}, err
+	}
+	if err := checkEmployeeInStore(ctx, q, dto.EmployeeId, dto.StoreId); err != nil {
+		return WriteOffDto{}, err
+	}
+	writeOff, err := q.CreateWriteOff(ctx, gen.CreateWriteOffParams{
+		StoreID:   dto.StoreId,
+		Reason:    dto.Reason,
+		Status:    WriteOffStatusDraft,
+		Comment:   pgtype.Text{String: dto.Comment, Valid: dto.Comment != ""},
+		CreatedBy: dto.EmployeeId,
+		CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	items := make([]gen.WriteOffItem, len(dto.Items))
+	for i, item := range dto.Items {
+		if item.Quantity <= 0 {
+			return WriteOffDto{}, InvalidWriteOffError
+		}
+		if _, err := q.GetGood(ctx, item.GoodId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return WriteOffDto{}, ProductNotFound
+			}
+			return WriteOffDto{}, err
+		}
+		items[i], err = q.CreateWriteOffItem(ctx, gen.CreateWriteOffItemParams{
+			WriteOffID: writeOff.ID,
+			GoodID:     item.GoodId,
+			Quantity:   item.Quantity,
+		})
+		if err != nil {
+			return WriteOffDto{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return WriteOffDto{}, err
+	}
+	return ToWriteOffDto(writeOff, items), nil
+}
+
+func (s WriteOffService) GetWriteOff(ctx context.Context, id int32) (WriteOffDto, error) {
+	writeOff, err := s.Queries.GetWriteOff(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return WriteOffDto{}, WriteOffNotFound
+		}
+		return WriteOffDto{}, err
+	}
+	items, err := s.Queries.ListWriteOffItems(ctx, id)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	return ToWriteOffDto(writeOff, items), nil
+}
+
+func (s WriteOffService) GetWriteOffs(ctx context.Context, storeId *int32, status string) ([]WriteOffDto, error) {
+	writeOffs, err := s.Queries.ListWriteOffs(ctx, gen.ListWriteOffsParams{
+		StoreID: toInt4(storeId),
+		Status:  pgtype.Text{String: status, Valid: status != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := make([]WriteOffDto, len(writeOffs))
+	for i, writeOff := range writeOffs {
+		items, err := s.Queries.ListWriteOffItems(ctx, writeOff.ID)
+		if err != nil {
+			return nil, err
+		}
+		response[i] = ToWriteOffDto(writeOff, items)
+	}
+	return response, nil
+}
+
+// lockDraftWriteOff блокирует черновик и проверяет право сотрудника утверждать списания
+func lockDraftWriteOff(ctx context.Context, q *gen.Queries, id int32, employeeId int32) (gen.WriteOff, error) {
+	writeOff, err := q.GetWriteOffForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gen.WriteOff{}, WriteOffNotFound
+		}
+		return gen.WriteOff{}, err
+	}
+	if writeOff.Status != WriteOffStatusDraft {
+		return gen.WriteOff{}, WriteOffStatusError
+	}
+	if err := checkPermission(ctx, q, employeeId, PermissionApproveWriteOffs); err != nil {
+		return gen.WriteOff{}, err
+	}
+	return writeOff, nil
+}
+
+// checkWriteOffApprover запрещает автору утверждать собственное списание
+func checkWriteOffApprover(writeOff gen.WriteOff, employeeId int32) error {
+	if writeOff.CreatedBy == employeeId {
+		return WriteOffSelfApprovalError
+	}
+	return nil
+}
+
+// writeOffTotalCost считает себестоимость списания по зафиксированным ценам; товары без себестоимости не учитываются
+func writeOffTotalCost(items []gen.WriteOffItem) int64 {
+	var total int64
+	for _, item := range items {
+		if item.UnitCost.Valid {
+			total += fromNumeric(item.UnitCost) * int64(item.Quantity)
+		}
+	}
+	return total
+}
+
+// ApproveWriteOff утверждает списание: фиксирует себестоимость товаров на этот момент и уменьшает остатки.
+// Зарезервированный под заказы товар списать нельзя. Автор списания утвердить его не может.
+// Товар без себестоимости списывается с пустой себестоимостью и не входит в total_cost.
+func (s WriteOffService) ApproveWriteOff(ctx context.Context, id int32, dto ReviewWriteOffDto) (WriteOffDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	writeOff, err := lockDraftWriteOff(ctx, q, id, dto.EmployeeId)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	if err := checkWriteOffApprover(writeOff, dto.EmployeeId); err != nil {
+		return WriteOffDto{}, err
+	}
+	items, err := q.ListWriteOffItems(ctx, id)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	now := pgtype.Timestamp{Time: time.Now(), Valid: true}
+	for i, item := range items {
+		good, err := q.GetGoodForUpdate(ctx, item.GoodID)
+		if err != nil {
+			return WriteOffDto{}, err
+		}
+		reserved, err := q.GetReservedQuantity(ctx, item.GoodID)
+		if err != nil {
+			return WriteOffDto{}, err
+		}
+		if err := checkAvailable(good.Quantity, reserved, item.Quantity); err != nil {
+			return WriteOffDto{}, err
+		}
+		if err := q.SetWriteOffItemCost(ctx, gen.SetWriteOffItemCostParams{ID: item.ID, UnitCost: good.Cost}); err != nil {
+			return WriteOffDto{}, err
+		}
+		items[i].UnitCost = good.Cost
+		_, err = q.DecreaseGoodQuantity(ctx, gen.DecreaseGoodQuantityParams{ID: item.GoodID, Quantity: item.Quantity})
+		if err != nil {
+			return WriteOffDto{}, err
+		}
+		_, err = q.CreateStockAdjustment(ctx, gen.CreateStockAdjustmentParams{
+			GoodID:     item.GoodID,
+			StoreID:    writeOff.StoreID,
+			Quantity:   -item.Quantity,
+			Reason:     StockAdjustmentWriteOff,
+			WriteOffID: pgtype.Int4{Int32: id, Valid: true},
+			EmployeeID: pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+			Comment:    pgtype.Text{String: writeOff.Reason, Valid: true},
+			CreatedAt:  now,
+		})
+		if err != nil {
+			return WriteOffDto{}, err
+		}
+	}
+	writeOff, err = q.UpdateWriteOffStatus(ctx, gen.UpdateWriteOffStatusParams{
+		ID:         id,
+		Status:     WriteOffStatusApproved,
+		ApprovedBy: pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+		ApprovedAt: now,
+		TotalCost:  toNumeric(writeOffTotalCost(items)),
+	})
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return WriteOffDto{}, err
+	}
+	return ToWriteOffDto(writeOff, items), nil
+}
+
+// RejectWriteOff отклоняет списание без изменения остатков
+func (s WriteOffService) RejectWriteOff(ctx context.Context, id int32, dto ReviewWriteOffDto) (WriteOffDto, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.Queries.WithTx(tx)
+
+	if _, err := lockDraftWriteOff(ctx, q, id, dto.EmployeeId); err != nil {
+		return WriteOffDto{}, err
+	}
+	writeOff, err := q.UpdateWriteOffStatus(ctx, gen.UpdateWriteOffStatusParams{
+		ID:         id,
+		Status:     WriteOffStatusRejected,
+		ApprovedBy: pgtype.Int4{Int32: dto.EmployeeId, Valid: true},
+		ApprovedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	items, err := q.ListWriteOffItems(ctx, id)
+	if err != nil {
+		return WriteOffDto{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return WriteOffDto{}, err
+	}
+	return ToWriteOffDto(writeOff, items), nil
+}
+
+// GetReport считает себестоимость утверждённых за [from, to) списаний по магазинам и причинам
+func (s WriteOffService) GetReport(ctx context.Context, storeId *int32, from time.Time, to time.Time) (WriteOffReportDto, error) {
+	rows, err := s.Queries.WriteOffReport(ctx, gen.WriteOffReportParams{
+		DateFrom: pgtype.Timestamp{Time: from, Valid: true},
+		DateTo:   pgtype.Timestamp{Time: to, Valid: true},
+		StoreID:  toInt4(storeId),
+	})
+	if err != nil {
+		return WriteOffReportDto{}, err
+	}
+	response := WriteOffReportDto{
+		From: from.Format(DateLayout),
+		To:   to.AddDate(0, 0, -1).Format(DateLayout),
+		Rows: make([]WriteOffReportRowDto, len(rows)),
+	}
+	for i, row := range rows {
+		response.Rows[i] = WriteOffReportRowDto{
+			StoreId:          row.StoreID,
+			Reason:           row.Reason,
+			Documents:        row.Documents,
+			Quantity:         row.Quantity,
+			Cost:             row.Cost,
+			UncostedQuantity: row.UncostedQuantity,
+		}
+		response.Total += row.Cost
+		response.UncostedQuantity += row.UncostedQuantity
+	}
+	return response, nil
+}
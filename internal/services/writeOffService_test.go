@@ -0,0 +1,108 @@
+package services
+
+import (
+	"HomeApplianceStore/pkg/gen"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strings"
+	"testing"
+)
+
+// writeOffDB отвечает на блокировку списания и проверку права утверждать списания
+type writeOffDB struct {
+	gen.DBTX
+	writeOffs map[int32]gen.WriteOff
+	approvers map[int32]bool
+}
+
+func (db writeOffDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	switch {
+	case strings.Contains(sql, "name: GetWriteOffForUpdate "):
+		w, ok := db.writeOffs[args[0].(int32)]
+		if !ok {
+			return tenderRow{err: pgx.ErrNoRows}
+		}
+		return tenderRow{values: []any{w.ID, w.StoreID, w.Reason, w.Status, w.Comment, w.CreatedBy, w.CreatedAt, w.ApprovedBy, w.ApprovedAt, w.TotalCost}}
+	case strings.Contains(sql, "name: CountEmployeePermissions "):
+		var granted int32
+		if db.approvers[args[0].(int32)] && args[1] == PermissionApproveWriteOffs {
+			granted = 1
+		}
+		return tenderRow{values: []any{granted}}
+	}
+	return tenderRow{err: errors.New("unexpected query")}
+}
+
+func TestLockDraftWriteOff(t *testing.T) {
+	db := writeOffDB{
+		writeOffs: map[int32]gen.WriteOff{
+			1: {ID: 1, StoreID: 1, Reason: WriteOffReasonDamaged, Status: WriteOffStatusDraft, CreatedBy: 10},
+			2: {ID: 2, StoreID: 1, Reason: WriteOffReasonLost, Status: WriteOffStatusApproved, CreatedBy: 10},
+			3: {ID: 3, StoreID: 1, Reason: WriteOffReasonLost, Status: WriteOffStatusRejected, CreatedBy: 10},
+		},
+		approvers: map[int32]bool{20: true},
+	}
+	q := gen.New(db)
+	tests := []struct {
+		name       string
+		id         int32
+		employeeId int32
+		want       error
+	}{
+		{"черновик и право есть", 1, 20, nil},
+		{"нет права утверждать", 1, 30, PermissionDeniedError},
+		{"уже утверждено", 2, 20, WriteOffStatusError},
+		{"уже отклонено", 3, 20, WriteOffStatusError},
+		{"списания нет", 4, 20, WriteOffNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writeOff, err := lockDraftWriteOff(context.Background(), q, test.id, test.employeeId)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("lockDraftWriteOff = %v, want %v", err, test.want)
+			}
+			if err == nil && writeOff.ID != test.id {
+				t.Errorf("lockDraftWriteOff id = %d, want %d", writeOff.ID, test.id)
+			}
+		})
+	}
+}
+
+func TestCheckWriteOffApprover(t *testing.T) {
+	writeOff := gen.WriteOff{ID: 1, CreatedBy: 10}
+	if err := checkWriteOffApprover(writeOff, 20); err != nil {
+		t.Errorf("checkWriteOffApprover другим сотрудником = %v, want nil", err)
+	}
+	if err := checkWriteOffApprover(writeOff, 10); !errors.Is(err, WriteOffSelfApprovalError) {
+		t.Errorf("checkWriteOffApprover автором = %v, want %v", err, WriteOffSelfApprovalError)
+	}
+}
+
+func TestWriteOffTotalCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []gen.WriteOffItem
+		want  int64
+	}{
+		{"пустое списание", nil, 0},
+		{"одна позиция", []gen.WriteOffItem{{Quantity: 3, UnitCost: toNumeric(1500)}}, 4500},
+		{
+			"товар без себестоимости не учитывается",
+			[]gen.WriteOffItem{
+				{Quantity: 2, UnitCost: toNumeric(1000)},
+				{Quantity: 5, UnitCost: pgtype.Numeric{}},
+				{Quantity: 1, UnitCost: toNumeric(700)},
+			},
+			2700,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := writeOffTotalCost(test.items); got != test.want {
+				t.Errorf("writeOffTotalCost = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
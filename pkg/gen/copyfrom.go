@@ -34,6 +34,9 @@ func (r iteratorForCreateManyGoods) Values() ([]interface{}, error) {
 		r.rows[0].Name,
 		r.rows[0].Quantity,
 		r.rows[0].IsAlive,
+		r.rows[0].CategoryID,
+		r.rows[0].TaxRateID,
+		r.rows[0].Brand,
 	}, nil
 }
 
@@ -42,5 +45,5 @@ func (r iteratorForCreateManyGoods) Err() error {
 }
 
 func (q *Queries) CreateManyGoods(ctx context.Context, arg []CreateManyGoodsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"goods"}, []string{"article", "price", "name", "quantity", "is_alive"}, &iteratorForCreateManyGoods{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"goods"}, []string{"article", "price", "name", "quantity", "is_alive", "category_id", "tax_rate_id", "brand"}, &iteratorForCreateManyGoods{rows: arg})
 }
@@ -11,10 +11,26 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addCustomerBalance = `-- name: AddCustomerBalance :exec
+UPDATE Customers
+SET balance = balance + $2
+WHERE id = $1
+`
+
+type AddCustomerBalanceParams struct {
+	ID      int32
+	Balance pgtype.Numeric
+}
+
+func (q *Queries) AddCustomerBalance(ctx context.Context, arg AddCustomerBalanceParams) error {
+	_, err := q.db.Exec(ctx, addCustomerBalance, arg.ID, arg.Balance)
+	return err
+}
+
 const createCustomer = `-- name: CreateCustomer :one
-INSERT INTO Customers (account_id, balance, created_at, is_alive)
-VALUES ($1, $2, $3, $4)
-RETURNING id, account_id, balance, created_at, is_alive
+INSERT INTO Customers (account_id, balance, created_at, is_alive, phone, email)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, account_id, balance, created_at, is_alive, phone, email
 `
 
 type CreateCustomerParams struct {
@@ -22,6 +38,8 @@ type CreateCustomerParams struct {
 	Balance   pgtype.Numeric
 	CreatedAt pgtype.Timestamp
 	IsAlive   bool
+	Phone     pgtype.Text
+	Email     pgtype.Text
 }
 
 func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error) {
@@ -30,6 +48,8 @@ func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams)
 		arg.Balance,
 		arg.CreatedAt,
 		arg.IsAlive,
+		arg.Phone,
+		arg.Email,
 	)
 	var i Customer
 	err := row.Scan(
@@ -38,6 +58,8 @@ func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams)
 		&i.Balance,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.Phone,
+		&i.Email,
 	)
 	return i, err
 }
@@ -53,9 +75,66 @@ func (q *Queries) DeleteCustomer(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportCustomers = `-- name: ExportCustomers :many
+SELECT c.id, c.account_id, c.balance, c.created_at, c.is_alive, c.phone, c.email,
+       a.login as account_login
+FROM Customers c
+         JOIN Accounts a ON c.account_id = a.id
+WHERE c.is_alive = true
+  AND c.id > $1
+ORDER BY c.id
+LIMIT $2
+`
+
+type ExportCustomersParams struct {
+	ID    int32
+	Limit int32
+}
+
+type ExportCustomersRow struct {
+	ID           int32
+	AccountID    int32
+	Balance      pgtype.Numeric
+	CreatedAt    pgtype.Timestamp
+	IsAlive      bool
+	Phone        pgtype.Text
+	Email        pgtype.Text
+	AccountLogin string
+}
+
+// Страница выгрузки покупателей после покупателя с id = $1
+func (q *Queries) ExportCustomers(ctx context.Context, arg ExportCustomersParams) ([]ExportCustomersRow, error) {
+	rows, err := q.db.Query(ctx, exportCustomers, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportCustomersRow
+	for rows.Next() {
+		var i ExportCustomersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.IsAlive,
+			&i.Phone,
+			&i.Email,
+			&i.AccountLogin,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCustomer = `-- name: GetCustomer :one
 SELECT
-    c.id, c.account_id, c.balance, c.created_at, c.is_alive,
+    c.id, c.account_id, c.balance, c.created_at, c.is_alive, c.phone, c.email,
     a.login as account_login,
     a.created_at as account_created_at,
     a.is_alive as account_is_alive
@@ -72,6 +151,8 @@ type GetCustomerRow struct {
 	Balance          pgtype.Numeric
 	CreatedAt        pgtype.Timestamp
 	IsAlive          bool
+	Phone            pgtype.Text
+	Email            pgtype.Text
 	AccountLogin     string
 	AccountCreatedAt pgtype.Timestamp
 	AccountIsAlive   bool
@@ -87,6 +168,8 @@ func (q *Queries) GetCustomer(ctx context.Context, id int32) (GetCustomerRow, er
 		&i.Balance,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.Phone,
+		&i.Email,
 		&i.AccountLogin,
 		&i.AccountCreatedAt,
 		&i.AccountIsAlive,
@@ -94,8 +177,24 @@ func (q *Queries) GetCustomer(ctx context.Context, id int32) (GetCustomerRow, er
 	return i, err
 }
 
+const getCustomerBalanceForUpdate = `-- name: GetCustomerBalanceForUpdate :one
+SELECT balance
+FROM Customers
+WHERE id = $1
+  AND is_alive = true
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetCustomerBalanceForUpdate(ctx context.Context, id int32) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getCustomerBalanceForUpdate, id)
+	var balance pgtype.Numeric
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const listCustomers = `-- name: ListCustomers :many
-SELECT c.id, c.account_id, c.balance, c.created_at, c.is_alive,
+SELECT c.id, c.account_id, c.balance, c.created_at, c.is_alive, c.phone, c.email,
        a.login as account_login,
        a.created_at as account_created_at,
        a.is_alive as account_is_alive
@@ -111,6 +210,8 @@ type ListCustomersRow struct {
 	Balance          pgtype.Numeric
 	CreatedAt        pgtype.Timestamp
 	IsAlive          bool
+	Phone            pgtype.Text
+	Email            pgtype.Text
 	AccountLogin     string
 	AccountCreatedAt pgtype.Timestamp
 	AccountIsAlive   bool
@@ -132,6 +233,8 @@ func (q *Queries) ListCustomers(ctx context.Context) ([]ListCustomersRow, error)
 			&i.Balance,
 			&i.CreatedAt,
 			&i.IsAlive,
+			&i.Phone,
+			&i.Email,
 			&i.AccountLogin,
 			&i.AccountCreatedAt,
 			&i.AccountIsAlive,
@@ -149,19 +252,29 @@ func (q *Queries) ListCustomers(ctx context.Context) ([]ListCustomersRow, error)
 const updateCustomer = `-- name: UpdateCustomer :one
 UPDATE Customers
 SET balance  = $2,
-    is_alive = $3
+    is_alive = $3,
+    phone    = $4,
+    email    = $5
 WHERE id = $1
-RETURNING id, account_id, balance, created_at, is_alive
+RETURNING id, account_id, balance, created_at, is_alive, phone, email
 `
 
 type UpdateCustomerParams struct {
 	ID      int32
 	Balance pgtype.Numeric
 	IsAlive bool
+	Phone   pgtype.Text
+	Email   pgtype.Text
 }
 
 func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error) {
-	row := q.db.QueryRow(ctx, updateCustomer, arg.ID, arg.Balance, arg.IsAlive)
+	row := q.db.QueryRow(ctx, updateCustomer,
+		arg.ID,
+		arg.Balance,
+		arg.IsAlive,
+		arg.Phone,
+		arg.Email,
+	)
 	var i Customer
 	err := row.Scan(
 		&i.ID,
@@ -169,6 +282,8 @@ func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams)
 		&i.Balance,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.Phone,
+		&i.Email,
 	)
 	return i, err
 }
@@ -11,6 +11,32 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const closeEmployeeStore = `-- name: CloseEmployeeStore :one
+UPDATE Employee_Stores
+SET ends_on = $2
+WHERE id = $1
+RETURNING id, employee_id, store_id, starts_on, ends_on, created_at
+`
+
+type CloseEmployeeStoreParams struct {
+	ID     int32
+	EndsOn pgtype.Date
+}
+
+func (q *Queries) CloseEmployeeStore(ctx context.Context, arg CloseEmployeeStoreParams) (EmployeeStore, error) {
+	row := q.db.QueryRow(ctx, closeEmployeeStore, arg.ID, arg.EndsOn)
+	var i EmployeeStore
+	err := row.Scan(
+		&i.ID,
+		&i.EmployeeID,
+		&i.StoreID,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createEmployee = `-- name: CreateEmployee :one
 INSERT INTO Employees (account_id, role_id, created_at, is_alive)
 VALUES ($1, $2, $3, $4)
@@ -42,6 +68,38 @@ func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams)
 	return i, err
 }
 
+const createEmployeeStore = `-- name: CreateEmployeeStore :one
+INSERT INTO Employee_Stores (employee_id, store_id, starts_on, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, employee_id, store_id, starts_on, ends_on, created_at
+`
+
+type CreateEmployeeStoreParams struct {
+	EmployeeID int32
+	StoreID    int32
+	StartsOn   pgtype.Date
+	CreatedAt  pgtype.Timestamp
+}
+
+func (q *Queries) CreateEmployeeStore(ctx context.Context, arg CreateEmployeeStoreParams) (EmployeeStore, error) {
+	row := q.db.QueryRow(ctx, createEmployeeStore,
+		arg.EmployeeID,
+		arg.StoreID,
+		arg.StartsOn,
+		arg.CreatedAt,
+	)
+	var i EmployeeStore
+	err := row.Scan(
+		&i.ID,
+		&i.EmployeeID,
+		&i.StoreID,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const deleteEmployee = `-- name: DeleteEmployee :exec
 UPDATE Employees
 SET is_alive = false
@@ -53,6 +111,41 @@ func (q *Queries) DeleteEmployee(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteEmployeeStore = `-- name: DeleteEmployeeStore :exec
+DELETE
+FROM Employee_Stores
+WHERE id = $1
+`
+
+func (q *Queries) DeleteEmployeeStore(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deleteEmployeeStore, id)
+	return err
+}
+
+const getCurrentEmployeeStoreForUpdate = `-- name: GetCurrentEmployeeStoreForUpdate :one
+SELECT id, employee_id, store_id, starts_on, ends_on, created_at
+FROM Employee_Stores
+WHERE employee_id = $1
+  AND ends_on IS NULL
+LIMIT 1
+FOR UPDATE
+`
+
+// Текущее назначение — единственное без даты окончания
+func (q *Queries) GetCurrentEmployeeStoreForUpdate(ctx context.Context, employeeID int32) (EmployeeStore, error) {
+	row := q.db.QueryRow(ctx, getCurrentEmployeeStoreForUpdate, employeeID)
+	var i EmployeeStore
+	err := row.Scan(
+		&i.ID,
+		&i.EmployeeID,
+		&i.StoreID,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getEmployee = `-- name: GetEmployee :one
 SELECT
     e.id,
@@ -68,28 +161,39 @@ SELECT
 
     r.id as id_role,
     r.name as role_name,
-    r.created_at as role_created_at
+    r.created_at as role_created_at,
+
+    cs.store_id as current_store_id,
+    s.address as current_store_address,
+    cs.starts_on as current_store_since
 
 FROM Employees e
          JOIN Accounts a ON e.account_id = a.id
          JOIN Roles r ON e.role_id = r.id
+         LEFT JOIN Employee_Stores cs ON cs.employee_id = e.id
+    AND cs.starts_on <= current_date
+    AND (cs.ends_on IS NULL OR cs.ends_on > current_date)
+         LEFT JOIN Stores s ON s.id = cs.store_id
 WHERE e.id = $1
 LIMIT 1
 `
 
 type GetEmployeeRow struct {
-	ID               int32
-	AccountID        int32
-	RoleID           int32
-	CreatedAt        pgtype.Timestamp
-	IsAlive          bool
-	IDAccount        int32
-	AccountLogin     string
-	AccountCreatedAt pgtype.Timestamp
-	AccountIsAlive   bool
-	IDRole           int32
-	RoleName         string
-	RoleCreatedAt    pgtype.Timestamp
+	ID                  int32
+	AccountID           int32
+	RoleID              int32
+	CreatedAt           pgtype.Timestamp
+	IsAlive             bool
+	IDAccount           int32
+	AccountLogin        string
+	AccountCreatedAt    pgtype.Timestamp
+	AccountIsAlive      bool
+	IDRole              int32
+	RoleName            string
+	RoleCreatedAt       pgtype.Timestamp
+	CurrentStoreID      pgtype.Int4
+	CurrentStoreAddress pgtype.Text
+	CurrentStoreSince   pgtype.Date
 }
 
 func (q *Queries) GetEmployee(ctx context.Context, id int32) (GetEmployeeRow, error) {
@@ -108,10 +212,59 @@ func (q *Queries) GetEmployee(ctx context.Context, id int32) (GetEmployeeRow, er
 		&i.IDRole,
 		&i.RoleName,
 		&i.RoleCreatedAt,
+		&i.CurrentStoreID,
+		&i.CurrentStoreAddress,
+		&i.CurrentStoreSince,
 	)
 	return i, err
 }
 
+const listEmployeeStores = `-- name: ListEmployeeStores :many
+SELECT es.id, es.employee_id, es.store_id, es.starts_on, es.ends_on, es.created_at, s.address as store_address
+FROM Employee_Stores es
+         JOIN Stores s ON s.id = es.store_id
+WHERE es.employee_id = $1
+ORDER BY es.starts_on DESC, es.id DESC
+`
+
+type ListEmployeeStoresRow struct {
+	ID           int32
+	EmployeeID   int32
+	StoreID      int32
+	StartsOn     pgtype.Date
+	EndsOn       pgtype.Date
+	CreatedAt    pgtype.Timestamp
+	StoreAddress string
+}
+
+func (q *Queries) ListEmployeeStores(ctx context.Context, employeeID int32) ([]ListEmployeeStoresRow, error) {
+	rows, err := q.db.Query(ctx, listEmployeeStores, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeeStoresRow
+	for rows.Next() {
+		var i ListEmployeeStoresRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmployeeID,
+			&i.StoreID,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.CreatedAt,
+			&i.StoreAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEmployees = `-- name: ListEmployees :many
 SELECT
     e.id,
@@ -127,16 +280,114 @@ SELECT
 
     r.id as id_role,
     r.name as role_name,
-    r.created_at as role_created_at
+    r.created_at as role_created_at,
+
+    cs.store_id as current_store_id,
+    s.address as current_store_address,
+    cs.starts_on as current_store_since
 
 FROM Employees e
          JOIN Accounts a ON e.account_id = a.id
          JOIN Roles r ON e.role_id = r.id
+         LEFT JOIN Employee_Stores cs ON cs.employee_id = e.id
+    AND cs.starts_on <= current_date
+    AND (cs.ends_on IS NULL OR cs.ends_on > current_date)
+         LEFT JOIN Stores s ON s.id = cs.store_id
 WHERE e.is_alive = true
 ORDER BY e.id
 `
 
 type ListEmployeesRow struct {
+	ID                  int32
+	AccountID           int32
+	RoleID              int32
+	CreatedAt           pgtype.Timestamp
+	IsAlive             bool
+	IDAccount           int32
+	AccountLogin        string
+	AccountCreatedAt    pgtype.Timestamp
+	AccountIsAlive      bool
+	IDRole              int32
+	RoleName            string
+	RoleCreatedAt       pgtype.Timestamp
+	CurrentStoreID      pgtype.Int4
+	CurrentStoreAddress pgtype.Text
+	CurrentStoreSince   pgtype.Date
+}
+
+func (q *Queries) ListEmployees(ctx context.Context) ([]ListEmployeesRow, error) {
+	rows, err := q.db.Query(ctx, listEmployees)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmployeesRow
+	for rows.Next() {
+		var i ListEmployeesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.RoleID,
+			&i.CreatedAt,
+			&i.IsAlive,
+			&i.IDAccount,
+			&i.AccountLogin,
+			&i.AccountCreatedAt,
+			&i.AccountIsAlive,
+			&i.IDRole,
+			&i.RoleName,
+			&i.RoleCreatedAt,
+			&i.CurrentStoreID,
+			&i.CurrentStoreAddress,
+			&i.CurrentStoreSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStoreEmployees = `-- name: ListStoreEmployees :many
+SELECT
+    e.id,
+    e.account_id,
+    e.role_id,
+    e.created_at,
+    e.is_alive,
+
+    a.id as id_account,
+    a.login as account_login,
+    a.created_at as account_created_at,
+    a.is_alive as account_is_alive,
+
+    r.id as id_role,
+    r.name as role_name,
+    r.created_at as role_created_at,
+
+    es.starts_on as assigned_from,
+    es.ends_on as assigned_to
+
+FROM Employee_Stores es
+         JOIN Employees e ON e.id = es.employee_id
+         JOIN Accounts a ON e.account_id = a.id
+         JOIN Roles r ON e.role_id = r.id
+WHERE es.store_id = $1
+  AND es.starts_on <= $2
+  AND (es.ends_on IS NULL OR es.ends_on > $2)
+  AND e.is_alive = true
+ORDER BY e.id
+`
+
+type ListStoreEmployeesParams struct {
+	StoreID int32
+	OnDate  pgtype.Date
+}
+
+type ListStoreEmployeesRow struct {
 	ID               int32
 	AccountID        int32
 	RoleID           int32
@@ -149,17 +400,20 @@ type ListEmployeesRow struct {
 	IDRole           int32
 	RoleName         string
 	RoleCreatedAt    pgtype.Timestamp
+	AssignedFrom     pgtype.Date
+	AssignedTo       pgtype.Date
 }
 
-func (q *Queries) ListEmployees(ctx context.Context) ([]ListEmployeesRow, error) {
-	rows, err := q.db.Query(ctx, listEmployees)
+// Сотрудники, работавшие в магазине в указанный день: начало включительно, окончание не включительно
+func (q *Queries) ListStoreEmployees(ctx context.Context, arg ListStoreEmployeesParams) ([]ListStoreEmployeesRow, error) {
+	rows, err := q.db.Query(ctx, listStoreEmployees, arg.StoreID, arg.OnDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListEmployeesRow
+	var items []ListStoreEmployeesRow
 	for rows.Next() {
-		var i ListEmployeesRow
+		var i ListStoreEmployeesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.AccountID,
@@ -173,6 +427,8 @@ func (q *Queries) ListEmployees(ctx context.Context) ([]ListEmployeesRow, error)
 			&i.IDRole,
 			&i.RoleName,
 			&i.RoleCreatedAt,
+			&i.AssignedFrom,
+			&i.AssignedTo,
 		); err != nil {
 			return nil, err
 		}
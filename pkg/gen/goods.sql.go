@@ -12,17 +12,21 @@ import (
 )
 
 const createGood = `-- name: CreateGood :one
-INSERT INTO Goods (article, price, name, quantity, is_alive)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, article, price, name, quantity, is_alive
+INSERT INTO Goods (article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
 `
 
 type CreateGoodParams struct {
-	Article  string
-	Price    pgtype.Numeric
-	Name     string
-	Quantity int32
-	IsAlive  bool
+	Article    string
+	Price      pgtype.Numeric
+	Name       string
+	Quantity   int32
+	IsAlive    bool
+	CategoryID pgtype.Int4
+	TaxRateID  pgtype.Int4
+	Cost       pgtype.Numeric
+	Brand      pgtype.Text
 }
 
 func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, error) {
@@ -32,6 +36,10 @@ func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, e
 		arg.Name,
 		arg.Quantity,
 		arg.IsAlive,
+		arg.CategoryID,
+		arg.TaxRateID,
+		arg.Cost,
+		arg.Brand,
 	)
 	var i Good
 	err := row.Scan(
@@ -41,16 +49,86 @@ func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, e
 		&i.Name,
 		&i.Quantity,
 		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const createGoodCost = `-- name: CreateGoodCost :one
+INSERT INTO Good_Costs (good_id, cost, goods_receipt_id, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, good_id, cost, goods_receipt_id, created_at
+`
+
+type CreateGoodCostParams struct {
+	GoodID         int32
+	Cost           pgtype.Numeric
+	GoodsReceiptID pgtype.Int4
+	CreatedAt      pgtype.Timestamp
+}
+
+func (q *Queries) CreateGoodCost(ctx context.Context, arg CreateGoodCostParams) (GoodCost, error) {
+	row := q.db.QueryRow(ctx, createGoodCost,
+		arg.GoodID,
+		arg.Cost,
+		arg.GoodsReceiptID,
+		arg.CreatedAt,
+	)
+	var i GoodCost
+	err := row.Scan(
+		&i.ID,
+		&i.GoodID,
+		&i.Cost,
+		&i.GoodsReceiptID,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 type CreateManyGoodsParams struct {
-	Article  string
-	Price    pgtype.Numeric
-	Name     string
+	Article    string
+	Price      pgtype.Numeric
+	Name       string
+	Quantity   int32
+	IsAlive    bool
+	CategoryID pgtype.Int4
+	TaxRateID  pgtype.Int4
+	Brand      pgtype.Text
+}
+
+const decreaseGoodQuantity = `-- name: DecreaseGoodQuantity :one
+UPDATE Goods
+SET quantity = quantity - $2
+WHERE id = $1
+RETURNING id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
+`
+
+type DecreaseGoodQuantityParams struct {
+	ID       int32
 	Quantity int32
-	IsAlive  bool
+}
+
+func (q *Queries) DecreaseGoodQuantity(ctx context.Context, arg DecreaseGoodQuantityParams) (Good, error) {
+	row := q.db.QueryRow(ctx, decreaseGoodQuantity, arg.ID, arg.Quantity)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Article,
+		&i.Price,
+		&i.Name,
+		&i.Quantity,
+		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
+	)
+	return i, err
 }
 
 const deleteGood = `-- name: DeleteGood :exec
@@ -64,8 +142,55 @@ func (q *Queries) DeleteGood(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportGoods = `-- name: ExportGoods :many
+SELECT id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
+FROM Goods
+WHERE is_alive = true
+  AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ExportGoodsParams struct {
+	ID    int32
+	Limit int32
+}
+
+// Страница выгрузки товаров после товара с id = $1
+func (q *Queries) ExportGoods(ctx context.Context, arg ExportGoodsParams) ([]Good, error) {
+	rows, err := q.db.Query(ctx, exportGoods, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Good
+	for rows.Next() {
+		var i Good
+		if err := rows.Scan(
+			&i.ID,
+			&i.Article,
+			&i.Price,
+			&i.Name,
+			&i.Quantity,
+			&i.IsAlive,
+			&i.CategoryID,
+			&i.TaxRateID,
+			&i.Cost,
+			&i.Brand,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getGood = `-- name: GetGood :one
-SELECT id, article, price, name, quantity, is_alive
+SELECT id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
 FROM Goods
 WHERE id = $1
 LIMIT 1
@@ -81,12 +206,133 @@ func (q *Queries) GetGood(ctx context.Context, id int32) (Good, error) {
 		&i.Name,
 		&i.Quantity,
 		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
+const getGoodForUpdate = `-- name: GetGoodForUpdate :one
+SELECT id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
+FROM Goods
+WHERE id = $1
+LIMIT 1
+FOR UPDATE
+`
+
+// Блокируем строку товара до конца транзакции
+func (q *Queries) GetGoodForUpdate(ctx context.Context, id int32) (Good, error) {
+	row := q.db.QueryRow(ctx, getGoodForUpdate, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Article,
+		&i.Price,
+		&i.Name,
+		&i.Quantity,
+		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const getGoodTaxRate = `-- name: GetGoodTaxRate :one
+SELECT tr.id, tr.name, tr.rate, tr.created_at, tr.is_alive
+FROM Goods g
+         LEFT JOIN Categories c ON c.id = g.category_id
+         LEFT JOIN Tax_Rates gr ON gr.id = g.tax_rate_id AND gr.is_alive = true
+         JOIN Tax_Rates tr ON tr.id = COALESCE(gr.id, c.tax_rate_id) AND tr.is_alive = true
+WHERE g.id = $1
+LIMIT 1
+`
+
+// Ставка НДС товара: своя, а если не задана или удалена — ставка его категории
+func (q *Queries) GetGoodTaxRate(ctx context.Context, id int32) (TaxRate, error) {
+	row := q.db.QueryRow(ctx, getGoodTaxRate, id)
+	var i TaxRate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Rate,
+		&i.CreatedAt,
+		&i.IsAlive,
+	)
+	return i, err
+}
+
+const increaseGoodQuantity = `-- name: IncreaseGoodQuantity :one
+UPDATE Goods
+SET quantity = quantity + $2
+WHERE id = $1
+RETURNING id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
+`
+
+type IncreaseGoodQuantityParams struct {
+	ID       int32
+	Quantity int32
+}
+
+func (q *Queries) IncreaseGoodQuantity(ctx context.Context, arg IncreaseGoodQuantityParams) (Good, error) {
+	row := q.db.QueryRow(ctx, increaseGoodQuantity, arg.ID, arg.Quantity)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Article,
+		&i.Price,
+		&i.Name,
+		&i.Quantity,
+		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const listGoodArticles = `-- name: ListGoodArticles :many
+SELECT id, article
+FROM Goods
+WHERE is_alive = true
+ORDER BY id
+`
+
+type ListGoodArticlesRow struct {
+	ID      int32
+	Article string
+}
+
+// Артикулы товаров для сопоставления строк импорта
+func (q *Queries) ListGoodArticles(ctx context.Context) ([]ListGoodArticlesRow, error) {
+	rows, err := q.db.Query(ctx, listGoodArticles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGoodArticlesRow
+	for rows.Next() {
+		var i ListGoodArticlesRow
+		if err := rows.Scan(&i.ID, &i.Article); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGoods = `-- name: ListGoods :many
-SELECT id, article, price, name, quantity, is_alive
+SELECT id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
 FROM Goods
 WHERE is_alive = true
 ORDER BY name
@@ -108,6 +354,11 @@ func (q *Queries) ListGoods(ctx context.Context) ([]Good, error) {
 			&i.Name,
 			&i.Quantity,
 			&i.IsAlive,
+			&i.CategoryID,
+			&i.TaxRateID,
+			&i.Cost,
+			&i.Brand,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -121,32 +372,39 @@ func (q *Queries) ListGoods(ctx context.Context) ([]Good, error) {
 
 const updateGood = `-- name: UpdateGood :one
 UPDATE Goods
-SET article  = $2,
-    price    = $3,
-    name     = $4,
-    quantity = $5,
-    is_alive = $6
+SET article     = $2,
+    price       = $3,
+    name        = $4,
+    is_alive    = $5,
+    category_id = $6,
+    tax_rate_id = $7,
+    brand       = $8
 WHERE id = $1
-RETURNING id, article, price, name, quantity, is_alive
+RETURNING id, article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand, external_id
 `
 
 type UpdateGoodParams struct {
-	ID       int32
-	Article  string
-	Price    pgtype.Numeric
-	Name     string
-	Quantity int32
-	IsAlive  bool
+	ID         int32
+	Article    string
+	Price      pgtype.Numeric
+	Name       string
+	IsAlive    bool
+	CategoryID pgtype.Int4
+	TaxRateID  pgtype.Int4
+	Brand      pgtype.Text
 }
 
+// Остаток и себестоимость меняются только документами: приёмкой, продажей, списанием, инвентаризацией
 func (q *Queries) UpdateGood(ctx context.Context, arg UpdateGoodParams) (Good, error) {
 	row := q.db.QueryRow(ctx, updateGood,
 		arg.ID,
 		arg.Article,
 		arg.Price,
 		arg.Name,
-		arg.Quantity,
 		arg.IsAlive,
+		arg.CategoryID,
+		arg.TaxRateID,
+		arg.Brand,
 	)
 	var i Good
 	err := row.Scan(
@@ -156,6 +414,72 @@ func (q *Queries) UpdateGood(ctx context.Context, arg UpdateGoodParams) (Good, e
 		&i.Name,
 		&i.Quantity,
 		&i.IsAlive,
+		&i.CategoryID,
+		&i.TaxRateID,
+		&i.Cost,
+		&i.Brand,
+		&i.ExternalID,
 	)
 	return i, err
 }
+
+const updateGoodCost = `-- name: UpdateGoodCost :exec
+UPDATE Goods
+SET cost = $2
+WHERE id = $1
+`
+
+type UpdateGoodCostParams struct {
+	ID   int32
+	Cost pgtype.Numeric
+}
+
+func (q *Queries) UpdateGoodCost(ctx context.Context, arg UpdateGoodCostParams) error {
+	_, err := q.db.Exec(ctx, updateGoodCost, arg.ID, arg.Cost)
+	return err
+}
+
+const updateManyGoods = `-- name: UpdateManyGoods :exec
+UPDATE Goods g
+SET name        = CASE WHEN $1::bool THEN u.name ELSE g.name END,
+    price       = CASE WHEN $2::bool THEN u.price ELSE g.price END,
+    category_id = CASE WHEN $3::bool THEN u.category_id ELSE g.category_id END,
+    tax_rate_id = CASE WHEN $4::bool THEN u.tax_rate_id ELSE g.tax_rate_id END,
+    brand       = CASE WHEN $5::bool THEN u.brand ELSE g.brand END
+FROM unnest($6::integer[], $7::text[], $8::decimal[],
+            $9::integer[], $10::integer[],
+            $11::text[]) AS u(id, name, price, category_id, tax_rate_id, brand)
+WHERE g.id = u.id
+`
+
+type UpdateManyGoodsParams struct {
+	SetName     bool
+	SetPrice    bool
+	SetCategory bool
+	SetTaxRate  bool
+	SetBrand    bool
+	Ids         []int32
+	Names       []string
+	Prices      []pgtype.Numeric
+	CategoryIds []pgtype.Int4
+	TaxRateIds  []pgtype.Int4
+	Brands      []pgtype.Text
+}
+
+// Обновляет пачку товаров из импорта. Колонки, которых нет в файле, не меняются
+func (q *Queries) UpdateManyGoods(ctx context.Context, arg UpdateManyGoodsParams) error {
+	_, err := q.db.Exec(ctx, updateManyGoods,
+		arg.SetName,
+		arg.SetPrice,
+		arg.SetCategory,
+		arg.SetTaxRate,
+		arg.SetBrand,
+		arg.Ids,
+		arg.Names,
+		arg.Prices,
+		arg.CategoryIds,
+		arg.TaxRateIds,
+		arg.Brands,
+	)
+	return err
+}
@@ -12,17 +12,19 @@ import (
 )
 
 const createGoodsSupplier = `-- name: CreateGoodsSupplier :one
-INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive)
-VALUES ($1, $2, $3, $4, $5)
-    RETURNING id, supplier_id, good_id, created_at, is_alive
+INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days
 `
 
 type CreateGoodsSupplierParams struct {
-	ID         int32
-	SupplierID int32
-	GoodID     int32
-	CreatedAt  pgtype.Timestamp
-	IsAlive    bool
+	ID            int32
+	SupplierID    int32
+	GoodID        int32
+	CreatedAt     pgtype.Timestamp
+	IsAlive       bool
+	PurchasePrice pgtype.Numeric
+	LeadTimeDays  pgtype.Int4
 }
 
 func (q *Queries) CreateGoodsSupplier(ctx context.Context, arg CreateGoodsSupplierParams) (GoodsSupplier, error) {
@@ -32,6 +34,8 @@ func (q *Queries) CreateGoodsSupplier(ctx context.Context, arg CreateGoodsSuppli
 		arg.GoodID,
 		arg.CreatedAt,
 		arg.IsAlive,
+		arg.PurchasePrice,
+		arg.LeadTimeDays,
 	)
 	var i GoodsSupplier
 	err := row.Scan(
@@ -40,22 +44,26 @@ func (q *Queries) CreateGoodsSupplier(ctx context.Context, arg CreateGoodsSuppli
 		&i.GoodID,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.PurchasePrice,
+		&i.LeadTimeDays,
 	)
 	return i, err
 }
 
 const createGoodsSuppliers = `-- name: CreateGoodsSuppliers :one
-INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive)
-VALUES ($1, $2, $3, $4, $5)
-    RETURNING id, supplier_id, good_id, created_at, is_alive
+INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days
 `
 
 type CreateGoodsSuppliersParams struct {
-	ID         int32
-	SupplierID int32
-	GoodID     int32
-	CreatedAt  pgtype.Timestamp
-	IsAlive    bool
+	ID            int32
+	SupplierID    int32
+	GoodID        int32
+	CreatedAt     pgtype.Timestamp
+	IsAlive       bool
+	PurchasePrice pgtype.Numeric
+	LeadTimeDays  pgtype.Int4
 }
 
 func (q *Queries) CreateGoodsSuppliers(ctx context.Context, arg CreateGoodsSuppliersParams) (GoodsSupplier, error) {
@@ -65,6 +73,8 @@ func (q *Queries) CreateGoodsSuppliers(ctx context.Context, arg CreateGoodsSuppl
 		arg.GoodID,
 		arg.CreatedAt,
 		arg.IsAlive,
+		arg.PurchasePrice,
+		arg.LeadTimeDays,
 	)
 	var i GoodsSupplier
 	err := row.Scan(
@@ -73,6 +83,8 @@ func (q *Queries) CreateGoodsSuppliers(ctx context.Context, arg CreateGoodsSuppl
 		&i.GoodID,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.PurchasePrice,
+		&i.LeadTimeDays,
 	)
 	return i, err
 }
@@ -90,7 +102,7 @@ func (q *Queries) DeleteGoodsSupplier(ctx context.Context, id int32) error {
 }
 
 const getGoodsSupplier = `-- name: GetGoodsSupplier :one
-SELECT id, supplier_id, good_id, created_at, is_alive
+SELECT id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days
 FROM Goods_Suppliers
 WHERE id = $1
     LIMIT 1
@@ -105,12 +117,14 @@ func (q *Queries) GetGoodsSupplier(ctx context.Context, id int32) (GoodsSupplier
 		&i.GoodID,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.PurchasePrice,
+		&i.LeadTimeDays,
 	)
 	return i, err
 }
 
 const listGoodsBySupplier = `-- name: ListGoodsBySupplier :many
-SELECT g.id, g.article, g.price, g.name, g.quantity, g.is_alive
+SELECT g.id, g.article, g.price, g.name, g.quantity, g.is_alive, g.category_id, g.tax_rate_id, g.cost, g.brand, g.external_id
 FROM Goods g
          JOIN Goods_Suppliers gs ON g.id = gs.good_id
 WHERE gs.supplier_id = $1
@@ -135,6 +149,11 @@ func (q *Queries) ListGoodsBySupplier(ctx context.Context, supplierID int32) ([]
 			&i.Name,
 			&i.Quantity,
 			&i.IsAlive,
+			&i.CategoryID,
+			&i.TaxRateID,
+			&i.Cost,
+			&i.Brand,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -202,7 +221,7 @@ const updateGoodsSupplier = `-- name: UpdateGoodsSupplier :one
 UPDATE Goods_Suppliers
 SET is_alive = $2
 WHERE id = $1
-    RETURNING id, supplier_id, good_id, created_at, is_alive
+    RETURNING id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days
 `
 
 type UpdateGoodsSupplierParams struct {
@@ -219,6 +238,8 @@ func (q *Queries) UpdateGoodsSupplier(ctx context.Context, arg UpdateGoodsSuppli
 		&i.GoodID,
 		&i.CreatedAt,
 		&i.IsAlive,
+		&i.PurchasePrice,
+		&i.LeadTimeDays,
 	)
 	return i, err
 }
@@ -16,12 +16,80 @@ type Account struct {
 	IsAlive   bool
 }
 
+type CashMovement struct {
+	ID         int32
+	SessionID  int32
+	Type       string
+	Amount     pgtype.Numeric
+	OrderID    pgtype.Int4
+	PaymentID  pgtype.Int4
+	EmployeeID pgtype.Int4
+	Comment    pgtype.Text
+	CreatedAt  pgtype.Timestamp
+}
+
+type Category struct {
+	ID         int32
+	Name       string
+	TaxRateID  pgtype.Int4
+	CreatedAt  pgtype.Timestamp
+	IsAlive    bool
+	ExternalID pgtype.Text
+}
+
+type CommissionRule struct {
+	ID         int32
+	RoleID     pgtype.Int4
+	CategoryID pgtype.Int4
+	RateBps    int32
+	CreatedAt  pgtype.Timestamp
+	IsAlive    bool
+}
+
 type Customer struct {
 	ID        int32
 	AccountID int32
 	Balance   pgtype.Numeric
 	CreatedAt pgtype.Timestamp
 	IsAlive   bool
+	Phone     pgtype.Text
+	Email     pgtype.Text
+}
+
+type CustomerAddress struct {
+	ID          int32
+	CustomerID  int32
+	Label       string
+	City        string
+	Street      string
+	Apartment   pgtype.Text
+	Floor       pgtype.Int4
+	HasElevator bool
+	PostalCode  pgtype.Text
+	IsDefault   bool
+	CreatedAt   pgtype.Timestamp
+	IsAlive     bool
+}
+
+type Delivery struct {
+	ID        int32
+	OrderID   int32
+	SlotID    int32
+	CourierID pgtype.Int4
+	Address   string
+	Comment   pgtype.Text
+	Status    string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type DeliverySlot struct {
+	ID        int32
+	StoreID   int32
+	StartsAt  pgtype.Timestamp
+	EndsAt    pgtype.Timestamp
+	Capacity  int32
+	CreatedAt pgtype.Timestamp
 }
 
 type Employee struct {
@@ -32,21 +100,242 @@ type Employee struct {
 	IsAlive   bool
 }
 
+type EmployeeStore struct {
+	ID         int32
+	EmployeeID int32
+	StoreID    int32
+	StartsOn   pgtype.Date
+	EndsOn     pgtype.Date
+	CreatedAt  pgtype.Timestamp
+}
+
+type GiftCard struct {
+	ID        int32
+	Code      string
+	Balance   pgtype.Numeric
+	CreatedAt pgtype.Timestamp
+	IsAlive   bool
+}
+
 type Good struct {
+	ID         int32
+	Article    string
+	Price      pgtype.Numeric
+	Name       string
+	Quantity   int32
+	IsAlive    bool
+	CategoryID pgtype.Int4
+	TaxRateID  pgtype.Int4
+	Cost       pgtype.Numeric
+	Brand      pgtype.Text
+	ExternalID pgtype.Text
+}
+
+type GoodCost struct {
+	ID             int32
+	GoodID         int32
+	Cost           pgtype.Numeric
+	GoodsReceiptID pgtype.Int4
+	CreatedAt      pgtype.Timestamp
+}
+
+type GoodsReceipt struct {
+	ID              int32
+	PurchaseOrderID int32
+	StoreID         int32
+	EmployeeID      int32
+	IsFinal         bool
+	Comment         pgtype.Text
+	CreatedAt       pgtype.Timestamp
+}
+
+type GoodsReceiptItem struct {
+	ID                  int32
+	GoodsReceiptID      int32
+	PurchaseOrderItemID int32
+	GoodID              int32
+	ExpectedQuantity    int32
+	ReceivedQuantity    int32
+	DamagedQuantity     int32
+	AcceptedQuantity    int32
+	MissingQuantity     int32
+}
+
+type GoodsSupplier struct {
+	ID            int32
+	SupplierID    int32
+	GoodID        int32
+	CreatedAt     pgtype.Timestamp
+	IsAlive       bool
+	PurchasePrice pgtype.Numeric
+	LeadTimeDays  pgtype.Int4
+}
+
+type Order struct {
+	ID            int32
+	CustomerID    pgtype.Int4
+	StoreID       int32
+	Status        string
+	PaymentStatus string
+	FiscalStatus  string
+	FiscalSign    pgtype.Text
+	Total         pgtype.Numeric
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	EmployeeID    pgtype.Int4
+	RegisterID    pgtype.Int4
+	ExchangedAt   pgtype.Timestamp
+}
+
+type OrderItem struct {
 	ID       int32
-	Article  string
-	Price    pgtype.Numeric
-	Name     string
+	OrderID  int32
+	GoodID   int32
 	Quantity int32
-	IsAlive  bool
+	Price    pgtype.Numeric
+	VatRate  pgtype.Int4
+	UnitCost pgtype.Numeric
 }
 
-type GoodsSupplier struct {
+type OrderService struct {
+	ID        int32
+	OrderID   int32
+	ServiceID int32
+	Quantity  int32
+	Price     pgtype.Numeric
+	VatRate   pgtype.Int4
+}
+
+type Payment struct {
+	ID             int32
+	OrderID        int32
+	Tender         string
+	Provider       string
+	ExternalID     string
+	GiftCardID     pgtype.Int4
+	Amount         pgtype.Numeric
+	RefundedAmount pgtype.Numeric
+	Status         string
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+}
+
+type PurchaseOrder struct {
 	ID         int32
 	SupplierID int32
-	GoodID     int32
+	StoreID    int32
+	Status     string
+	Comment    pgtype.Text
+	CreatedBy  pgtype.Int4
 	CreatedAt  pgtype.Timestamp
-	IsAlive    bool
+	SentAt     pgtype.Timestamp
+	ExpectedAt pgtype.Timestamp
+}
+
+type PurchaseOrderItem struct {
+	ID              int32
+	PurchaseOrderID int32
+	GoodID          int32
+	Quantity        int32
+	UnitCost        pgtype.Numeric
+}
+
+type Receipt struct {
+	ID                   int32
+	OrderID              int32
+	RefundID             pgtype.Int4
+	CalculationType      int32
+	Total                pgtype.Numeric
+	Status               string
+	Document             pgtype.Text
+	FiscalDocumentNumber pgtype.Int4
+	FiscalSign           pgtype.Text
+	FnNumber             pgtype.Text
+	Error                pgtype.Text
+	CreatedAt            pgtype.Timestamp
+	RegisteredAt         pgtype.Timestamp
+}
+
+type Refund struct {
+	ID        int32
+	OrderID   int32
+	Amount    pgtype.Numeric
+	CreatedAt pgtype.Timestamp
+}
+
+type RefundItem struct {
+	ID       int32
+	RefundID int32
+	GoodID   int32
+	Quantity int32
+	Price    pgtype.Numeric
+	VatRate  pgtype.Int4
+}
+
+type RefundTender struct {
+	ID        int32
+	RefundID  int32
+	PaymentID int32
+	Tender    string
+	Amount    pgtype.Numeric
+	Status    string
+}
+
+type Register struct {
+	ID        int32
+	StoreID   int32
+	Name      string
+	CreatedAt pgtype.Timestamp
+	IsAlive   bool
+}
+
+type RegisterSession struct {
+	ID           int32
+	RegisterID   int32
+	OpenedBy     int32
+	OpenedAt     pgtype.Timestamp
+	OpeningCash  pgtype.Numeric
+	ClosedBy     pgtype.Int4
+	ClosedAt     pgtype.Timestamp
+	ExpectedCash pgtype.Numeric
+	CountedCash  pgtype.Numeric
+	ZReport      pgtype.Text
+	Status       string
+}
+
+type ReorderPoint struct {
+	ID              int32
+	GoodID          int32
+	StoreID         pgtype.Int4
+	MinQuantity     int32
+	ReorderQuantity int32
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+type ReplenishmentSuggestion struct {
+	ID              int32
+	GoodID          int32
+	SupplierID      pgtype.Int4
+	Available       int32
+	OnOrder         int32
+	MinQuantity     int32
+	Quantity        int32
+	UnitCost        pgtype.Numeric
+	Status          string
+	PurchaseOrderID pgtype.Int4
+	CreatedAt       pgtype.Timestamp
+}
+
+type Reservation struct {
+	ID        int32
+	GoodID    int32
+	StoreID   int32
+	Quantity  int32
+	Owner     string
+	Status    string
+	ExpiresAt pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
 }
 
 type Role struct {
@@ -55,6 +344,77 @@ type Role struct {
 	CreatedAt pgtype.Timestamp
 }
 
+type RolePermission struct {
+	RoleID     int32
+	Permission string
+	CreatedAt  pgtype.Timestamp
+}
+
+type Service struct {
+	ID              int32
+	Name            string
+	Description     pgtype.Text
+	DurationMinutes int32
+	Price           pgtype.Numeric
+	TaxRateID       pgtype.Int4
+	CreatedAt       pgtype.Timestamp
+	IsAlive         bool
+}
+
+type Shift struct {
+	ID         int32
+	EmployeeID int32
+	StoreID    int32
+	StartsAt   pgtype.Timestamp
+	EndsAt     pgtype.Timestamp
+	ClockInAt  pgtype.Timestamp
+	ClockOutAt pgtype.Timestamp
+	CreatedAt  pgtype.Timestamp
+	IsAlive    bool
+}
+
+type StockAdjustment struct {
+	ID             int32
+	GoodID         int32
+	StoreID        int32
+	Quantity       int32
+	Reason         string
+	StocktakeID    pgtype.Int4
+	WriteOffID     pgtype.Int4
+	GoodsReceiptID pgtype.Int4
+	EmployeeID     pgtype.Int4
+	Comment        pgtype.Text
+	CreatedAt      pgtype.Timestamp
+}
+
+type Stocktake struct {
+	ID         int32
+	StoreID    int32
+	CategoryID pgtype.Int4
+	Status     string
+	Comment    pgtype.Text
+	CreatedBy  int32
+	CreatedAt  pgtype.Timestamp
+	ApprovedBy pgtype.Int4
+	ApprovedAt pgtype.Timestamp
+}
+
+type StocktakeCount struct {
+	ID          int32
+	StocktakeID int32
+	GoodID      int32
+	EmployeeID  int32
+	Quantity    int32
+	CountedAt   pgtype.Timestamp
+}
+
+type StocktakeLine struct {
+	ID               int32
+	StocktakeID      int32
+	GoodID           int32
+	ExpectedQuantity int32
+}
+
 type Store struct {
 	ID        int32
 	Address   string
@@ -69,3 +429,66 @@ type Supplier struct {
 	CreatedAt pgtype.Timestamp
 	IsAlive   bool
 }
+
+type SupplierClaim struct {
+	ID              int32
+	SupplierID      int32
+	PurchaseOrderID int32
+	GoodsReceiptID  int32
+	Status          string
+	Amount          pgtype.Numeric
+	Resolution      pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	ResolvedAt      pgtype.Timestamp
+}
+
+type SupplierClaimItem struct {
+	ID              int32
+	SupplierClaimID int32
+	GoodID          int32
+	DamagedQuantity int32
+	MissingQuantity int32
+	UnitCost        pgtype.Numeric
+	Amount          pgtype.Numeric
+}
+
+type TaxRate struct {
+	ID        int32
+	Name      string
+	Rate      pgtype.Int4
+	CreatedAt pgtype.Timestamp
+	IsAlive   bool
+}
+
+type TechnicianBooking struct {
+	ID         int32
+	EmployeeID int32
+	OrderID    pgtype.Int4
+	ServiceID  pgtype.Int4
+	StartsAt   pgtype.Timestamp
+	EndsAt     pgtype.Timestamp
+	Address    pgtype.Text
+	Status     string
+	CreatedAt  pgtype.Timestamp
+}
+
+type WriteOff struct {
+	ID         int32
+	StoreID    int32
+	Reason     string
+	Status     string
+	Comment    pgtype.Text
+	CreatedBy  int32
+	CreatedAt  pgtype.Timestamp
+	ApprovedBy pgtype.Int4
+	ApprovedAt pgtype.Timestamp
+	TotalCost  pgtype.Numeric
+}
+
+type WriteOffItem struct {
+	ID         int32
+	WriteOffID int32
+	GoodID     int32
+	Quantity   int32
+	UnitCost   pgtype.Numeric
+}
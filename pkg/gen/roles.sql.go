@@ -9,6 +9,28 @@ import (
 	"context"
 )
 
+const countEmployeePermissions = `-- name: CountEmployeePermissions :one
+select count(*)::integer as granted
+from employees e
+         join role_permissions rp on rp.role_id = e.role_id
+where e.id = $1
+  and e.is_alive = true
+  and rp.permission = $2
+`
+
+type CountEmployeePermissionsParams struct {
+	ID         int32
+	Permission string
+}
+
+// Право действует, пока сотрудник работает и его роль его имеет
+func (q *Queries) CountEmployeePermissions(ctx context.Context, arg CountEmployeePermissionsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countEmployeePermissions, arg.ID, arg.Permission)
+	var granted int32
+	err := row.Scan(&granted)
+	return granted, err
+}
+
 const createRole = `-- name: CreateRole :one
 insert into roles (name, created_at) VALUES ($1, now()) returning id, name, created_at
 `
@@ -59,6 +81,70 @@ func (q *Queries) GetRoles(ctx context.Context) ([]Role, error) {
 	return items, nil
 }
 
+const grantRolePermission = `-- name: GrantRolePermission :one
+insert into role_permissions (role_id, permission, created_at) VALUES ($1, $2, now())
+on conflict (role_id, permission) do update set permission = excluded.permission
+returning role_id, permission, created_at
+`
+
+type GrantRolePermissionParams struct {
+	RoleID     int32
+	Permission string
+}
+
+func (q *Queries) GrantRolePermission(ctx context.Context, arg GrantRolePermissionParams) (RolePermission, error) {
+	row := q.db.QueryRow(ctx, grantRolePermission, arg.RoleID, arg.Permission)
+	var i RolePermission
+	err := row.Scan(&i.RoleID, &i.Permission, &i.CreatedAt)
+	return i, err
+}
+
+const listRolePermissions = `-- name: ListRolePermissions :many
+select role_id, permission, created_at
+from role_permissions
+where role_id = $1
+order by permission
+`
+
+func (q *Queries) ListRolePermissions(ctx context.Context, roleID int32) ([]RolePermission, error) {
+	rows, err := q.db.Query(ctx, listRolePermissions, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RolePermission
+	for rows.Next() {
+		var i RolePermission
+		if err := rows.Scan(&i.RoleID, &i.Permission, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeRolePermission = `-- name: RevokeRolePermission :execrows
+delete from role_permissions
+where role_id = $1
+  and permission = $2
+`
+
+type RevokeRolePermissionParams struct {
+	RoleID     int32
+	Permission string
+}
+
+func (q *Queries) RevokeRolePermission(ctx context.Context, arg RevokeRolePermissionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRolePermission, arg.RoleID, arg.Permission)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateRole = `-- name: UpdateRole :one
 update roles
 set name = $2
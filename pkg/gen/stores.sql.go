@@ -47,6 +47,47 @@ func (q *Queries) DeleteStore(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportStores = `-- name: ExportStores :many
+select id, address, created_at, updated_at, is_alive
+from stores
+where is_alive = true
+  and id > $1
+order by id
+limit $2
+`
+
+type ExportStoresParams struct {
+	ID    int32
+	Limit int32
+}
+
+// Страница выгрузки магазинов после магазина с id = $1
+func (q *Queries) ExportStores(ctx context.Context, arg ExportStoresParams) ([]Store, error) {
+	rows, err := q.db.Query(ctx, exportStores, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Store
+	for rows.Next() {
+		var i Store
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsAlive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStore = `-- name: GetStore :one
 select id, address, created_at, updated_at, is_alive
 from stores
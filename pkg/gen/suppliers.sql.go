@@ -52,6 +52,57 @@ func (q *Queries) DeleteSupplier(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportSuppliers = `-- name: ExportSuppliers :many
+SELECT s.id, s.account_id, s.created_at, s.is_alive,
+       a.login as account_login
+FROM Suppliers s
+         JOIN Accounts a ON s.account_id = a.id
+WHERE s.is_alive = true
+  AND s.id > $1
+ORDER BY s.id
+LIMIT $2
+`
+
+type ExportSuppliersParams struct {
+	ID    int32
+	Limit int32
+}
+
+type ExportSuppliersRow struct {
+	ID           int32
+	AccountID    int32
+	CreatedAt    pgtype.Timestamp
+	IsAlive      bool
+	AccountLogin string
+}
+
+// Страница выгрузки поставщиков после поставщика с id = $1
+func (q *Queries) ExportSuppliers(ctx context.Context, arg ExportSuppliersParams) ([]ExportSuppliersRow, error) {
+	rows, err := q.db.Query(ctx, exportSuppliers, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportSuppliersRow
+	for rows.Next() {
+		var i ExportSuppliersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.CreatedAt,
+			&i.IsAlive,
+			&i.AccountLogin,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSupplier = `-- name: GetSupplier :one
 SELECT s.id, s.account_id, s.created_at, s.is_alive,
        a.login as account_login,
@@ -89,6 +140,133 @@ func (q *Queries) GetSupplier(ctx context.Context, id int32) (GetSupplierRow, er
 	return i, err
 }
 
+const getSupplierClaimTotals = `-- name: GetSupplierClaimTotals :one
+SELECT COUNT(*)::integer                  AS claims,
+       COALESCE(SUM(c.amount), 0)::decimal AS amount
+FROM Supplier_Claims c
+         JOIN Purchase_Orders po ON po.id = c.purchase_order_id
+WHERE c.supplier_id = $1
+  AND po.sent_at >= $2
+  AND po.sent_at < $3
+`
+
+type GetSupplierClaimTotalsParams struct {
+	SupplierID int32
+	DateFrom   pgtype.Timestamp
+	DateTo     pgtype.Timestamp
+}
+
+type GetSupplierClaimTotalsRow struct {
+	Claims int32
+	Amount pgtype.Numeric
+}
+
+// Претензии поставщику по заказам, отправленным за период
+func (q *Queries) GetSupplierClaimTotals(ctx context.Context, arg GetSupplierClaimTotalsParams) (GetSupplierClaimTotalsRow, error) {
+	row := q.db.QueryRow(ctx, getSupplierClaimTotals, arg.SupplierID, arg.DateFrom, arg.DateTo)
+	var i GetSupplierClaimTotalsRow
+	err := row.Scan(&i.Claims, &i.Amount)
+	return i, err
+}
+
+const getSupplierReturnedQuantity = `-- name: GetSupplierReturnedQuantity :one
+SELECT COALESCE(SUM(ri.quantity), 0)::bigint AS returned
+FROM Refund_Items ri
+         JOIN Refunds r ON r.id = ri.refund_id
+WHERE r.created_at >= $1
+  AND r.created_at < $2
+  AND ri.good_id IN (SELECT gri.good_id
+                     FROM Goods_Receipt_Items gri
+                              JOIN Goods_Receipts gr ON gr.id = gri.goods_receipt_id
+                              JOIN Purchase_Orders po ON po.id = gr.purchase_order_id
+                     WHERE po.supplier_id = $3
+                       AND po.sent_at >= $1
+                       AND po.sent_at < $2)
+`
+
+type GetSupplierReturnedQuantityParams struct {
+	DateFrom   pgtype.Timestamp
+	DateTo     pgtype.Timestamp
+	SupplierID int32
+}
+
+// Количество возвращённого покупателями за период товара, который поставщик привозил по заказам, отправленным за тот же период
+func (q *Queries) GetSupplierReturnedQuantity(ctx context.Context, arg GetSupplierReturnedQuantityParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getSupplierReturnedQuantity, arg.DateFrom, arg.DateTo, arg.SupplierID)
+	var returned int64
+	err := row.Scan(&returned)
+	return returned, err
+}
+
+const listSupplierOrderPerformance = `-- name: ListSupplierOrderPerformance :many
+SELECT po.id,
+       po.sent_at,
+       po.expected_at,
+       (SELECT COALESCE(SUM(poi.quantity), 0)
+        FROM Purchase_Order_Items poi
+        WHERE poi.purchase_order_id = po.id)::bigint              AS ordered,
+       COALESCE(SUM(ri.received_quantity), 0)::bigint            AS received,
+       COALESCE(SUM(ri.damaged_quantity), 0)::bigint             AS damaged,
+       COALESCE(SUM(ri.accepted_quantity), 0)::bigint            AS accepted,
+       (CASE WHEN po.status = 'received' THEN MAX(r.created_at) END)::timestamp AS completed_at
+FROM Purchase_Orders po
+         LEFT JOIN Goods_Receipts r ON r.purchase_order_id = po.id
+         LEFT JOIN Goods_Receipt_Items ri ON ri.goods_receipt_id = r.id
+WHERE po.supplier_id = $1
+  AND po.status <> 'cancelled'
+  AND po.sent_at >= $2
+  AND po.sent_at < $3
+GROUP BY po.id
+ORDER BY po.sent_at
+`
+
+type ListSupplierOrderPerformanceParams struct {
+	SupplierID int32
+	DateFrom   pgtype.Timestamp
+	DateTo     pgtype.Timestamp
+}
+
+type ListSupplierOrderPerformanceRow struct {
+	ID          int32
+	SentAt      pgtype.Timestamp
+	ExpectedAt  pgtype.Timestamp
+	Ordered     int64
+	Received    int64
+	Damaged     int64
+	Accepted    int64
+	CompletedAt pgtype.Timestamp
+}
+
+// Отправленные поставщику заказы за период с итогами приёмок: заказано, привезено, брак и дата последней приёмки закрытого (received) заказа
+func (q *Queries) ListSupplierOrderPerformance(ctx context.Context, arg ListSupplierOrderPerformanceParams) ([]ListSupplierOrderPerformanceRow, error) {
+	rows, err := q.db.Query(ctx, listSupplierOrderPerformance, arg.SupplierID, arg.DateFrom, arg.DateTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSupplierOrderPerformanceRow
+	for rows.Next() {
+		var i ListSupplierOrderPerformanceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SentAt,
+			&i.ExpectedAt,
+			&i.Ordered,
+			&i.Received,
+			&i.Damaged,
+			&i.Accepted,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSuppliers = `-- name: ListSuppliers :many
 SELECT s.id, s.account_id, s.created_at, s.is_alive,
        a.login as account_login,
@@ -0,0 +1,29 @@
+-- name: CreateCategory :one
+INSERT INTO Categories (name, tax_rate_id, created_at, is_alive)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCategory :one
+SELECT *
+FROM Categories
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListCategories :many
+SELECT *
+FROM Categories
+WHERE is_alive = true
+ORDER BY name;
+
+-- name: UpdateCategory :one
+UPDATE Categories
+SET name        = $2,
+    tax_rate_id = $3,
+    is_alive    = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCategory :exec
+UPDATE Categories
+SET is_alive = false
+WHERE id = $1;
@@ -0,0 +1,80 @@
+-- name: CreateCommissionRule :one
+INSERT INTO Commission_Rules (role_id, category_id, rate_bps, created_at, is_alive)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetCommissionRule :one
+SELECT *
+FROM Commission_Rules
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListCommissionRules :many
+SELECT *
+FROM Commission_Rules
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: UpdateCommissionRule :one
+UPDATE Commission_Rules
+SET role_id     = $2,
+    category_id = $3,
+    rate_bps    = $4,
+    is_alive    = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCommissionRule :exec
+UPDATE Commission_Rules
+SET is_alive = false
+WHERE id = $1;
+
+-- name: ListEmployeeSaleLines :many
+-- Строки оплаченных заказов продавца: товары с категорией и услуги без категории
+SELECT oi.order_id, g.category_id, oi.quantity, oi.price
+FROM Order_Items oi
+         JOIN Orders o ON o.id = oi.order_id
+         JOIN Goods g ON g.id = oi.good_id
+WHERE o.employee_id = sqlc.arg(employee_id)
+  AND o.status = 'paid'
+  AND o.created_at >= sqlc.arg(date_from)
+  AND o.created_at < sqlc.arg(date_to)
+UNION ALL
+SELECT os.order_id, NULL::integer AS category_id, os.quantity, os.price
+FROM Order_Services os
+         JOIN Orders o ON o.id = os.order_id
+WHERE o.employee_id = sqlc.arg(employee_id)
+  AND o.status = 'paid'
+  AND o.created_at >= sqlc.arg(date_from)
+  AND o.created_at < sqlc.arg(date_to);
+
+-- name: ListOrderSaleLines :many
+SELECT oi.order_id, g.category_id, oi.quantity, oi.price
+FROM Order_Items oi
+         JOIN Goods g ON g.id = oi.good_id
+WHERE oi.order_id = sqlc.arg(order_id)
+UNION ALL
+SELECT os.order_id, NULL::integer AS category_id, os.quantity, os.price
+FROM Order_Services os
+WHERE os.order_id = sqlc.arg(order_id);
+
+-- name: ListEmployeeRefundLines :many
+-- Возвращённые товары по заказам продавца, оформленные в периоде
+SELECT r.order_id, g.category_id, ri.quantity, ri.price
+FROM Refund_Items ri
+         JOIN Refunds r ON r.id = ri.refund_id
+         JOIN Orders o ON o.id = r.order_id
+         JOIN Goods g ON g.id = ri.good_id
+WHERE o.employee_id = sqlc.arg(employee_id)
+  AND r.created_at >= sqlc.arg(date_from)
+  AND r.created_at < sqlc.arg(date_to);
+
+-- name: ListEmployeeAmountRefunds :many
+-- Возвраты суммой без товарных строк по заказам продавца, оформленные в периоде
+SELECT r.id, r.order_id, r.amount, o.total AS order_total
+FROM Refunds r
+         JOIN Orders o ON o.id = r.order_id
+WHERE o.employee_id = sqlc.arg(employee_id)
+  AND r.created_at >= sqlc.arg(date_from)
+  AND r.created_at < sqlc.arg(date_to)
+  AND NOT EXISTS (SELECT 1 FROM Refund_Items ri WHERE ri.refund_id = r.id);
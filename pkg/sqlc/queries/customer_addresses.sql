@@ -0,0 +1,51 @@
+-- name: CreateCustomerAddress :one
+INSERT INTO Customer_Addresses (customer_id, label, city, street, apartment, floor, has_elevator, postal_code,
+                                is_default, created_at, is_alive)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetCustomerAddress :one
+SELECT *
+FROM Customer_Addresses
+WHERE id = $1
+  AND customer_id = $2
+  AND is_alive = true
+LIMIT 1;
+
+-- name: ListCustomerAddresses :many
+-- Адрес по умолчанию первым
+SELECT *
+FROM Customer_Addresses
+WHERE customer_id = $1
+  AND is_alive = true
+ORDER BY is_default DESC, id;
+
+-- name: UpdateCustomerAddress :one
+UPDATE Customer_Addresses
+SET label        = $2,
+    city         = $3,
+    street       = $4,
+    apartment    = $5,
+    floor        = $6,
+    has_elevator = $7,
+    postal_code  = $8
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCustomerAddress :exec
+UPDATE Customer_Addresses
+SET is_alive   = false,
+    is_default = false
+WHERE id = $1;
+
+-- name: ClearDefaultCustomerAddress :exec
+UPDATE Customer_Addresses
+SET is_default = false
+WHERE customer_id = $1
+  AND is_default = true;
+
+-- name: SetDefaultCustomerAddress :one
+UPDATE Customer_Addresses
+SET is_default = true
+WHERE id = $1
+RETURNING *;
@@ -1,6 +1,6 @@
 -- name: CreateCustomer :one
-INSERT INTO Customers (account_id, balance, created_at, is_alive)
-VALUES ($1, $2, $3, $4)
+INSERT INTO Customers (account_id, balance, created_at, is_alive, phone, email)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetCustomer :one
@@ -30,11 +30,37 @@ ORDER BY c.id;
 -- name: UpdateCustomer :one
 UPDATE Customers
 SET balance  = $2,
-    is_alive = $3
+    is_alive = $3,
+    phone    = $4,
+    email    = $5
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteCustomer :exec
 UPDATE Customers
 SET is_alive = false
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetCustomerBalanceForUpdate :one
+SELECT balance
+FROM Customers
+WHERE id = $1
+  AND is_alive = true
+LIMIT 1
+FOR UPDATE;
+
+-- name: AddCustomerBalance :exec
+UPDATE Customers
+SET balance = balance + $2
+WHERE id = $1;
+
+-- name: ExportCustomers :many
+-- Страница выгрузки покупателей после покупателя с id = $1
+SELECT c.*,
+       a.login as account_login
+FROM Customers c
+         JOIN Accounts a ON c.account_id = a.id
+WHERE c.is_alive = true
+  AND c.id > $1
+ORDER BY c.id
+LIMIT $2;
\ No newline at end of file
@@ -0,0 +1,84 @@
+-- name: CreateDeliverySlot :one
+INSERT INTO Delivery_Slots (store_id, starts_at, ends_at, capacity, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetDeliverySlotForUpdate :one
+-- Блокируем слот, чтобы два бронирования не заняли последнее место одновременно
+SELECT *
+FROM Delivery_Slots
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListDeliverySlots :many
+SELECT s.*,
+       COUNT(d.id)::integer AS booked
+FROM Delivery_Slots s
+         LEFT JOIN Deliveries d ON d.slot_id = s.id AND d.status <> 'failed'
+WHERE s.store_id = sqlc.arg(store_id)
+  AND s.starts_at >= sqlc.arg(date_from)
+  AND s.starts_at < sqlc.arg(date_to)
+GROUP BY s.id
+ORDER BY s.starts_at;
+
+-- name: CountBookedDeliveries :one
+SELECT COUNT(*)::integer AS booked
+FROM Deliveries
+WHERE slot_id = $1
+  AND status <> 'failed';
+
+-- name: CreateDelivery :one
+INSERT INTO Deliveries (order_id, slot_id, address, comment, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetDelivery :one
+SELECT *
+FROM Deliveries
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetDeliveryForUpdate :one
+SELECT *
+FROM Deliveries
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListDeliveries :many
+SELECT *
+FROM Deliveries
+ORDER BY id DESC;
+
+-- name: ListDeliveriesByOrder :many
+SELECT *
+FROM Deliveries
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: AssignDeliveryCourier :one
+UPDATE Deliveries
+SET courier_id = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateDeliveryStatus :one
+UPDATE Deliveries
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListCourierRoute :many
+-- Доставки курьера за день в порядке слотов
+SELECT d.*,
+       s.starts_at AS slot_starts_at,
+       s.ends_at   AS slot_ends_at
+FROM Deliveries d
+         JOIN Delivery_Slots s ON s.id = d.slot_id
+WHERE d.courier_id = sqlc.arg(courier_id)
+  AND s.starts_at >= sqlc.arg(date_from)
+  AND s.starts_at < sqlc.arg(date_to)
+ORDER BY s.starts_at, d.id;
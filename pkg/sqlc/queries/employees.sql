@@ -18,11 +18,19 @@ SELECT
 
     r.id as id_role,
     r.name as role_name,
-    r.created_at as role_created_at
+    r.created_at as role_created_at,
+
+    cs.store_id as current_store_id,
+    s.address as current_store_address,
+    cs.starts_on as current_store_since
 
 FROM Employees e
          JOIN Accounts a ON e.account_id = a.id
          JOIN Roles r ON e.role_id = r.id
+         LEFT JOIN Employee_Stores cs ON cs.employee_id = e.id
+    AND cs.starts_on <= current_date
+    AND (cs.ends_on IS NULL OR cs.ends_on > current_date)
+         LEFT JOIN Stores s ON s.id = cs.store_id
 WHERE e.id = $1
 LIMIT 1;
 
@@ -41,11 +49,19 @@ SELECT
 
     r.id as id_role,
     r.name as role_name,
-    r.created_at as role_created_at
+    r.created_at as role_created_at,
+
+    cs.store_id as current_store_id,
+    s.address as current_store_address,
+    cs.starts_on as current_store_since
 
 FROM Employees e
          JOIN Accounts a ON e.account_id = a.id
          JOIN Roles r ON e.role_id = r.id
+         LEFT JOIN Employee_Stores cs ON cs.employee_id = e.id
+    AND cs.starts_on <= current_date
+    AND (cs.ends_on IS NULL OR cs.ends_on > current_date)
+         LEFT JOIN Stores s ON s.id = cs.store_id
 WHERE e.is_alive = true
 ORDER BY e.id;
 
@@ -59,4 +75,67 @@ RETURNING *;
 -- name: DeleteEmployee :exec
 UPDATE Employees
 SET is_alive = false
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: CreateEmployeeStore :one
+INSERT INTO Employee_Stores (employee_id, store_id, starts_on, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCurrentEmployeeStoreForUpdate :one
+-- Текущее назначение — единственное без даты окончания
+SELECT *
+FROM Employee_Stores
+WHERE employee_id = $1
+  AND ends_on IS NULL
+LIMIT 1
+FOR UPDATE;
+
+-- name: CloseEmployeeStore :one
+UPDATE Employee_Stores
+SET ends_on = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteEmployeeStore :exec
+DELETE
+FROM Employee_Stores
+WHERE id = $1;
+
+-- name: ListEmployeeStores :many
+SELECT es.*, s.address as store_address
+FROM Employee_Stores es
+         JOIN Stores s ON s.id = es.store_id
+WHERE es.employee_id = $1
+ORDER BY es.starts_on DESC, es.id DESC;
+
+-- name: ListStoreEmployees :many
+-- Сотрудники, работавшие в магазине в указанный день: начало включительно, окончание не включительно
+SELECT
+    e.id,
+    e.account_id,
+    e.role_id,
+    e.created_at,
+    e.is_alive,
+
+    a.id as id_account,
+    a.login as account_login,
+    a.created_at as account_created_at,
+    a.is_alive as account_is_alive,
+
+    r.id as id_role,
+    r.name as role_name,
+    r.created_at as role_created_at,
+
+    es.starts_on as assigned_from,
+    es.ends_on as assigned_to
+
+FROM Employee_Stores es
+         JOIN Employees e ON e.id = es.employee_id
+         JOIN Accounts a ON e.account_id = a.id
+         JOIN Roles r ON e.role_id = r.id
+WHERE es.store_id = sqlc.arg(store_id)
+  AND es.starts_on <= sqlc.arg(on_date)
+  AND (es.ends_on IS NULL OR es.ends_on > sqlc.arg(on_date))
+  AND e.is_alive = true
+ORDER BY e.id;
@@ -0,0 +1,89 @@
+-- name: GetEmployeeByCredentials :one
+-- Сотрудник, под учётной записью которого 1С подключается к обмену
+SELECT e.id
+FROM Employees e
+         JOIN Accounts a ON a.id = e.account_id
+WHERE a.login = $1
+  AND a.password = $2
+  AND a.is_alive = true
+  AND e.is_alive = true
+LIMIT 1;
+
+-- name: ListExchangeCategories :many
+SELECT id, name, external_id
+FROM Categories
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: CreateExchangeCategory :one
+INSERT INTO Categories (name, created_at, is_alive, external_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: UpdateExchangeCategory :exec
+UPDATE Categories
+SET name        = $2,
+    external_id = $3
+WHERE id = $1;
+
+-- name: ListExchangeGoods :many
+SELECT id, article, external_id
+FROM Goods
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: CreateExchangeGood :one
+INSERT INTO Goods (article, price, name, quantity, is_alive, category_id, brand, external_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id;
+
+-- name: UpdateExchangeGood :exec
+-- Товар без группы или изготовителя в 1С сохраняет свои категорию и бренд
+UPDATE Goods
+SET article     = $2,
+    name        = $3,
+    category_id = COALESCE($4, category_id),
+    brand       = COALESCE($5, brand),
+    external_id = $6
+WHERE id = $1;
+
+-- name: UpdateGoodPrice :exec
+UPDATE Goods
+SET price = $2
+WHERE id = $1;
+
+-- name: ListExchangeOrders :many
+-- Оплаченные заказы, ещё не принятые 1С
+SELECT *
+FROM Orders
+WHERE status = $1
+  AND exchanged_at IS NULL
+ORDER BY id
+LIMIT $2;
+
+-- name: ListExchangeOrderItems :many
+SELECT oi.good_id,
+       oi.quantity,
+       oi.price,
+       g.article,
+       g.name,
+       g.external_id
+FROM Order_Items oi
+         JOIN Goods g ON g.id = oi.good_id
+WHERE oi.order_id = $1
+ORDER BY oi.id;
+
+-- name: ListExchangeOrderServices :many
+SELECT os.service_id,
+       os.quantity,
+       os.price,
+       s.name
+FROM Order_Services os
+         JOIN Services s ON s.id = os.service_id
+WHERE os.order_id = $1
+ORDER BY os.id;
+
+-- name: MarkOrdersExchanged :exec
+UPDATE Orders
+SET exchanged_at = $2
+WHERE id = ANY ($1::integer[]);
\ No newline at end of file
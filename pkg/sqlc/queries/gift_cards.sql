@@ -0,0 +1,30 @@
+-- name: CreateGiftCard :one
+INSERT INTO Gift_Cards (code, balance, created_at, is_alive)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetGiftCardByCode :one
+SELECT *
+FROM Gift_Cards
+WHERE code = $1
+LIMIT 1;
+
+-- name: GetGiftCardByCodeForUpdate :one
+SELECT *
+FROM Gift_Cards
+WHERE code = $1
+  AND is_alive = true
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListGiftCards :many
+SELECT *
+FROM Gift_Cards
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: AddGiftCardBalance :one
+UPDATE Gift_Cards
+SET balance = balance + $2
+WHERE id = $1
+RETURNING *;
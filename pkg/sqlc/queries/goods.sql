@@ -1,11 +1,11 @@
 -- name: CreateGood :one
-INSERT INTO Goods (article, price, name, quantity, is_alive)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO Goods (article, price, name, quantity, is_alive, category_id, tax_rate_id, cost, brand)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: CreateManyGoods :copyfrom
-INSERT INTO Goods (article, price, name, quantity, is_alive)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO Goods (article, price, name, quantity, is_alive, category_id, tax_rate_id, brand)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetGood :one
 SELECT *
@@ -20,16 +20,88 @@ WHERE is_alive = true
 ORDER BY name;
 
 -- name: UpdateGood :one
+-- Остаток и себестоимость меняются только документами: приёмкой, продажей, списанием, инвентаризацией
 UPDATE Goods
-SET article  = $2,
-    price    = $3,
-    name     = $4,
-    quantity = $5,
-    is_alive = $6
+SET article     = $2,
+    price       = $3,
+    name        = $4,
+    is_alive    = $5,
+    category_id = $6,
+    tax_rate_id = $7,
+    brand       = $8
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteGood :exec
 UPDATE Goods
 SET is_alive = false
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetGoodForUpdate :one
+-- Блокируем строку товара до конца транзакции
+SELECT *
+FROM Goods
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: DecreaseGoodQuantity :one
+UPDATE Goods
+SET quantity = quantity - $2
+WHERE id = $1
+RETURNING *;
+
+-- name: IncreaseGoodQuantity :one
+UPDATE Goods
+SET quantity = quantity + $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetGoodTaxRate :one
+-- Ставка НДС товара: своя, а если не задана или удалена — ставка его категории
+SELECT tr.*
+FROM Goods g
+         LEFT JOIN Categories c ON c.id = g.category_id
+         LEFT JOIN Tax_Rates gr ON gr.id = g.tax_rate_id AND gr.is_alive = true
+         JOIN Tax_Rates tr ON tr.id = COALESCE(gr.id, c.tax_rate_id) AND tr.is_alive = true
+WHERE g.id = $1
+LIMIT 1;
+
+-- name: UpdateGoodCost :exec
+UPDATE Goods
+SET cost = $2
+WHERE id = $1;
+
+-- name: CreateGoodCost :one
+INSERT INTO Good_Costs (good_id, cost, goods_receipt_id, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListGoodArticles :many
+-- Артикулы товаров для сопоставления строк импорта
+SELECT id, article
+FROM Goods
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: UpdateManyGoods :exec
+-- Обновляет пачку товаров из импорта. Колонки, которых нет в файле, не меняются
+UPDATE Goods g
+SET name        = CASE WHEN sqlc.arg(set_name)::bool THEN u.name ELSE g.name END,
+    price       = CASE WHEN sqlc.arg(set_price)::bool THEN u.price ELSE g.price END,
+    category_id = CASE WHEN sqlc.arg(set_category)::bool THEN u.category_id ELSE g.category_id END,
+    tax_rate_id = CASE WHEN sqlc.arg(set_tax_rate)::bool THEN u.tax_rate_id ELSE g.tax_rate_id END,
+    brand       = CASE WHEN sqlc.arg(set_brand)::bool THEN u.brand ELSE g.brand END
+FROM unnest(sqlc.arg(ids)::integer[], sqlc.arg(names)::text[], sqlc.arg(prices)::decimal[],
+            sqlc.arg(category_ids)::integer[], sqlc.arg(tax_rate_ids)::integer[],
+            sqlc.arg(brands)::text[]) AS u(id, name, price, category_id, tax_rate_id, brand)
+WHERE g.id = u.id;
+
+-- name: ExportGoods :many
+-- Страница выгрузки товаров после товара с id = $1
+SELECT *
+FROM Goods
+WHERE is_alive = true
+  AND id > $1
+ORDER BY id
+LIMIT $2;
\ No newline at end of file
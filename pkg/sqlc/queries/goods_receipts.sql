@@ -0,0 +1,87 @@
+-- name: CreateGoodsReceipt :one
+INSERT INTO Goods_Receipts (purchase_order_id, store_id, employee_id, is_final, comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetGoodsReceipt :one
+SELECT *
+FROM Goods_Receipts
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListGoodsReceipts :many
+SELECT *
+FROM Goods_Receipts
+WHERE (sqlc.narg(purchase_order_id)::integer IS NULL OR purchase_order_id = sqlc.narg(purchase_order_id)::integer)
+ORDER BY created_at DESC;
+
+-- name: CreateGoodsReceiptItem :one
+INSERT INTO Goods_Receipt_Items (goods_receipt_id, purchase_order_item_id, good_id, expected_quantity,
+                                 received_quantity, damaged_quantity, accepted_quantity, missing_quantity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListGoodsReceiptItems :many
+SELECT *
+FROM Goods_Receipt_Items
+WHERE goods_receipt_id = $1
+ORDER BY id;
+
+-- name: ListReceivedQuantities :many
+-- Сколько уже привезено по каждой строке заказа поставщику
+SELECT ri.purchase_order_item_id,
+       SUM(ri.received_quantity)::integer AS received
+FROM Goods_Receipt_Items ri
+         JOIN Goods_Receipts r ON r.id = ri.goods_receipt_id
+WHERE r.purchase_order_id = $1
+GROUP BY ri.purchase_order_item_id;
+
+-- name: CreateSupplierClaim :one
+INSERT INTO Supplier_Claims (supplier_id, purchase_order_id, goods_receipt_id, status, amount, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CreateSupplierClaimItem :one
+INSERT INTO Supplier_Claim_Items (supplier_claim_id, good_id, damaged_quantity, missing_quantity, unit_cost, amount)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetSupplierClaim :one
+SELECT *
+FROM Supplier_Claims
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetSupplierClaimForUpdate :one
+SELECT *
+FROM Supplier_Claims
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetGoodsReceiptClaim :one
+SELECT *
+FROM Supplier_Claims
+WHERE goods_receipt_id = $1
+LIMIT 1;
+
+-- name: ListSupplierClaims :many
+SELECT *
+FROM Supplier_Claims
+WHERE (sqlc.narg(supplier_id)::integer IS NULL OR supplier_id = sqlc.narg(supplier_id)::integer)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC;
+
+-- name: ListSupplierClaimItems :many
+SELECT *
+FROM Supplier_Claim_Items
+WHERE supplier_claim_id = $1
+ORDER BY id;
+
+-- name: ResolveSupplierClaim :one
+UPDATE Supplier_Claims
+SET status      = $2,
+    resolution  = $3,
+    resolved_at = $4
+WHERE id = $1
+RETURNING *;
@@ -1,11 +1,11 @@
 -- name: CreateGoodsSupplier :one
-INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
     RETURNING *;
 
 -- name: CreateGoodsSuppliers :one
-INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO Goods_Suppliers (id, supplier_id, good_id, created_at, is_alive, purchase_price, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
     RETURNING *;
 
 -- name: GetGoodsSupplier :one
@@ -0,0 +1,82 @@
+-- name: CreateOrder :one
+INSERT INTO Orders (customer_id, store_id, status, payment_status, fiscal_status, total, created_at, employee_id,
+                    register_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT *
+FROM Orders
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetOrderForUpdate :one
+SELECT *
+FROM Orders
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListOrders :many
+SELECT *
+FROM Orders
+ORDER BY id DESC;
+
+-- name: UpdateOrderTotal :one
+UPDATE Orders
+SET total      = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrderStatus :one
+UPDATE Orders
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrderPaymentStatus :one
+UPDATE Orders
+SET payment_status = $2,
+    updated_at     = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateOrderFiscal :one
+UPDATE Orders
+SET fiscal_status = $2,
+    fiscal_sign   = $3,
+    updated_at    = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateOrderItem :one
+INSERT INTO Order_Items (order_id, good_id, quantity, price, vat_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListOrderItems :many
+SELECT *
+FROM Order_Items
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: CreateOrderService :one
+INSERT INTO Order_Services (order_id, service_id, quantity, price, vat_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListOrderServices :many
+SELECT *
+FROM Order_Services
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: SetOrderItemCosts :exec
+-- Фиксирует себестоимость проданных товаров по текущей средней себестоимости
+UPDATE Order_Items oi
+SET unit_cost = g.cost
+FROM Goods g
+WHERE g.id = oi.good_id
+  AND oi.order_id = $1;
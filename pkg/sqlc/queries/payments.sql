@@ -0,0 +1,59 @@
+-- name: CreatePayment :one
+INSERT INTO Payments (order_id, tender, provider, external_id, gift_card_id, amount, refunded_amount, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetPayment :one
+SELECT *
+FROM Payments
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPaymentForUpdate :one
+SELECT *
+FROM Payments
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetPaymentByExternalID :one
+SELECT *
+FROM Payments
+WHERE provider = $1
+  AND external_id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListPaymentsByOrder :many
+SELECT *
+FROM Payments
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: UpdatePaymentStatus :one
+UPDATE Payments
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: AddPaymentRefund :one
+UPDATE Payments
+SET refunded_amount = refunded_amount + $2,
+    status          = $3,
+    updated_at      = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: PaymentTenderReport :many
+-- Итоги по способам оплаты за период
+SELECT tender,
+       COUNT(*)::bigint                          AS payments,
+       COALESCE(SUM(amount), 0)::bigint          AS amount,
+       COALESCE(SUM(refunded_amount), 0)::bigint AS refunded
+FROM Payments
+WHERE status <> 'failed'
+  AND created_at >= sqlc.arg(date_from)
+  AND created_at < sqlc.arg(date_to)
+GROUP BY tender
+ORDER BY tender;
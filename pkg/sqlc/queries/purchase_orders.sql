@@ -0,0 +1,49 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO Purchase_Orders (supplier_id, store_id, status, comment, created_by, created_at, expected_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT *
+FROM Purchase_Orders
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT *
+FROM Purchase_Orders
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT *
+FROM Purchase_Orders
+WHERE (sqlc.narg(supplier_id)::integer IS NULL OR supplier_id = sqlc.narg(supplier_id)::integer)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC;
+
+-- name: SendPurchaseOrder :one
+UPDATE Purchase_Orders
+SET status      = $2,
+    sent_at     = $3,
+    expected_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE Purchase_Orders
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO Purchase_Order_Items (purchase_order_id, good_id, quantity, unit_cost)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListPurchaseOrderItems :many
+SELECT *
+FROM Purchase_Order_Items
+WHERE purchase_order_id = $1
+ORDER BY id;
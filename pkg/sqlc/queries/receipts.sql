@@ -0,0 +1,58 @@
+-- name: CreateReceipt :one
+INSERT INTO Receipts (order_id, refund_id, calculation_type, total, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetReceipt :one
+SELECT *
+FROM Receipts
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetReceiptForUpdate :one
+SELECT *
+FROM Receipts
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListReceiptsByOrder :many
+SELECT *
+FROM Receipts
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: ListPendingReceipts :many
+-- Очередь на регистрацию, включая чеки, ответ кассы по которым не записан
+SELECT *
+FROM Receipts
+WHERE status IN ('pending', 'registering')
+ORDER BY id;
+
+-- name: MarkReceiptRegistering :one
+UPDATE Receipts
+SET status   = 'registering',
+    document = $2,
+    error    = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkReceiptRegistered :one
+UPDATE Receipts
+SET status                 = $2,
+    document               = $3,
+    fiscal_document_number = $4,
+    fiscal_sign            = $5,
+    fn_number              = $6,
+    error                  = NULL,
+    registered_at          = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkReceiptFailed :one
+UPDATE Receipts
+SET status   = $2,
+    document = $3,
+    error    = $4
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,60 @@
+-- name: CreateRefund :one
+INSERT INTO Refunds (order_id, amount, created_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateRefundItem :one
+INSERT INTO Refund_Items (refund_id, good_id, quantity, price, vat_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreateRefundTender :one
+INSERT INTO Refund_Tenders (refund_id, payment_id, tender, amount, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListPendingRefundTenders :many
+-- Возвраты на карту, которые ещё не подтвердил эквайер
+SELECT rt.id, rt.amount, p.external_id
+FROM Refund_Tenders rt
+         JOIN Payments p ON p.id = rt.payment_id
+WHERE rt.status = 'pending'
+ORDER BY rt.id;
+
+-- name: CompleteRefundTender :exec
+UPDATE Refund_Tenders
+SET status = 'completed'
+WHERE id = $1;
+
+-- name: GetRefund :one
+SELECT *
+FROM Refunds
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListRefundsByOrder :many
+SELECT *
+FROM Refunds
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: ListRefundItems :many
+SELECT *
+FROM Refund_Items
+WHERE refund_id = $1
+ORDER BY id;
+
+-- name: ListRefundTenders :many
+SELECT *
+FROM Refund_Tenders
+WHERE refund_id = $1
+ORDER BY id;
+
+-- name: ListRefundedQuantities :many
+-- Сколько единиц каждого товара заказа уже вернули
+SELECT ri.good_id,
+       COALESCE(SUM(ri.quantity), 0)::integer AS quantity
+FROM Refund_Items ri
+         JOIN Refunds r ON r.id = ri.refund_id
+WHERE r.order_id = $1
+GROUP BY ri.good_id;
@@ -0,0 +1,113 @@
+-- name: CreateRegister :one
+INSERT INTO Registers (store_id, name, created_at, is_alive)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRegister :one
+SELECT *
+FROM Registers
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListRegisters :many
+SELECT *
+FROM Registers
+WHERE is_alive = true
+ORDER BY store_id, id;
+
+-- name: ListStoreRegisters :many
+SELECT *
+FROM Registers
+WHERE store_id = $1
+  AND is_alive = true
+ORDER BY id;
+
+-- name: CreateRegisterSession :one
+INSERT INTO Register_Sessions (register_id, opened_by, opened_at, opening_cash, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetRegisterSession :one
+SELECT *
+FROM Register_Sessions
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetRegisterSessionForUpdate :one
+SELECT *
+FROM Register_Sessions
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetOpenRegisterSession :one
+SELECT *
+FROM Register_Sessions
+WHERE register_id = $1
+  AND status = 'open'
+LIMIT 1;
+
+-- name: GetOpenRegisterSessionForUpdate :one
+-- Блокировка открытой смены кассы не даёт закрыть её, пока проводится продажа или возврат
+SELECT *
+FROM Register_Sessions
+WHERE register_id = $1
+  AND status = 'open'
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListRegisterSessions :many
+SELECT *
+FROM Register_Sessions
+WHERE register_id = $1
+ORDER BY opened_at DESC;
+
+-- name: CloseRegisterSession :one
+UPDATE Register_Sessions
+SET closed_by     = $2,
+    closed_at     = $3,
+    expected_cash = $4,
+    counted_cash  = $5,
+    z_report      = $6,
+    status        = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateCashMovement :one
+INSERT INTO Cash_Movements (session_id, type, amount, order_id, payment_id, employee_id, comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListCashMovements :many
+SELECT *
+FROM Cash_Movements
+WHERE session_id = $1
+ORDER BY created_at, id;
+
+-- name: RegisterPaymentTotals :many
+-- Оплаты заказов кассы за время смены по способам оплаты
+SELECT p.tender,
+       COUNT(*)::bigint                 AS payments,
+       COALESCE(SUM(p.amount), 0)::bigint AS amount
+FROM Payments p
+         JOIN Orders o ON o.id = p.order_id
+WHERE o.register_id = sqlc.arg(register_id)
+  AND p.status <> 'failed'
+  AND p.created_at >= sqlc.arg(date_from)
+  AND p.created_at < sqlc.arg(date_to)
+GROUP BY p.tender
+ORDER BY p.tender;
+
+-- name: RegisterRefundTotals :many
+-- Возвраты по заказам кассы, оформленные за время смены, по способам оплаты
+SELECT rt.tender,
+       COUNT(*)::bigint                  AS refunds,
+       COALESCE(SUM(rt.amount), 0)::bigint AS amount
+FROM Refund_Tenders rt
+         JOIN Refunds r ON r.id = rt.refund_id
+         JOIN Orders o ON o.id = r.order_id
+WHERE o.register_id = sqlc.arg(register_id)
+  AND r.created_at >= sqlc.arg(date_from)
+  AND r.created_at < sqlc.arg(date_to)
+GROUP BY rt.tender
+ORDER BY rt.tender;
@@ -0,0 +1,132 @@
+-- name: GetReorderPoint :one
+SELECT *
+FROM Reorder_Points
+WHERE id = $1
+LIMIT 1;
+
+-- name: FindReorderPoint :one
+SELECT *
+FROM Reorder_Points
+WHERE good_id = sqlc.arg(good_id)
+  AND store_id IS NOT DISTINCT FROM sqlc.narg(store_id)::integer
+LIMIT 1;
+
+-- name: ListReorderPoints :many
+SELECT *
+FROM Reorder_Points
+WHERE (sqlc.narg(good_id)::integer IS NULL OR good_id = sqlc.narg(good_id)::integer)
+ORDER BY good_id, store_id NULLS FIRST;
+
+-- name: CreateReorderPoint :one
+INSERT INTO Reorder_Points (good_id, store_id, min_quantity, reorder_quantity, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: UpdateReorderPoint :one
+UPDATE Reorder_Points
+SET min_quantity     = $2,
+    reorder_quantity = $3,
+    updated_at       = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReorderPoint :exec
+DELETE
+FROM Reorder_Points
+WHERE id = $1;
+
+-- name: ListReorderThresholds :many
+-- Порог по сети: общий порог товара, а если его нет — сумма порогов магазинов
+SELECT g.id                                                                   AS good_id,
+       g.quantity,
+       COALESCE(MAX(rp.min_quantity) FILTER (WHERE rp.store_id IS NULL),
+                SUM(rp.min_quantity) FILTER (WHERE rp.store_id IS NOT NULL))::integer AS min_quantity,
+       COALESCE(MAX(rp.reorder_quantity) FILTER (WHERE rp.store_id IS NULL),
+                SUM(rp.reorder_quantity) FILTER (WHERE rp.store_id IS NOT NULL))::integer AS reorder_quantity
+FROM Goods g
+         JOIN Reorder_Points rp ON rp.good_id = g.id
+WHERE g.is_alive = true
+GROUP BY g.id, g.quantity
+ORDER BY g.id;
+
+-- name: ListOnOrderQuantities :many
+-- Товар в ещё не принятых заказах поставщикам за вычетом уже привезённого по частичным приёмкам
+SELECT i.good_id,
+       COALESCE(SUM(i.quantity - COALESCE(r.received, 0)), 0)::integer AS on_order
+FROM Purchase_Order_Items i
+         JOIN Purchase_Orders p ON p.id = i.purchase_order_id
+         LEFT JOIN (SELECT purchase_order_item_id, SUM(received_quantity) AS received
+                    FROM Goods_Receipt_Items
+                    GROUP BY purchase_order_item_id) r ON r.purchase_order_item_id = i.id
+WHERE p.status IN ('draft', 'sent', 'partially_received')
+GROUP BY i.good_id;
+
+-- name: ListBestSuppliers :many
+-- Лучший поставщик товара: самая низкая закупочная цена, затем самый короткий срок поставки
+SELECT DISTINCT ON (gs.good_id) gs.good_id,
+                                gs.supplier_id,
+                                gs.purchase_price,
+                                gs.lead_time_days
+FROM Goods_Suppliers gs
+         JOIN Suppliers s ON s.id = gs.supplier_id
+WHERE gs.is_alive = true
+  AND s.is_alive = true
+ORDER BY gs.good_id, gs.purchase_price NULLS LAST, gs.lead_time_days NULLS LAST, gs.created_at, gs.id;
+
+-- name: GetBestSupplier :one
+SELECT gs.supplier_id, gs.purchase_price, gs.lead_time_days
+FROM Goods_Suppliers gs
+         JOIN Suppliers s ON s.id = gs.supplier_id
+WHERE gs.good_id = $1
+  AND gs.is_alive = true
+  AND s.is_alive = true
+ORDER BY gs.purchase_price NULLS LAST, gs.lead_time_days NULLS LAST, gs.created_at, gs.id
+LIMIT 1;
+
+-- name: ListGoodDailySales :many
+-- Продажи товара по дням в оплаченных заказах, по магазину или по всей сети
+SELECT o.created_at::date          AS day,
+       SUM(oi.quantity)::integer AS quantity
+FROM Order_Items oi
+         JOIN Orders o ON o.id = oi.order_id
+WHERE oi.good_id = sqlc.arg(good_id)
+  AND o.status = 'paid'
+  AND (sqlc.narg(store_id)::integer IS NULL OR o.store_id = sqlc.narg(store_id))
+  AND o.created_at >= sqlc.arg(date_from)
+  AND o.created_at < sqlc.arg(date_to)
+GROUP BY day
+ORDER BY day;
+
+-- name: DeleteOpenSuggestions :execrows
+DELETE
+FROM Replenishment_Suggestions
+WHERE status = 'open';
+
+-- name: CreateSuggestion :one
+INSERT INTO Replenishment_Suggestions (good_id, supplier_id, available, on_order, min_quantity, quantity, unit_cost,
+                                       status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: ListOpenSuggestions :many
+SELECT s.*,
+       g.article,
+       g.name
+FROM Replenishment_Suggestions s
+         JOIN Goods g ON g.id = s.good_id
+WHERE s.status = 'open'
+ORDER BY s.supplier_id NULLS LAST, g.name;
+
+-- name: ListSupplierSuggestionsForUpdate :many
+SELECT *
+FROM Replenishment_Suggestions
+WHERE status = 'open'
+  AND supplier_id = $1
+ORDER BY id
+FOR UPDATE;
+
+-- name: MarkSuggestionOrdered :exec
+UPDATE Replenishment_Suggestions
+SET status            = 'ordered',
+    purchase_order_id = $2
+WHERE id = $1;
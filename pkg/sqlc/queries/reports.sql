@@ -0,0 +1,116 @@
+-- name: ListGoodsStock :many
+SELECT id, category_id, quantity, cost
+FROM Goods
+ORDER BY id;
+
+-- name: ListStoreStockBalances :many
+-- Движение товара по магазинам из журнала остатков до даты
+SELECT good_id, store_id, SUM(quantity)::integer AS quantity
+FROM Stock_Adjustments
+WHERE created_at < $1
+GROUP BY good_id, store_id;
+
+-- name: ListStockMovementsSince :many
+-- Движение товара по сети начиная с даты, чтобы откатить текущий остаток назад
+SELECT good_id, SUM(quantity)::integer AS quantity
+FROM Stock_Adjustments
+WHERE created_at >= $1
+GROUP BY good_id;
+
+-- name: ListGoodCostsAt :many
+-- Последняя известная средняя себестоимость товара до даты
+SELECT DISTINCT ON (good_id) good_id, cost
+FROM Good_Costs
+WHERE created_at < $1
+ORDER BY good_id, created_at DESC, id DESC;
+
+-- name: SalesReport :many
+-- Продажи оплаченных заказов с группировкой по выбранным измерениям. Невыбранные измерения сворачиваются в NULL
+WITH lines AS (SELECT o.id AS order_id,
+                      o.created_at,
+                      o.store_id,
+                      o.employee_id,
+                      g.category_id,
+                      g.brand,
+                      oi.quantity,
+                      oi.price,
+                      oi.unit_cost
+               FROM Order_Items oi
+                        JOIN Orders o ON o.id = oi.order_id
+                        JOIN Goods g ON g.id = oi.good_id
+               WHERE o.status = 'paid'
+                 AND o.created_at >= sqlc.arg(date_from)
+                 AND o.created_at < sqlc.arg(date_to)
+               UNION ALL
+               SELECT o.id,
+                      o.created_at,
+                      o.store_id,
+                      o.employee_id,
+                      NULL::integer,
+                      NULL::text,
+                      os.quantity,
+                      os.price,
+                      NULL::decimal
+               FROM Order_Services os
+                        JOIN Orders o ON o.id = os.order_id
+               WHERE o.status = 'paid'
+                 AND o.created_at >= sqlc.arg(date_from)
+                 AND o.created_at < sqlc.arg(date_to))
+SELECT date_trunc(sqlc.narg(period)::text, created_at)::timestamp              AS period,
+       (CASE WHEN sqlc.arg(by_store)::bool THEN store_id END)::integer         AS store_id,
+       (CASE WHEN sqlc.arg(by_category)::bool THEN category_id END)::integer   AS category_id,
+       (CASE WHEN sqlc.arg(by_brand)::bool THEN brand END)::text               AS brand,
+       (CASE WHEN sqlc.arg(by_employee)::bool THEN employee_id END)::integer   AS employee_id,
+       COALESCE(SUM(quantity * price), 0)::bigint                              AS revenue,
+       COALESCE(SUM(quantity), 0)::bigint                                      AS units,
+       COALESCE(SUM(quantity * unit_cost), 0)::bigint                          AS cost,
+       COALESCE(SUM(quantity * price) FILTER (WHERE unit_cost IS NULL), 0)::bigint AS uncosted_revenue,
+       COUNT(DISTINCT order_id)::bigint                                        AS orders
+FROM lines
+WHERE (sqlc.narg(store_id)::integer IS NULL OR store_id = sqlc.narg(store_id)::integer)
+  AND (sqlc.narg(category_id)::integer IS NULL OR category_id = sqlc.narg(category_id)::integer)
+  AND (sqlc.narg(brand_filter)::text IS NULL OR brand = sqlc.narg(brand_filter)::text)
+  AND (sqlc.narg(employee_id)::integer IS NULL OR employee_id = sqlc.narg(employee_id)::integer)
+GROUP BY 1, 2, 3, 4, 5
+ORDER BY 1, 2, 3, 4, 5;
+
+-- name: ListGoodsSold :many
+-- Продано единиц товара в оплаченных заказах начиная с даты
+SELECT oi.good_id, SUM(oi.quantity)::integer AS units
+FROM Order_Items oi
+         JOIN Orders o ON o.id = oi.order_id
+WHERE o.status = 'paid'
+  AND o.created_at >= $1
+GROUP BY oi.good_id;
+
+-- name: ListDeadStock :many
+-- Товары на складе без продаж с даты, самые дорогие по замороженной себестоимости первыми
+SELECT g.id,
+       g.article,
+       g.name,
+       g.category_id,
+       g.quantity,
+       g.cost,
+       MAX(o.created_at)::timestamp AS last_sold_at
+FROM Goods g
+         LEFT JOIN Order_Items oi ON oi.good_id = g.id
+         LEFT JOIN Orders o ON o.id = oi.order_id AND o.status = 'paid'
+WHERE g.is_alive = true
+  AND g.quantity > 0
+GROUP BY g.id
+HAVING MAX(o.created_at) IS NULL
+    OR MAX(o.created_at) < $1
+ORDER BY g.quantity * COALESCE(g.cost, 0) DESC, g.id;
+
+-- name: ListGoodBucketSales :many
+-- Выручка и продажи товаров в оплаченных заказах по неделям или месяцам
+SELECT oi.good_id,
+       date_trunc(sqlc.arg(bucket)::text, o.created_at)::timestamp AS bucket,
+       SUM(oi.quantity * oi.price)::bigint                        AS revenue,
+       SUM(oi.quantity)::bigint                                   AS units
+FROM Order_Items oi
+         JOIN Orders o ON o.id = oi.order_id
+WHERE o.status = 'paid'
+  AND o.created_at >= sqlc.arg(date_from)
+  AND o.created_at < sqlc.arg(date_to)
+GROUP BY 1, 2;
@@ -0,0 +1,70 @@
+-- name: CreateReservation :one
+INSERT INTO Reservations (good_id, store_id, quantity, owner, status, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetReservation :one
+SELECT *
+FROM Reservations
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListActiveReservations :many
+SELECT *
+FROM Reservations
+WHERE status = 'active'
+  AND expires_at > now()
+ORDER BY expires_at;
+
+-- name: ListActiveReservationsByOwner :many
+SELECT *
+FROM Reservations
+WHERE owner = $1
+  AND status = 'active'
+  AND expires_at > now()
+ORDER BY expires_at;
+
+-- name: ExtendReservationsByOwner :execrows
+-- Продлевает ещё действующие резервы, например на срок авторизации карты
+UPDATE Reservations
+SET expires_at = $2
+WHERE owner = $1
+  AND status = 'active'
+  AND expires_at > now();
+
+-- name: ConsumeReservation :one
+-- Резерв превращается в продажу только пока он активен
+UPDATE Reservations
+SET status = 'consumed'
+WHERE id = $1
+  AND status = 'active'
+  AND expires_at > now()
+RETURNING *;
+
+-- name: ReleaseReservation :one
+UPDATE Reservations
+SET status = 'released'
+WHERE id = $1
+  AND status = 'active'
+RETURNING *;
+
+-- name: ExpireReservations :execrows
+UPDATE Reservations
+SET status = 'expired'
+WHERE status = 'active'
+  AND expires_at <= now();
+
+-- name: GetReservedQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::integer AS reserved
+FROM Reservations
+WHERE good_id = $1
+  AND status = 'active'
+  AND expires_at > now();
+
+-- name: ListReservedQuantities :many
+SELECT good_id,
+       COALESCE(SUM(quantity), 0)::integer AS reserved
+FROM Reservations
+WHERE status = 'active'
+  AND expires_at > now()
+GROUP BY good_id;
@@ -16,3 +16,28 @@ update roles
 set name = $2
 where id = $1
 returning *;
+
+-- name: ListRolePermissions :many
+select *
+from role_permissions
+where role_id = $1
+order by permission;
+
+-- name: GrantRolePermission :one
+insert into role_permissions (role_id, permission, created_at) VALUES ($1, $2, now())
+on conflict (role_id, permission) do update set permission = excluded.permission
+returning *;
+
+-- name: RevokeRolePermission :execrows
+delete from role_permissions
+where role_id = $1
+  and permission = $2;
+
+-- name: CountEmployeePermissions :one
+-- Право действует, пока сотрудник работает и его роль его имеет
+select count(*)::integer as granted
+from employees e
+         join role_permissions rp on rp.role_id = e.role_id
+where e.id = $1
+  and e.is_alive = true
+  and rp.permission = $2;
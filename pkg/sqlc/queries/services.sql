@@ -0,0 +1,39 @@
+-- name: CreateService :one
+INSERT INTO Services (name, description, duration_minutes, price, tax_rate_id, created_at, is_alive)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetService :one
+SELECT *
+FROM Services
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListServices :many
+SELECT *
+FROM Services
+WHERE is_alive = true
+ORDER BY name;
+
+-- name: UpdateService :one
+UPDATE Services
+SET name             = $2,
+    description      = $3,
+    duration_minutes = $4,
+    price            = $5,
+    tax_rate_id      = $6,
+    is_alive         = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteService :exec
+UPDATE Services
+SET is_alive = false
+WHERE id = $1;
+
+-- name: GetServiceTaxRate :one
+SELECT tr.*
+FROM Services s
+         JOIN Tax_Rates tr ON tr.id = s.tax_rate_id AND tr.is_alive = true
+WHERE s.id = $1
+LIMIT 1;
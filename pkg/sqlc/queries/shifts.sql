@@ -0,0 +1,90 @@
+-- name: CreateShift :one
+INSERT INTO Shifts (employee_id, store_id, starts_at, ends_at, created_at, is_alive)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetShift :one
+SELECT *
+FROM Shifts
+WHERE id = $1
+  AND is_alive = true
+LIMIT 1;
+
+-- name: GetShiftForUpdate :one
+SELECT *
+FROM Shifts
+WHERE id = $1
+  AND is_alive = true
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListStoreShifts :many
+SELECT *
+FROM Shifts
+WHERE store_id = sqlc.arg(store_id)
+  AND is_alive = true
+  AND starts_at >= sqlc.arg(date_from)
+  AND starts_at < sqlc.arg(date_to)
+ORDER BY starts_at, employee_id;
+
+-- name: ListEmployeeShifts :many
+SELECT *
+FROM Shifts
+WHERE employee_id = sqlc.arg(employee_id)
+  AND is_alive = true
+  AND starts_at >= sqlc.arg(date_from)
+  AND starts_at < sqlc.arg(date_to)
+ORDER BY starts_at;
+
+-- name: CountOverlappingShifts :one
+-- Смена с id = exclude_id не учитывается, чтобы при переносе смена не пересекалась сама с собой
+SELECT count(*)::integer AS overlapping
+FROM Shifts
+WHERE employee_id = sqlc.arg(employee_id)
+  AND id <> sqlc.arg(exclude_id)
+  AND is_alive = true
+  AND starts_at < sqlc.arg(ends_at)
+  AND ends_at > sqlc.arg(starts_at);
+
+-- name: SumShiftMinutes :one
+-- Запланированные минуты сотрудника по сменам, начинающимся в [date_from, date_to)
+SELECT COALESCE(sum(extract(epoch FROM ends_at - starts_at) / 60), 0)::integer AS minutes
+FROM Shifts
+WHERE employee_id = sqlc.arg(employee_id)
+  AND id <> sqlc.arg(exclude_id)
+  AND is_alive = true
+  AND starts_at >= sqlc.arg(date_from)
+  AND starts_at < sqlc.arg(date_to);
+
+-- name: CountEmployeeStoreAssignments :one
+SELECT count(*)::integer AS assignments
+FROM Employee_Stores
+WHERE employee_id = sqlc.arg(employee_id)
+  AND store_id = sqlc.arg(store_id)
+  AND starts_on <= sqlc.arg(on_date)
+  AND (ends_on IS NULL OR ends_on > sqlc.arg(on_date));
+
+-- name: UpdateShift :one
+UPDATE Shifts
+SET store_id  = $2,
+    starts_at = $3,
+    ends_at   = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteShift :exec
+UPDATE Shifts
+SET is_alive = false
+WHERE id = $1;
+
+-- name: ClockInShift :one
+UPDATE Shifts
+SET clock_in_at = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ClockOutShift :one
+UPDATE Shifts
+SET clock_out_at = $2
+WHERE id = $1
+RETURNING *;
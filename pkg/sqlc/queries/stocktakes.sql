@@ -0,0 +1,121 @@
+-- name: CreateStocktake :one
+INSERT INTO Stocktakes (store_id, category_id, status, comment, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetStocktake :one
+SELECT *
+FROM Stocktakes
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetStocktakeForUpdate :one
+SELECT *
+FROM Stocktakes
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListStocktakes :many
+SELECT *
+FROM Stocktakes
+ORDER BY created_at DESC;
+
+-- name: ListStoreStocktakes :many
+SELECT *
+FROM Stocktakes
+WHERE store_id = $1
+ORDER BY created_at DESC;
+
+-- name: CountOpenStocktakes :one
+-- Одновременно в магазине может идти только одна инвентаризация
+SELECT COUNT(*)::integer AS open_stocktakes
+FROM Stocktakes
+WHERE store_id = $1
+  AND status = 'counting';
+
+-- name: UpdateStocktakeStatus :one
+UPDATE Stocktakes
+SET status      = $2,
+    approved_by = $3,
+    approved_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: SnapshotStocktakeLines :execrows
+-- Ожидаемые остатки фиксируются на момент начала инвентаризации. Остаток магазина — сумма движений
+-- товара по магазину в журнале, Goods.quantity — остаток всей сети
+INSERT INTO Stocktake_Lines (stocktake_id, good_id, expected_quantity)
+SELECT sqlc.arg(stocktake_id)::integer, g.id, COALESCE(b.quantity, 0)
+FROM Goods g
+         LEFT JOIN (SELECT good_id, SUM(quantity)::integer AS quantity
+                    FROM Stock_Adjustments
+                    WHERE store_id = sqlc.arg(store_id)
+                    GROUP BY good_id) b ON b.good_id = g.id
+WHERE g.is_alive = true
+  AND (sqlc.narg(category_id)::integer IS NULL OR g.category_id = sqlc.narg(category_id)::integer);
+
+-- name: GetUnallocatedQuantity :one
+-- Остаток сети, не учтённый в журнале ни за одним магазином: товар, принятый до появления журнала
+SELECT (g.quantity - COALESCE((SELECT SUM(sa.quantity) FROM Stock_Adjustments sa WHERE sa.good_id = g.id), 0))::integer AS unallocated
+FROM Goods g
+WHERE g.id = $1;
+
+-- name: GetStocktakeLine :one
+SELECT *
+FROM Stocktake_Lines
+WHERE stocktake_id = $1
+  AND good_id = $2
+LIMIT 1;
+
+-- name: ListStocktakeLines :many
+-- Посчитанное количество — сумма подсчётов всех счётчиков, null — товар ещё не считали
+SELECT l.id,
+       l.good_id,
+       g.article,
+       g.name,
+       g.price,
+       l.expected_quantity,
+       c.counted_quantity,
+       COALESCE(c.counters, 0)::integer AS counters
+FROM Stocktake_Lines l
+         JOIN Goods g ON g.id = l.good_id
+         LEFT JOIN (SELECT good_id, SUM(quantity)::integer AS counted_quantity, COUNT(*) AS counters
+                    FROM Stocktake_Counts
+                    WHERE stocktake_id = $1
+                    GROUP BY good_id) c ON c.good_id = l.good_id
+WHERE l.stocktake_id = $1
+ORDER BY g.name;
+
+-- name: UpsertStocktakeCount :one
+-- Повторный подсчёт того же товара тем же сотрудником заменяет предыдущий
+INSERT INTO Stocktake_Counts (stocktake_id, good_id, employee_id, quantity, counted_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (stocktake_id, good_id, employee_id) DO UPDATE
+    SET quantity   = excluded.quantity,
+        counted_at = excluded.counted_at
+RETURNING *;
+
+-- name: ListStocktakeCounts :many
+SELECT *
+FROM Stocktake_Counts
+WHERE stocktake_id = $1
+ORDER BY good_id, counted_at;
+
+-- name: CreateStockAdjustment :one
+INSERT INTO Stock_Adjustments (good_id, store_id, quantity, reason, stocktake_id, write_off_id, goods_receipt_id,
+                               employee_id, comment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: ListStocktakeAdjustments :many
+SELECT *
+FROM Stock_Adjustments
+WHERE stocktake_id = $1
+ORDER BY good_id;
+
+-- name: ListGoodStockAdjustments :many
+SELECT *
+FROM Stock_Adjustments
+WHERE good_id = $1
+ORDER BY created_at DESC;
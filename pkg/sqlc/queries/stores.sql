@@ -26,4 +26,13 @@ returning *;
 -- name: DeleteStore :exec
 update stores
 set is_alive = false
-where id = $1;
\ No newline at end of file
+where id = $1;
+
+-- name: ExportStores :many
+-- Страница выгрузки магазинов после магазина с id = $1
+select *
+from stores
+where is_alive = true
+  and id > $1
+order by id
+limit $2;
\ No newline at end of file
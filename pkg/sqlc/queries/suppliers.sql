@@ -34,4 +34,62 @@ WHERE id = $1
 -- name: DeleteSupplier :exec
 UPDATE Suppliers
 SET is_alive = false
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListSupplierOrderPerformance :many
+-- Отправленные поставщику заказы за период с итогами приёмок: заказано, привезено, брак и дата последней приёмки закрытого (received) заказа
+SELECT po.id,
+       po.sent_at,
+       po.expected_at,
+       (SELECT COALESCE(SUM(poi.quantity), 0)
+        FROM Purchase_Order_Items poi
+        WHERE poi.purchase_order_id = po.id)::bigint              AS ordered,
+       COALESCE(SUM(ri.received_quantity), 0)::bigint            AS received,
+       COALESCE(SUM(ri.damaged_quantity), 0)::bigint             AS damaged,
+       COALESCE(SUM(ri.accepted_quantity), 0)::bigint            AS accepted,
+       (CASE WHEN po.status = 'received' THEN MAX(r.created_at) END)::timestamp AS completed_at
+FROM Purchase_Orders po
+         LEFT JOIN Goods_Receipts r ON r.purchase_order_id = po.id
+         LEFT JOIN Goods_Receipt_Items ri ON ri.goods_receipt_id = r.id
+WHERE po.supplier_id = sqlc.arg(supplier_id)
+  AND po.status <> 'cancelled'
+  AND po.sent_at >= sqlc.arg(date_from)
+  AND po.sent_at < sqlc.arg(date_to)
+GROUP BY po.id
+ORDER BY po.sent_at;
+
+-- name: GetSupplierClaimTotals :one
+-- Претензии поставщику по заказам, отправленным за период
+SELECT COUNT(*)::integer                  AS claims,
+       COALESCE(SUM(c.amount), 0)::decimal AS amount
+FROM Supplier_Claims c
+         JOIN Purchase_Orders po ON po.id = c.purchase_order_id
+WHERE c.supplier_id = sqlc.arg(supplier_id)
+  AND po.sent_at >= sqlc.arg(date_from)
+  AND po.sent_at < sqlc.arg(date_to);
+
+-- name: GetSupplierReturnedQuantity :one
+-- Количество возвращённого покупателями за период товара, который поставщик привозил по заказам, отправленным за тот же период
+SELECT COALESCE(SUM(ri.quantity), 0)::bigint AS returned
+FROM Refund_Items ri
+         JOIN Refunds r ON r.id = ri.refund_id
+WHERE r.created_at >= sqlc.arg(date_from)
+  AND r.created_at < sqlc.arg(date_to)
+  AND ri.good_id IN (SELECT gri.good_id
+                     FROM Goods_Receipt_Items gri
+                              JOIN Goods_Receipts gr ON gr.id = gri.goods_receipt_id
+                              JOIN Purchase_Orders po ON po.id = gr.purchase_order_id
+                     WHERE po.supplier_id = sqlc.arg(supplier_id)
+                       AND po.sent_at >= sqlc.arg(date_from)
+                       AND po.sent_at < sqlc.arg(date_to));
+
+-- name: ExportSuppliers :many
+-- Страница выгрузки поставщиков после поставщика с id = $1
+SELECT s.*,
+       a.login as account_login
+FROM Suppliers s
+         JOIN Accounts a ON s.account_id = a.id
+WHERE s.is_alive = true
+  AND s.id > $1
+ORDER BY s.id
+LIMIT $2;
\ No newline at end of file
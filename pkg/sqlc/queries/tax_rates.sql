@@ -0,0 +1,21 @@
+-- name: CreateTaxRate :one
+INSERT INTO Tax_Rates (name, rate, created_at, is_alive)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetTaxRate :one
+SELECT *
+FROM Tax_Rates
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListTaxRates :many
+SELECT *
+FROM Tax_Rates
+WHERE is_alive = true
+ORDER BY id;
+
+-- name: DeleteTaxRate :exec
+UPDATE Tax_Rates
+SET is_alive = false
+WHERE id = $1;
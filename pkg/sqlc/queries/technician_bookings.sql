@@ -0,0 +1,52 @@
+-- name: LockEmployee :one
+-- Блокировка строки сотрудника сериализует параллельные записи к одному технику
+SELECT id AS employee_id
+FROM Employees
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountOverlappingBookings :one
+SELECT count(*)::integer AS overlapping
+FROM Technician_Bookings
+WHERE employee_id = sqlc.arg(employee_id)
+  AND status = 'scheduled'
+  AND starts_at < sqlc.arg(ends_at)
+  AND ends_at > sqlc.arg(starts_at);
+
+-- name: CreateTechnicianBooking :one
+INSERT INTO Technician_Bookings (employee_id, order_id, service_id, starts_at, ends_at, address, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetTechnicianBooking :one
+SELECT *
+FROM Technician_Bookings
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetTechnicianBookingForUpdate :one
+SELECT *
+FROM Technician_Bookings
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListTechnicianBookings :many
+SELECT *
+FROM Technician_Bookings
+WHERE employee_id = sqlc.arg(employee_id)
+  AND starts_at < sqlc.arg(date_to)
+  AND ends_at > sqlc.arg(date_from)
+ORDER BY starts_at;
+
+-- name: ListTechnicianBookingsByOrder :many
+SELECT *
+FROM Technician_Bookings
+WHERE order_id = $1
+ORDER BY starts_at;
+
+-- name: UpdateTechnicianBookingStatus :one
+UPDATE Technician_Bookings
+SET status = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,67 @@
+-- name: CreateWriteOff :one
+INSERT INTO Write_Offs (store_id, reason, status, comment, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWriteOff :one
+SELECT *
+FROM Write_Offs
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetWriteOffForUpdate :one
+SELECT *
+FROM Write_Offs
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListWriteOffs :many
+SELECT *
+FROM Write_Offs
+WHERE (sqlc.narg(store_id)::integer IS NULL OR store_id = sqlc.narg(store_id)::integer)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC;
+
+-- name: UpdateWriteOffStatus :one
+UPDATE Write_Offs
+SET status      = $2,
+    approved_by = $3,
+    approved_at = $4,
+    total_cost  = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWriteOffItem :one
+INSERT INTO Write_Off_Items (write_off_id, good_id, quantity)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListWriteOffItems :many
+SELECT *
+FROM Write_Off_Items
+WHERE write_off_id = $1
+ORDER BY id;
+
+-- name: SetWriteOffItemCost :exec
+UPDATE Write_Off_Items
+SET unit_cost = $2
+WHERE id = $1;
+
+-- name: WriteOffReport :many
+-- Себестоимость списаний по магазинам и причинам, по дате утверждения. Товар без себестоимости
+-- в cost не входит и считается отдельно
+SELECT w.store_id,
+       w.reason,
+       COUNT(DISTINCT w.id)::integer              AS documents,
+       COALESCE(SUM(i.quantity), 0)::integer      AS quantity,
+       COALESCE(SUM(i.quantity * i.unit_cost), 0)::bigint AS cost,
+       COALESCE(SUM(i.quantity) FILTER (WHERE i.unit_cost IS NULL), 0)::integer AS uncosted_quantity
+FROM Write_Offs w
+         JOIN Write_Off_Items i ON i.write_off_id = w.id
+WHERE w.status = 'approved'
+  AND w.approved_at >= sqlc.arg(date_from)
+  AND w.approved_at < sqlc.arg(date_to)
+  AND (sqlc.narg(store_id)::integer IS NULL OR w.store_id = sqlc.narg(store_id)::integer)
+GROUP BY w.store_id, w.reason
+ORDER BY w.store_id, cost DESC;
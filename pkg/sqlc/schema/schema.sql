@@ -12,6 +12,13 @@ create table Roles (
                        created_at timestamp not null
 );
 
+create table Role_Permissions(
+                                  role_id integer not null references Roles(id),
+                                  permission varchar(50) not null,
+                                  created_at timestamp not null,
+                                  primary key (role_id, permission)
+);
+
 create table Accounts(
                          id serial primary key,
                          login varchar(50) not null,
@@ -34,7 +41,24 @@ create table Customers(
                           account_id integer not null references Accounts(id),
                           balance decimal not null,
                           created_at timestamp not null,
-                          is_alive bool not null
+                          is_alive bool not null,
+                          phone varchar(20),
+                          email varchar(255)
+);
+
+create table Customer_Addresses(
+                                   id serial primary key,
+                                   customer_id integer not null references Customers(id),
+                                   label varchar(50) not null,
+                                   city text not null,
+                                   street text not null,
+                                   apartment varchar(20),
+                                   floor integer,
+                                   has_elevator bool not null,
+                                   postal_code varchar(10),
+                                   is_default bool not null,
+                                   created_at timestamp not null,
+                                   is_alive bool not null
 );
 
 create table Suppliers(
@@ -44,13 +68,35 @@ create table Suppliers(
                           is_alive bool not null
 );
 
+create table Tax_Rates(
+                          id serial primary key,
+                          name varchar(50) not null,
+                          rate integer,
+                          created_at timestamp not null,
+                          is_alive bool not null
+);
+
+create table Categories(
+                           id serial primary key,
+                           name varchar(100) not null,
+                           tax_rate_id integer references Tax_Rates(id),
+                           created_at timestamp not null,
+                           is_alive bool not null,
+                           external_id text
+);
+
 create table Goods(
                       id serial primary key,
                       article text not null,
                       price decimal not null,
                       name text not null,
                       quantity integer not null,
-                      is_alive bool not null
+                      is_alive bool not null,
+                      category_id integer references Categories(id),
+                      tax_rate_id integer references Tax_Rates(id),
+                      cost decimal,
+                      brand text,
+                      external_id text
 );
 
 create table Goods_Suppliers(
@@ -58,5 +104,385 @@ create table Goods_Suppliers(
                                 supplier_id integer not null references Suppliers(id),
                                 good_id integer not null references Goods(id),
                                 created_at timestamp not null,
-                                is_alive bool not null
+                                is_alive bool not null,
+                                purchase_price decimal,
+                                lead_time_days integer
+);
+
+create table Reservations(
+                             id serial primary key,
+                             good_id integer not null references Goods(id),
+                             store_id integer not null references Stores(id),
+                             quantity integer not null,
+                             owner text not null,
+                             status varchar(20) not null,
+                             expires_at timestamp not null,
+                             created_at timestamp not null
+);
+
+create table Registers(
+                          id serial primary key,
+                          store_id integer not null references Stores(id),
+                          name varchar(100) not null,
+                          created_at timestamp not null,
+                          is_alive bool not null
+);
+
+create table Orders(
+                       id serial primary key,
+                       customer_id integer references Customers(id),
+                       store_id integer not null references Stores(id),
+                       status varchar(20) not null,
+                       payment_status varchar(20) not null,
+                       fiscal_status varchar(20) not null,
+                       fiscal_sign text,
+                       total decimal not null,
+                       created_at timestamp not null,
+                       updated_at timestamp,
+                       employee_id integer references Employees(id),
+                       register_id integer references Registers(id),
+                       exchanged_at timestamp
+);
+
+create table Order_Items(
+                            id serial primary key,
+                            order_id integer not null references Orders(id),
+                            good_id integer not null references Goods(id),
+                            quantity integer not null,
+                            price decimal not null,
+                            vat_rate integer,
+                            unit_cost decimal
+);
+
+create table Gift_Cards(
+                           id serial primary key,
+                           code varchar(50) not null unique,
+                           balance decimal not null,
+                           created_at timestamp not null,
+                           is_alive bool not null
+);
+
+create table Payments(
+                         id serial primary key,
+                         order_id integer not null references Orders(id),
+                         tender varchar(20) not null,
+                         provider varchar(50) not null,
+                         external_id text not null,
+                         gift_card_id integer references Gift_Cards(id),
+                         amount decimal not null,
+                         refunded_amount decimal not null,
+                         status varchar(20) not null,
+                         created_at timestamp not null,
+                         updated_at timestamp
+);
+
+create table Refunds(
+                        id serial primary key,
+                        order_id integer not null references Orders(id),
+                        amount decimal not null,
+                        created_at timestamp not null
+);
+
+create table Refund_Items(
+                             id serial primary key,
+                             refund_id integer not null references Refunds(id),
+                             good_id integer not null references Goods(id),
+                             quantity integer not null,
+                             price decimal not null,
+                             vat_rate integer
+);
+
+create table Refund_Tenders(
+                               id serial primary key,
+                               refund_id integer not null references Refunds(id),
+                               payment_id integer not null references Payments(id),
+                               tender varchar(20) not null,
+                               amount decimal not null,
+                               status varchar(20) not null default 'completed'
+);
+
+create table Receipts(
+                         id serial primary key,
+                         order_id integer not null references Orders(id),
+                         refund_id integer references Refunds(id),
+                         calculation_type integer not null,
+                         total decimal not null,
+                         status varchar(20) not null,
+                         document text,
+                         fiscal_document_number integer,
+                         fiscal_sign text,
+                         fn_number text,
+                         error text,
+                         created_at timestamp not null,
+                         registered_at timestamp
+);
+
+create table Delivery_Slots(
+                               id serial primary key,
+                               store_id integer not null references Stores(id),
+                               starts_at timestamp not null,
+                               ends_at timestamp not null,
+                               capacity integer not null,
+                               created_at timestamp not null
+);
+
+create table Deliveries(
+                           id serial primary key,
+                           order_id integer not null references Orders(id),
+                           slot_id integer not null references Delivery_Slots(id),
+                           courier_id integer references Employees(id),
+                           address text not null,
+                           comment text,
+                           status varchar(20) not null,
+                           created_at timestamp not null,
+                           updated_at timestamp
+);
+
+create table Services(
+                         id serial primary key,
+                         name varchar(255) not null,
+                         description text,
+                         duration_minutes integer not null,
+                         price decimal not null,
+                         tax_rate_id integer references Tax_Rates(id),
+                         created_at timestamp not null,
+                         is_alive bool not null
+);
+
+create table Order_Services(
+                               id serial primary key,
+                               order_id integer not null references Orders(id),
+                               service_id integer not null references Services(id),
+                               quantity integer not null,
+                               price decimal not null,
+                               vat_rate integer
+);
+
+create table Technician_Bookings(
+                                    id serial primary key,
+                                    employee_id integer not null references Employees(id),
+                                    order_id integer references Orders(id),
+                                    service_id integer references Services(id),
+                                    starts_at timestamp not null,
+                                    ends_at timestamp not null,
+                                    address text,
+                                    status varchar(20) not null,
+                                    created_at timestamp not null
+);
+
+create table Employee_Stores(
+                                id serial primary key,
+                                employee_id integer not null references Employees(id),
+                                store_id integer not null references Stores(id),
+                                starts_on date not null,
+                                ends_on date,
+                                created_at timestamp not null
+);
+
+create table Shifts(
+                       id serial primary key,
+                       employee_id integer not null references Employees(id),
+                       store_id integer not null references Stores(id),
+                       starts_at timestamp not null,
+                       ends_at timestamp not null,
+                       clock_in_at timestamp,
+                       clock_out_at timestamp,
+                       created_at timestamp not null,
+                       is_alive bool not null
+);
+
+create table Commission_Rules(
+                                 id serial primary key,
+                                 role_id integer references Roles(id),
+                                 category_id integer references Categories(id),
+                                 rate_bps integer not null,
+                                 created_at timestamp not null,
+                                 is_alive bool not null
+);
+
+create table Register_Sessions(
+                                  id serial primary key,
+                                  register_id integer not null references Registers(id),
+                                  opened_by integer not null references Employees(id),
+                                  opened_at timestamp not null,
+                                  opening_cash decimal not null,
+                                  closed_by integer references Employees(id),
+                                  closed_at timestamp,
+                                  expected_cash decimal,
+                                  counted_cash decimal,
+                                  z_report text,
+                                  status varchar(20) not null
+);
+
+create table Cash_Movements(
+                               id serial primary key,
+                               session_id integer not null references Register_Sessions(id),
+                               type varchar(20) not null,
+                               amount decimal not null,
+                               order_id integer references Orders(id),
+                               payment_id integer references Payments(id),
+                               employee_id integer references Employees(id),
+                               comment text,
+                               created_at timestamp not null
+);
+
+create table Stocktakes(
+                           id serial primary key,
+                           store_id integer not null references Stores(id),
+                           category_id integer references Categories(id),
+                           status varchar(20) not null,
+                           comment text,
+                           created_by integer not null references Employees(id),
+                           created_at timestamp not null,
+                           approved_by integer references Employees(id),
+                           approved_at timestamp
+);
+
+create table Stocktake_Lines(
+                                id serial primary key,
+                                stocktake_id integer not null references Stocktakes(id),
+                                good_id integer not null references Goods(id),
+                                expected_quantity integer not null,
+                                unique (stocktake_id, good_id)
+);
+
+create table Stocktake_Counts(
+                                 id serial primary key,
+                                 stocktake_id integer not null references Stocktakes(id),
+                                 good_id integer not null references Goods(id),
+                                 employee_id integer not null references Employees(id),
+                                 quantity integer not null,
+                                 counted_at timestamp not null,
+                                 unique (stocktake_id, good_id, employee_id)
+);
+
+create table Write_Offs(
+                           id serial primary key,
+                           store_id integer not null references Stores(id),
+                           reason varchar(30) not null,
+                           status varchar(20) not null,
+                           comment text,
+                           created_by integer not null references Employees(id),
+                           created_at timestamp not null,
+                           approved_by integer references Employees(id),
+                           approved_at timestamp,
+                           total_cost decimal
+);
+
+create table Write_Off_Items(
+                                id serial primary key,
+                                write_off_id integer not null references Write_Offs(id),
+                                good_id integer not null references Goods(id),
+                                quantity integer not null,
+                                unit_cost decimal
+);
+
+create table Reorder_Points(
+                               id serial primary key,
+                               good_id integer not null references Goods(id),
+                               store_id integer references Stores(id),
+                               min_quantity integer not null,
+                               reorder_quantity integer not null,
+                               created_at timestamp not null,
+                               updated_at timestamp not null
+);
+
+create table Purchase_Orders(
+                                id serial primary key,
+                                supplier_id integer not null references Suppliers(id),
+                                store_id integer not null references Stores(id),
+                                status varchar(20) not null,
+                                comment text,
+                                created_by integer references Employees(id),
+                                created_at timestamp not null,
+                                sent_at timestamp,
+                                expected_at timestamp
+);
+
+create table Purchase_Order_Items(
+                                     id serial primary key,
+                                     purchase_order_id integer not null references Purchase_Orders(id),
+                                     good_id integer not null references Goods(id),
+                                     quantity integer not null,
+                                     unit_cost decimal
+);
+
+create table Replenishment_Suggestions(
+                                          id serial primary key,
+                                          good_id integer not null references Goods(id),
+                                          supplier_id integer references Suppliers(id),
+                                          available integer not null,
+                                          on_order integer not null,
+                                          min_quantity integer not null,
+                                          quantity integer not null,
+                                          unit_cost decimal,
+                                          status varchar(20) not null,
+                                          purchase_order_id integer references Purchase_Orders(id),
+                                          created_at timestamp not null
+);
+
+create table Goods_Receipts(
+                               id serial primary key,
+                               purchase_order_id integer not null references Purchase_Orders(id),
+                               store_id integer not null references Stores(id),
+                               employee_id integer not null references Employees(id),
+                               is_final bool not null,
+                               comment text,
+                               created_at timestamp not null
+);
+
+create table Goods_Receipt_Items(
+                                    id serial primary key,
+                                    goods_receipt_id integer not null references Goods_Receipts(id),
+                                    purchase_order_item_id integer not null references Purchase_Order_Items(id),
+                                    good_id integer not null references Goods(id),
+                                    expected_quantity integer not null,
+                                    received_quantity integer not null,
+                                    damaged_quantity integer not null,
+                                    accepted_quantity integer not null,
+                                    missing_quantity integer not null
+);
+
+create table Supplier_Claims(
+                                id serial primary key,
+                                supplier_id integer not null references Suppliers(id),
+                                purchase_order_id integer not null references Purchase_Orders(id),
+                                goods_receipt_id integer not null references Goods_Receipts(id),
+                                status varchar(20) not null,
+                                amount decimal not null,
+                                resolution text,
+                                created_at timestamp not null,
+                                resolved_at timestamp
+);
+
+create table Supplier_Claim_Items(
+                                     id serial primary key,
+                                     supplier_claim_id integer not null references Supplier_Claims(id),
+                                     good_id integer not null references Goods(id),
+                                     damaged_quantity integer not null,
+                                     missing_quantity integer not null,
+                                     unit_cost decimal,
+                                     amount decimal not null
+);
+
+create table Stock_Adjustments(
+                                  id serial primary key,
+                                  good_id integer not null references Goods(id),
+                                  store_id integer not null references Stores(id),
+                                  quantity integer not null,
+                                  reason varchar(20) not null,
+                                  stocktake_id integer references Stocktakes(id),
+                                  write_off_id integer references Write_Offs(id),
+                                  goods_receipt_id integer references Goods_Receipts(id),
+                                  employee_id integer references Employees(id),
+                                  comment text,
+                                  created_at timestamp not null
+);
+
+create table Good_Costs(
+                           id serial primary key,
+                           good_id integer not null references Goods(id),
+                           cost decimal not null,
+                           goods_receipt_id integer references Goods_Receipts(id),
+                           created_at timestamp not null
 )
\ No newline at end of file